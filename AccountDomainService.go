@@ -17,7 +17,7 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -73,6 +73,22 @@ func (p *ListUsersParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListUsersParams) APIName() string {
+	return "listUsers"
+}
+
+func (p *ListUsersParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListUsersParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListUsersParams) Response() interface{} {
+	return &ListUsersResponse{}
+}
+
 func (p *ListUsersParams) SetAccount(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -171,42 +187,58 @@ func (s *AccountDomainService) NewListUsersParams() *ListUsersParams {
 
 // Lists user accounts
 func (s *AccountDomainService) ListUsers(p *ListUsersParams) (*ListUsersResponse, error) {
-	resp, err := s.cs.newRequest("listUsers", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListUsersWithContext(context.Background(), p)
+}
 
+// ListUsersWithContext is like ListUsers, but the request is bound to ctx.
+func (s *AccountDomainService) ListUsersWithContext(ctx context.Context, p *ListUsersParams) (*ListUsersResponse, error) {
 	var r ListUsersResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListUsersPager returns a Pager over the items of all pages of ListUsers.
+func (s *AccountDomainService) ListUsersPager(p *ListUsersParams) *Pager[*User] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*User, int, error) {
+		r, err := s.ListUsersWithContext(ctx, &ListUsersParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Users, r.Count, nil
+	})
+}
+
+// ListUsersAll is like ListUsersWithContext, but requests all pages and returns the items of all of them.
+func (s *AccountDomainService) ListUsersAll(ctx context.Context, p *ListUsersParams) ([]*User, error) {
+	return s.ListUsersPager(p).All(ctx)
+}
+
 type ListUsersResponse struct {
 	Count int     `json:"count"`
 	Users []*User `json:"user"`
 }
 
 type User struct {
-	Account             string `json:"account,omitempty"`
-	Accountid           string `json:"accountid,omitempty"`
-	Accounttype         int    `json:"accounttype,omitempty"`
-	Apikey              string `json:"apikey,omitempty"`
-	Created             string `json:"created,omitempty"`
-	Domain              string `json:"domain,omitempty"`
-	Domainid            string `json:"domainid,omitempty"`
-	Email               string `json:"email,omitempty"`
-	Firstname           string `json:"firstname,omitempty"`
-	Id                  string `json:"id,omitempty"`
-	Iscallerchilddomain bool   `json:"iscallerchilddomain,omitempty"`
-	Isdefault           bool   `json:"isdefault,omitempty"`
-	Lastname            string `json:"lastname,omitempty"`
-	Secretkey           string `json:"secretkey,omitempty"`
-	State               string `json:"state,omitempty"`
-	Timezone            string `json:"timezone,omitempty"`
-	Username            string `json:"username,omitempty"`
+	Account             string    `json:"account,omitempty"`
+	Accountid           string    `json:"accountid,omitempty"`
+	Accounttype         int       `json:"accounttype,omitempty"`
+	Apikey              string    `json:"apikey,omitempty"`
+	Created             Timestamp `json:"created,omitempty"`
+	Domain              string    `json:"domain,omitempty"`
+	Domainid            string    `json:"domainid,omitempty"`
+	Email               string    `json:"email,omitempty"`
+	Firstname           string    `json:"firstname,omitempty"`
+	Id                  string    `json:"id,omitempty"`
+	Iscallerchilddomain bool      `json:"iscallerchilddomain,omitempty"`
+	Isdefault           bool      `json:"isdefault,omitempty"`
+	Lastname            string    `json:"lastname,omitempty"`
+	Secretkey           string    `json:"secretkey,omitempty"`
+	State               string    `json:"state,omitempty"`
+	Timezone            string    `json:"timezone,omitempty"`
+	Username            string    `json:"username,omitempty"`
 }
 
 type ListNetworksParams struct {
@@ -224,6 +256,14 @@ func (p *ListNetworksParams) toURLValues() url.Values {
 	if v, found := p.p["keyword"]; found {
 		u.Set("keyword", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["tags"]; found {
 		i := 0
 		for k, vv := range v.(map[string]string) {
@@ -238,6 +278,22 @@ func (p *ListNetworksParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListNetworksParams) APIName() string {
+	return "listNetworks"
+}
+
+func (p *ListNetworksParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListNetworksParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListNetworksParams) Response() interface{} {
+	return &ListNetworksResponse{}
+}
+
 func (p *ListNetworksParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -254,6 +310,22 @@ func (p *ListNetworksParams) SetKeyword(v string) {
 	return
 }
 
+func (p *ListNetworksParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListNetworksParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListNetworksParams) SetTags(v map[string]string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -277,21 +349,132 @@ func (s *AccountDomainService) NewListNetworksParams() *ListNetworksParams {
 	return p
 }
 
-// Lists all available networks.
-func (s *AccountDomainService) ListNetworks(p *ListNetworksParams) (*ListNetworksResponse, error) {
-	resp, err := s.cs.newRequest("listNetworks", p.toURLValues())
+// GetNetworkID returns the ID of the network with the given name. The error is a
+// *NotFoundError if there is no such network and an *AmbiguousError if there are several.
+func (s *AccountDomainService) GetNetworkID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetNetworkIDWithContext(context.Background(), name, opts...)
+}
+
+// GetNetworkIDWithContext is like GetNetworkID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetNetworkIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetNetworkByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetNetworkByName returns the network with the given name, like GetNetworkID.
+func (s *AccountDomainService) GetNetworkByName(name string, opts ...OptionFunc) (*Network, int, error) {
+	return s.GetNetworkByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetNetworkByNameWithContext is like GetNetworkByName, but the requests are bound to ctx.
+func (s *AccountDomainService) GetNetworkByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*Network, int, error) {
+	p := &ListNetworksParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["keyword"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListNetworksAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
 	}
+	return pickByName("Network", name, l, func(v *Network) string { return v.Name }, func(v *Network) string { return v.Id })
+}
 
+// GetNetworkByID returns the network with the given ID. The error is a *NotFoundError if there
+// is no such network.
+func (s *AccountDomainService) GetNetworkByID(id string, opts ...OptionFunc) (*Network, int, error) {
+	return s.GetNetworkByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetNetworkByIDWithContext is like GetNetworkByID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetNetworkByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*Network, int, error) {
+	p := &ListNetworksParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListNetworksAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "Network", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("Network", id, l, func(v *Network) string { return v.Id })
+}
+
+// GetNetworkByTag returns the network with a tag with the given key and value. The error is a
+// *NotFoundError if there is no such network and an *AmbiguousError if there are several.
+func (s *AccountDomainService) GetNetworkByTag(key string, value string, opts ...OptionFunc) (*Network, int, error) {
+	return s.GetNetworkByTagWithContext(context.Background(), key, value, opts...)
+}
+
+// GetNetworkByTagWithContext is like GetNetworkByTag, but the requests are bound to ctx.
+func (s *AccountDomainService) GetNetworkByTagWithContext(ctx context.Context, key string, value string, opts ...OptionFunc) (*Network, int, error) {
+	p := &ListNetworksParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["tags"] = map[string]string{key: value}
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListNetworksAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByTag("Network", key, value, l, func(v *Network) []Tag { return v.Tags }, func(v *Network) string { return v.Id })
+}
+
+// Lists all available networks.
+func (s *AccountDomainService) ListNetworks(p *ListNetworksParams) (*ListNetworksResponse, error) {
+	return s.ListNetworksWithContext(context.Background(), p)
+}
+
+// ListNetworksWithContext is like ListNetworks, but the request is bound to ctx.
+func (s *AccountDomainService) ListNetworksWithContext(ctx context.Context, p *ListNetworksParams) (*ListNetworksResponse, error) {
 	var r ListNetworksResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListNetworksPager returns a Pager over the items of all pages of ListNetworks.
+func (s *AccountDomainService) ListNetworksPager(p *ListNetworksParams) *Pager[*Network] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Network, int, error) {
+		r, err := s.ListNetworksWithContext(ctx, &ListNetworksParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Networks, r.Count, nil
+	})
+}
+
+// ListNetworksAll is like ListNetworksWithContext, but requests all pages and returns the items of all of them.
+func (s *AccountDomainService) ListNetworksAll(ctx context.Context, p *ListNetworksParams) ([]*Network, error) {
+	return s.ListNetworksPager(p).All(ctx)
+}
+
 type ListNetworksResponse struct {
 	Count    int        `json:"count"`
 	Networks []*Network `json:"network"`
@@ -414,6 +597,22 @@ func (p *ListServiceOfferingsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListServiceOfferingsParams) APIName() string {
+	return "listServiceOfferings"
+}
+
+func (p *ListServiceOfferingsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListServiceOfferingsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListServiceOfferingsParams) Response() interface{} {
+	return &ListServiceOfferingsResponse{}
+}
+
 func (p *ListServiceOfferingsParams) SetDomainid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -510,30 +709,115 @@ func (s *AccountDomainService) NewListServiceOfferingsParams() *ListServiceOffer
 	return p
 }
 
-// Lists all available service offerings.
-func (s *AccountDomainService) ListServiceOfferings(p *ListServiceOfferingsParams) (*ListServiceOfferingsResponse, error) {
-	resp, err := s.cs.newRequest("listServiceOfferings", p.toURLValues())
+// GetServiceOfferingID returns the ID of the service offering with the given name. The error is a
+// *NotFoundError if there is no such service offering and an *AmbiguousError if there are several.
+func (s *AccountDomainService) GetServiceOfferingID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetServiceOfferingIDWithContext(context.Background(), name, opts...)
+}
+
+// GetServiceOfferingIDWithContext is like GetServiceOfferingID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetServiceOfferingIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetServiceOfferingByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
 	}
+	return r.Id, count, nil
+}
 
+// GetServiceOfferingByName returns the service offering with the given name, like GetServiceOfferingID.
+func (s *AccountDomainService) GetServiceOfferingByName(name string, opts ...OptionFunc) (*ServiceOffering, int, error) {
+	return s.GetServiceOfferingByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetServiceOfferingByNameWithContext is like GetServiceOfferingByName, but the requests are bound to ctx.
+func (s *AccountDomainService) GetServiceOfferingByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*ServiceOffering, int, error) {
+	p := &ListServiceOfferingsParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListServiceOfferingsAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("ServiceOffering", name, l, func(v *ServiceOffering) string { return v.Name }, func(v *ServiceOffering) string { return v.Id })
+}
+
+// GetServiceOfferingByID returns the service offering with the given ID. The error is a *NotFoundError if there
+// is no such service offering.
+func (s *AccountDomainService) GetServiceOfferingByID(id string, opts ...OptionFunc) (*ServiceOffering, int, error) {
+	return s.GetServiceOfferingByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetServiceOfferingByIDWithContext is like GetServiceOfferingByID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetServiceOfferingByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*ServiceOffering, int, error) {
+	p := &ListServiceOfferingsParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListServiceOfferingsAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "ServiceOffering", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("ServiceOffering", id, l, func(v *ServiceOffering) string { return v.Id })
+}
+
+// Lists all available service offerings.
+func (s *AccountDomainService) ListServiceOfferings(p *ListServiceOfferingsParams) (*ListServiceOfferingsResponse, error) {
+	return s.ListServiceOfferingsWithContext(context.Background(), p)
+}
+
+// ListServiceOfferingsWithContext is like ListServiceOfferings, but the request is bound to ctx.
+func (s *AccountDomainService) ListServiceOfferingsWithContext(ctx context.Context, p *ListServiceOfferingsParams) (*ListServiceOfferingsResponse, error) {
 	var r ListServiceOfferingsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListServiceOfferingsPager returns a Pager over the items of all pages of ListServiceOfferings.
+func (s *AccountDomainService) ListServiceOfferingsPager(p *ListServiceOfferingsParams) *Pager[*ServiceOffering] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*ServiceOffering, int, error) {
+		r, err := s.ListServiceOfferingsWithContext(ctx, &ListServiceOfferingsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.ServiceOfferings, r.Count, nil
+	})
+}
+
+// ListServiceOfferingsAll is like ListServiceOfferingsWithContext, but requests all pages and returns the items of all of them.
+func (s *AccountDomainService) ListServiceOfferingsAll(ctx context.Context, p *ListServiceOfferingsParams) ([]*ServiceOffering, error) {
+	return s.ListServiceOfferingsPager(p).All(ctx)
+}
+
 type ListServiceOfferingsResponse struct {
 	Count            int                `json:"count"`
 	ServiceOfferings []*ServiceOffering `json:"serviceoffering"`
 }
 
 type ServiceOffering struct {
-	Cpunumber                 int               `json:"cpunumber,omitempty"`
-	Cpuspeed                  int               `json:"cpuspeed,omitempty"`
-	Created                   string            `json:"created,omitempty"`
+	Cpunumber                 FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed                  FlexInt           `json:"cpuspeed,omitempty"`
+	Created                   Timestamp         `json:"created,omitempty"`
 	Defaultuse                bool              `json:"defaultuse,omitempty"`
 	Deploymentplanner         string            `json:"deploymentplanner,omitempty"`
 	DiskBytesReadRate         int64             `json:"diskBytesReadRate,omitempty"`
@@ -552,7 +836,7 @@ type ServiceOffering struct {
 	Isvolatile                bool              `json:"isvolatile,omitempty"`
 	Limitcpuuse               bool              `json:"limitcpuuse,omitempty"`
 	Maxiops                   int64             `json:"maxiops,omitempty"`
-	Memory                    int               `json:"memory,omitempty"`
+	Memory                    FlexInt           `json:"memory,omitempty"`
 	Miniops                   int64             `json:"miniops,omitempty"`
 	Name                      string            `json:"name,omitempty"`
 	Networkrate               int               `json:"networkrate,omitempty"`
@@ -604,6 +888,22 @@ func (p *ListDiskOfferingsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListDiskOfferingsParams) APIName() string {
+	return "listDiskOfferings"
+}
+
+func (p *ListDiskOfferingsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListDiskOfferingsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListDiskOfferingsParams) Response() interface{} {
+	return &ListDiskOfferingsResponse{}
+}
+
 func (p *ListDiskOfferingsParams) SetDomainid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -676,48 +976,133 @@ func (s *AccountDomainService) NewListDiskOfferingsParams() *ListDiskOfferingsPa
 	return p
 }
 
-// Lists all available disk offerings.
-func (s *AccountDomainService) ListDiskOfferings(p *ListDiskOfferingsParams) (*ListDiskOfferingsResponse, error) {
-	resp, err := s.cs.newRequest("listDiskOfferings", p.toURLValues())
+// GetDiskOfferingID returns the ID of the disk offering with the given name. The error is a
+// *NotFoundError if there is no such disk offering and an *AmbiguousError if there are several.
+func (s *AccountDomainService) GetDiskOfferingID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetDiskOfferingIDWithContext(context.Background(), name, opts...)
+}
+
+// GetDiskOfferingIDWithContext is like GetDiskOfferingID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetDiskOfferingIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetDiskOfferingByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetDiskOfferingByName returns the disk offering with the given name, like GetDiskOfferingID.
+func (s *AccountDomainService) GetDiskOfferingByName(name string, opts ...OptionFunc) (*DiskOffering, int, error) {
+	return s.GetDiskOfferingByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetDiskOfferingByNameWithContext is like GetDiskOfferingByName, but the requests are bound to ctx.
+func (s *AccountDomainService) GetDiskOfferingByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*DiskOffering, int, error) {
+	p := &ListDiskOfferingsParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
 	}
 
+	l, err := s.ListDiskOfferingsAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("DiskOffering", name, l, func(v *DiskOffering) string { return v.Name }, func(v *DiskOffering) string { return v.Id })
+}
+
+// GetDiskOfferingByID returns the disk offering with the given ID. The error is a *NotFoundError if there
+// is no such disk offering.
+func (s *AccountDomainService) GetDiskOfferingByID(id string, opts ...OptionFunc) (*DiskOffering, int, error) {
+	return s.GetDiskOfferingByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetDiskOfferingByIDWithContext is like GetDiskOfferingByID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetDiskOfferingByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*DiskOffering, int, error) {
+	p := &ListDiskOfferingsParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListDiskOfferingsAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "DiskOffering", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("DiskOffering", id, l, func(v *DiskOffering) string { return v.Id })
+}
+
+// Lists all available disk offerings.
+func (s *AccountDomainService) ListDiskOfferings(p *ListDiskOfferingsParams) (*ListDiskOfferingsResponse, error) {
+	return s.ListDiskOfferingsWithContext(context.Background(), p)
+}
+
+// ListDiskOfferingsWithContext is like ListDiskOfferings, but the request is bound to ctx.
+func (s *AccountDomainService) ListDiskOfferingsWithContext(ctx context.Context, p *ListDiskOfferingsParams) (*ListDiskOfferingsResponse, error) {
 	var r ListDiskOfferingsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListDiskOfferingsPager returns a Pager over the items of all pages of ListDiskOfferings.
+func (s *AccountDomainService) ListDiskOfferingsPager(p *ListDiskOfferingsParams) *Pager[*DiskOffering] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*DiskOffering, int, error) {
+		r, err := s.ListDiskOfferingsWithContext(ctx, &ListDiskOfferingsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.DiskOfferings, r.Count, nil
+	})
+}
+
+// ListDiskOfferingsAll is like ListDiskOfferingsWithContext, but requests all pages and returns the items of all of them.
+func (s *AccountDomainService) ListDiskOfferingsAll(ctx context.Context, p *ListDiskOfferingsParams) ([]*DiskOffering, error) {
+	return s.ListDiskOfferingsPager(p).All(ctx)
+}
+
 type ListDiskOfferingsResponse struct {
 	Count         int             `json:"count"`
 	DiskOfferings []*DiskOffering `json:"diskoffering"`
 }
 
 type DiskOffering struct {
-	CacheMode                 string `json:"cacheMode,omitempty"`
-	Created                   string `json:"created,omitempty"`
-	DiskBytesReadRate         int64  `json:"diskBytesReadRate,omitempty"`
-	DiskBytesWriteRate        int64  `json:"diskBytesWriteRate,omitempty"`
-	DiskIopsReadRate          int64  `json:"diskIopsReadRate,omitempty"`
-	DiskIopsWriteRate         int64  `json:"diskIopsWriteRate,omitempty"`
-	Disksize                  int64  `json:"disksize,omitempty"`
-	Displayoffering           bool   `json:"displayoffering,omitempty"`
-	Displaytext               string `json:"displaytext,omitempty"`
-	Domain                    string `json:"domain,omitempty"`
-	Domainid                  string `json:"domainid,omitempty"`
-	Hypervisorsnapshotreserve int    `json:"hypervisorsnapshotreserve,omitempty"`
-	Id                        string `json:"id,omitempty"`
-	Iscustomized              bool   `json:"iscustomized,omitempty"`
-	Iscustomizediops          bool   `json:"iscustomizediops,omitempty"`
-	Maxiops                   int64  `json:"maxiops,omitempty"`
-	Miniops                   int64  `json:"miniops,omitempty"`
-	Name                      string `json:"name,omitempty"`
-	Provisioningtype          string `json:"provisioningtype,omitempty"`
-	Storagetype               string `json:"storagetype,omitempty"`
-	Tags                      string `json:"tags,omitempty"`
+	CacheMode                 string    `json:"cacheMode,omitempty"`
+	Created                   Timestamp `json:"created,omitempty"`
+	DiskBytesReadRate         int64     `json:"diskBytesReadRate,omitempty"`
+	DiskBytesWriteRate        int64     `json:"diskBytesWriteRate,omitempty"`
+	DiskIopsReadRate          int64     `json:"diskIopsReadRate,omitempty"`
+	DiskIopsWriteRate         int64     `json:"diskIopsWriteRate,omitempty"`
+	Disksize                  int64     `json:"disksize,omitempty"`
+	Displayoffering           bool      `json:"displayoffering,omitempty"`
+	Displaytext               string    `json:"displaytext,omitempty"`
+	Domain                    string    `json:"domain,omitempty"`
+	Domainid                  string    `json:"domainid,omitempty"`
+	Hypervisorsnapshotreserve int       `json:"hypervisorsnapshotreserve,omitempty"`
+	Id                        string    `json:"id,omitempty"`
+	Iscustomized              bool      `json:"iscustomized,omitempty"`
+	Iscustomizediops          bool      `json:"iscustomizediops,omitempty"`
+	Maxiops                   int64     `json:"maxiops,omitempty"`
+	Miniops                   int64     `json:"miniops,omitempty"`
+	Name                      string    `json:"name,omitempty"`
+	Provisioningtype          string    `json:"provisioningtype,omitempty"`
+	Storagetype               string    `json:"storagetype,omitempty"`
+	Tags                      string    `json:"tags,omitempty"`
 }
 
 type ListZonesParams struct {
@@ -771,6 +1156,22 @@ func (p *ListZonesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListZonesParams) APIName() string {
+	return "listZones"
+}
+
+func (p *ListZonesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListZonesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListZonesParams) Response() interface{} {
+	return &ListZonesResponse{}
+}
+
 func (p *ListZonesParams) SetAvailable(v bool) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -859,21 +1260,132 @@ func (s *AccountDomainService) NewListZonesParams() *ListZonesParams {
 	return p
 }
 
-// Lists zones
-func (s *AccountDomainService) ListZones(p *ListZonesParams) (*ListZonesResponse, error) {
-	resp, err := s.cs.newRequest("listZones", p.toURLValues())
+// GetZoneID returns the ID of the zone with the given name. The error is a
+// *NotFoundError if there is no such zone and an *AmbiguousError if there are several.
+func (s *AccountDomainService) GetZoneID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetZoneIDWithContext(context.Background(), name, opts...)
+}
+
+// GetZoneIDWithContext is like GetZoneID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetZoneIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetZoneByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetZoneByName returns the zone with the given name, like GetZoneID.
+func (s *AccountDomainService) GetZoneByName(name string, opts ...OptionFunc) (*Zone, int, error) {
+	return s.GetZoneByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetZoneByNameWithContext is like GetZoneByName, but the requests are bound to ctx.
+func (s *AccountDomainService) GetZoneByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*Zone, int, error) {
+	p := &ListZonesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListZonesAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("Zone", name, l, func(v *Zone) string { return v.Name }, func(v *Zone) string { return v.Id })
+}
+
+// GetZoneByID returns the zone with the given ID. The error is a *NotFoundError if there
+// is no such zone.
+func (s *AccountDomainService) GetZoneByID(id string, opts ...OptionFunc) (*Zone, int, error) {
+	return s.GetZoneByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetZoneByIDWithContext is like GetZoneByID, but the requests are bound to ctx.
+func (s *AccountDomainService) GetZoneByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*Zone, int, error) {
+	p := &ListZonesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
 	}
 
+	l, err := s.ListZonesAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "Zone", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("Zone", id, l, func(v *Zone) string { return v.Id })
+}
+
+// GetZoneByTag returns the zone with a tag with the given key and value. The error is a
+// *NotFoundError if there is no such zone and an *AmbiguousError if there are several.
+func (s *AccountDomainService) GetZoneByTag(key string, value string, opts ...OptionFunc) (*Zone, int, error) {
+	return s.GetZoneByTagWithContext(context.Background(), key, value, opts...)
+}
+
+// GetZoneByTagWithContext is like GetZoneByTag, but the requests are bound to ctx.
+func (s *AccountDomainService) GetZoneByTagWithContext(ctx context.Context, key string, value string, opts ...OptionFunc) (*Zone, int, error) {
+	p := &ListZonesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["tags"] = map[string]string{key: value}
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListZonesAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByTag("Zone", key, value, l, func(v *Zone) []Tag { return v.Tags }, func(v *Zone) string { return v.Id })
+}
+
+// Lists zones
+func (s *AccountDomainService) ListZones(p *ListZonesParams) (*ListZonesResponse, error) {
+	return s.ListZonesWithContext(context.Background(), p)
+}
+
+// ListZonesWithContext is like ListZones, but the request is bound to ctx.
+func (s *AccountDomainService) ListZonesWithContext(ctx context.Context, p *ListZonesParams) (*ListZonesResponse, error) {
 	var r ListZonesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListZonesPager returns a Pager over the items of all pages of ListZones.
+func (s *AccountDomainService) ListZonesPager(p *ListZonesParams) *Pager[*Zone] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Zone, int, error) {
+		r, err := s.ListZonesWithContext(ctx, &ListZonesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Zones, r.Count, nil
+	})
+}
+
+// ListZonesAll is like ListZonesWithContext, but requests all pages and returns the items of all of them.
+func (s *AccountDomainService) ListZonesAll(ctx context.Context, p *ListZonesParams) ([]*Zone, error) {
+	return s.ListZonesPager(p).All(ctx)
+}
+
 type ListZonesResponse struct {
 	Count int     `json:"count"`
 	Zones []*Zone `json:"zone"`
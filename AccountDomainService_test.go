@@ -0,0 +1,50 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"testing"
+)
+
+func TestGetNetworkAndZoneByTag(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true))
+
+	p := cs.Tags.NewCreateTagsParams([]string{srv.NetworkID}, "Network", map[string]string{"tier": "front"})
+	if _, err := cs.Tags.CreateTags(p); err != nil {
+		t.Fatalf("CreateTags: %v", err)
+	}
+	p = cs.Tags.NewCreateTagsParams([]string{srv.ZoneID}, "Zone", map[string]string{"region": "east"})
+	if _, err := cs.Tags.CreateTags(p); err != nil {
+		t.Fatalf("CreateTags: %v", err)
+	}
+
+	n, count, err := cs.AccountDomain.GetNetworkByTag("tier", "front", WithZoneID(srv.ZoneID))
+	if err != nil || count != 1 || n.Id != srv.NetworkID {
+		t.Errorf("GetNetworkByTag = %v, %d, %v, want %s", n, count, err, srv.NetworkID)
+	}
+	if _, _, err := cs.AccountDomain.GetNetworkByTag("tier", "back"); !IsNotFound(err) {
+		t.Errorf("err = %v, want a not found error", err)
+	}
+
+	z, count, err := cs.AccountDomain.GetZoneByTag("region", "east")
+	if err != nil || count != 1 || z.Id != srv.ZoneID {
+		t.Errorf("GetZoneByTag = %v, %d, %v, want %s", z, count, err, srv.ZoneID)
+	}
+	if _, _, err := cs.AccountDomain.GetZoneByTag("region", "west"); !IsNotFound(err) {
+		t.Errorf("err = %v, want a not found error", err)
+	}
+}
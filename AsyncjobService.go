@@ -17,10 +17,10 @@
 package gokcps
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 type QueryAsyncJobResultParams struct {
@@ -43,6 +43,22 @@ func (p *QueryAsyncJobResultParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *QueryAsyncJobResultParams) APIName() string {
+	return "queryAsyncJobResult"
+}
+
+func (p *QueryAsyncJobResultParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *QueryAsyncJobResultParams) IsAsync() bool {
+	return false
+}
+
+func (p *QueryAsyncJobResultParams) Response() interface{} {
+	return &QueryAsyncJobResultResponse{}
+}
+
 // For KDDI
 func (p *QueryExAsyncJobResultParams) toURLValues() url.Values {
 	u := url.Values{}
@@ -55,6 +71,22 @@ func (p *QueryExAsyncJobResultParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *QueryExAsyncJobResultParams) APIName() string {
+	return "queryExAsyncJobResult"
+}
+
+func (p *QueryExAsyncJobResultParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *QueryExAsyncJobResultParams) IsAsync() bool {
+	return false
+}
+
+func (p *QueryExAsyncJobResultParams) Response() interface{} {
+	return &QueryExAsyncJobResultResponse{}
+}
+
 func (p *QueryAsyncJobResultParams) SetJobid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -90,23 +122,14 @@ func (s *AsyncjobService) NewQueryExAsyncJobResultParams(jobid string) *QueryExA
 
 // Retrieves the current status of asynchronous job.
 func (s *AsyncjobService) QueryAsyncJobResult(p *QueryAsyncJobResultParams) (*QueryAsyncJobResultResponse, error) {
-	var resp json.RawMessage
-	var err error
-
-	// We should be able to retry on failure as this call is idempotent
-	for i := 0; i < 10; i++ {
-		resp, err = s.cs.newRequest("queryAsyncJobResult", p.toURLValues())
-		if err == nil {
-			break
-		}
-		time.Sleep(500 * time.Millisecond)
-	}
-	if err != nil {
-		return nil, err
-	}
+	return s.QueryAsyncJobResultWithContext(context.Background(), p)
+}
 
+// QueryAsyncJobResultWithContext is like QueryAsyncJobResult, but the request is bound to ctx.
+func (s *AsyncjobService) QueryAsyncJobResultWithContext(ctx context.Context, p *QueryAsyncJobResultParams) (*QueryAsyncJobResultResponse, error) {
+	// Transient failures are retried by the retry policy, as this call is idempotent
 	var r QueryAsyncJobResultResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -115,23 +138,14 @@ func (s *AsyncjobService) QueryAsyncJobResult(p *QueryAsyncJobResultParams) (*Qu
 
 // For KDDI
 func (s *AsyncjobService) QueryExAsyncJobResult(p *QueryExAsyncJobResultParams) (*QueryExAsyncJobResultResponse, error) {
-	var resp json.RawMessage
-	var err error
-
-	// We should be able to retry on failure as this call is idempotent
-	for i := 0; i < 10; i++ {
-		resp, err = s.cs.newRequest("queryExAsyncJobResult", p.toURLValues())
-		if err == nil {
-			break
-		}
-		time.Sleep(500 * time.Millisecond)
-	}
-	if err != nil {
-		return nil, err
-	}
+	return s.QueryExAsyncJobResultWithContext(context.Background(), p)
+}
 
+// QueryExAsyncJobResultWithContext is like QueryExAsyncJobResult, but the request is bound to ctx.
+func (s *AsyncjobService) QueryExAsyncJobResultWithContext(ctx context.Context, p *QueryExAsyncJobResultParams) (*QueryExAsyncJobResultResponse, error) {
+	// Transient failures are retried by the retry policy, as this call is idempotent
 	var r QueryExAsyncJobResultResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -141,7 +155,7 @@ func (s *AsyncjobService) QueryExAsyncJobResult(p *QueryExAsyncJobResultParams)
 type QueryAsyncJobResultResponse struct {
 	Accountid       string          `json:"accountid,omitempty"`
 	Cmd             string          `json:"cmd,omitempty"`
-	Created         string          `json:"created,omitempty"`
+	Created         Timestamp       `json:"created,omitempty"`
 	Jobinstanceid   string          `json:"jobinstanceid,omitempty"`
 	Jobinstancetype string          `json:"jobinstancetype,omitempty"`
 	Jobprocstatus   int             `json:"jobprocstatus,omitempty"`
@@ -156,7 +170,7 @@ type QueryAsyncJobResultResponse struct {
 type QueryExAsyncJobResultResponse struct {
 	Accountid       string          `json:"accountid,omitempty"`
 	Cmd             string          `json:"cmd,omitempty"`
-	Created         string          `json:"created,omitempty"`
+	Created         Timestamp       `json:"created,omitempty"`
 	Jobinstanceid   string          `json:"jobinstanceid,omitempty"`
 	Jobinstancetype string          `json:"jobinstancetype,omitempty"`
 	Jobprocstatus   int             `json:"jobprocstatus,omitempty"`
@@ -207,6 +221,22 @@ func (p *ListAsyncJobsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListAsyncJobsParams) APIName() string {
+	return "listAsyncJobs"
+}
+
+func (p *ListAsyncJobsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListAsyncJobsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListAsyncJobsParams) Response() interface{} {
+	return &ListAsyncJobsResponse{}
+}
+
 func (p *ListAsyncJobsParams) SetAccount(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -281,28 +311,45 @@ func (s *AsyncjobService) NewListAsyncJobsParams() *ListAsyncJobsParams {
 
 // Lists all pending asynchronous jobs for the account.
 func (s *AsyncjobService) ListAsyncJobs(p *ListAsyncJobsParams) (*ListAsyncJobsResponse, error) {
-	resp, err := s.cs.newRequest("listAsyncJobs", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListAsyncJobsWithContext(context.Background(), p)
+}
 
+// ListAsyncJobsWithContext is like ListAsyncJobs, but the request is bound to ctx.
+func (s *AsyncjobService) ListAsyncJobsWithContext(ctx context.Context, p *ListAsyncJobsParams) (*ListAsyncJobsResponse, error) {
 	var r ListAsyncJobsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListAsyncJobsPager returns a Pager over the items of all pages of ListAsyncJobs.
+func (s *AsyncjobService) ListAsyncJobsPager(p *ListAsyncJobsParams) *Pager[*AsyncJob] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*AsyncJob, int, error) {
+		r, err := s.ListAsyncJobsWithContext(ctx, &ListAsyncJobsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.AsyncJobs, r.Count, nil
+	})
+}
+
+// ListAsyncJobsAll is like ListAsyncJobsWithContext, but requests all pages and returns the items of all of them.
+func (s *AsyncjobService) ListAsyncJobsAll(ctx context.Context, p *ListAsyncJobsParams) ([]*AsyncJob, error) {
+	return s.ListAsyncJobsPager(p).All(ctx)
+}
+
 type ListAsyncJobsResponse struct {
 	Count     int         `json:"count"`
 	AsyncJobs []*AsyncJob `json:"asyncjobs"`
 }
 
 type AsyncJob struct {
+	Jobid           string          `json:"jobid,omitempty"`
 	Accountid       string          `json:"accountid,omitempty"`
 	Cmd             string          `json:"cmd,omitempty"`
-	Created         string          `json:"created,omitempty"`
+	Created         Timestamp       `json:"created,omitempty"`
 	Jobinstanceid   string          `json:"jobinstanceid,omitempty"`
 	Jobinstancetype string          `json:"jobinstancetype,omitempty"`
 	Jobprocstatus   int             `json:"jobprocstatus,omitempty"`
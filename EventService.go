@@ -17,8 +17,9 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -40,6 +41,14 @@ func (p *ListEventsParams) toURLValues() url.Values {
 	if v, found := p.p["level"]; found {
 		u.Set("level", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["startdate"]; found {
 		u.Set("startdate", v.(string))
 	}
@@ -52,6 +61,22 @@ func (p *ListEventsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListEventsParams) APIName() string {
+	return "listEvents"
+}
+
+func (p *ListEventsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListEventsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListEventsParams) Response() interface{} {
+	return &ListEventsResponse{}
+}
+
 func (p *ListEventsParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -75,6 +100,23 @@ func (p *ListEventsParams) SetLevel(v string) {
 	p.p["level"] = v
 	return
 }
+
+func (p *ListEventsParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListEventsParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListEventsParams) SetStartdate(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -109,38 +151,54 @@ func (s *EventService) NewListEventsParams() *ListEventsParams {
 
 // A command to list events.
 func (s *EventService) ListEvents(p *ListEventsParams) (*ListEventsResponse, error) {
-	resp, err := s.cs.newRequest("listEvents", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListEventsWithContext(context.Background(), p)
+}
 
+// ListEventsWithContext is like ListEvents, but the request is bound to ctx.
+func (s *EventService) ListEventsWithContext(ctx context.Context, p *ListEventsParams) (*ListEventsResponse, error) {
 	var r ListEventsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListEventsPager returns a Pager over the items of all pages of ListEvents.
+func (s *EventService) ListEventsPager(p *ListEventsParams) *Pager[*Event] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Event, int, error) {
+		r, err := s.ListEventsWithContext(ctx, &ListEventsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Events, r.Count, nil
+	})
+}
+
+// ListEventsAll is like ListEventsWithContext, but requests all pages and returns the items of all of them.
+func (s *EventService) ListEventsAll(ctx context.Context, p *ListEventsParams) ([]*Event, error) {
+	return s.ListEventsPager(p).All(ctx)
+}
+
 type ListEventsResponse struct {
 	Count  int      `json:"count"`
 	Events []*Event `json:"event"`
 }
 
 type Event struct {
-	Account     string `json:"account,omitempty"`
-	Created     string `json:"created,omitempty"`
-	Description string `json:"description,omitempty"`
-	Domain      string `json:"domain,omitempty"`
-	Domainid    string `json:"domainid,omitempty"`
-	Id          string `json:"id,omitempty"`
-	Level       string `json:"level,omitempty"`
-	Parentid    string `json:"parentid,omitempty"`
-	Project     string `json:"project,omitempty"`
-	Projectid   string `json:"projectid,omitempty"`
-	State       string `json:"state,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Username    string `json:"username,omitempty"`
+	Account     string    `json:"account,omitempty"`
+	Created     Timestamp `json:"created,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Domain      string    `json:"domain,omitempty"`
+	Domainid    string    `json:"domainid,omitempty"`
+	Id          string    `json:"id,omitempty"`
+	Level       string    `json:"level,omitempty"`
+	Parentid    string    `json:"parentid,omitempty"`
+	Project     string    `json:"project,omitempty"`
+	Projectid   string    `json:"projectid,omitempty"`
+	State       string    `json:"state,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	Username    string    `json:"username,omitempty"`
 }
 
 type ListEventTypesParams struct {
@@ -155,6 +213,22 @@ func (p *ListEventTypesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListEventTypesParams) APIName() string {
+	return "listEventTypes"
+}
+
+func (p *ListEventTypesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListEventTypesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListEventTypesParams) Response() interface{} {
+	return &ListEventTypesResponse{}
+}
+
 // You should always use this function to get a new ListEventTypesParams instance,
 // as then you are sure you have configured all required params
 func (s *EventService) NewListEventTypesParams() *ListEventTypesParams {
@@ -165,19 +239,36 @@ func (s *EventService) NewListEventTypesParams() *ListEventTypesParams {
 
 // List Event Types
 func (s *EventService) ListEventTypes(p *ListEventTypesParams) (*ListEventTypesResponse, error) {
-	resp, err := s.cs.newRequest("listEventTypes", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListEventTypesWithContext(context.Background(), p)
+}
 
+// ListEventTypesWithContext is like ListEventTypes, but the request is bound to ctx.
+func (s *EventService) ListEventTypesWithContext(ctx context.Context, p *ListEventTypesParams) (*ListEventTypesResponse, error) {
 	var r ListEventTypesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListEventTypesPager returns a Pager over the items of ListEventTypes, which does not support paging and
+// returns all items at once.
+func (s *EventService) ListEventTypesPager(p *ListEventTypesParams) *Pager[*EventType] {
+	return newUnpagedPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*EventType, int, error) {
+		r, err := s.ListEventTypesWithContext(ctx, &ListEventTypesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.EventTypes, r.Count, nil
+	})
+}
+
+// ListEventTypesAll is like ListEventTypesWithContext, but returns the items only.
+func (s *EventService) ListEventTypesAll(ctx context.Context, p *ListEventTypesParams) ([]*EventType, error) {
+	return s.ListEventTypesPager(p).All(ctx)
+}
+
 type ListEventTypesResponse struct {
 	Count      int          `json:"count"`
 	EventTypes []*EventType `json:"eventtype"`
@@ -212,6 +303,22 @@ func (p *DeleteEventsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteEventsParams) APIName() string {
+	return "deleteEvents"
+}
+
+func (p *DeleteEventsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteEventsParams) IsAsync() bool {
+	return false
+}
+
+func (p *DeleteEventsParams) Response() interface{} {
+	return &DeleteEventsResponse{}
+}
+
 func (p *DeleteEventsParams) SetEnddate(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -254,13 +361,13 @@ func (s *EventService) NewDeleteEventsParams() *DeleteEventsParams {
 
 // Delete one or more events.
 func (s *EventService) DeleteEvents(p *DeleteEventsParams) (*DeleteEventsResponse, error) {
-	resp, err := s.cs.newRequest("deleteEvents", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteEventsWithContext(context.Background(), p)
+}
 
+// DeleteEventsWithContext is like DeleteEvents, but the request is bound to ctx.
+func (s *EventService) DeleteEventsWithContext(ctx context.Context, p *DeleteEventsParams) (*DeleteEventsResponse, error) {
 	var r DeleteEventsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -268,5 +375,5 @@ func (s *EventService) DeleteEvents(p *DeleteEventsParams) (*DeleteEventsRespons
 }
 
 type DeleteEventsResponse struct {
-	Success string `json:"success,omitempty"`
+	Success FlexBool `json:"success,omitempty"`
 }
@@ -17,62 +17,13 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/url"
 	"strconv"
 	"strings"
 )
 
-// Helper function for maintaining backwards compatibility
-func convertFirewallServiceResponse(b []byte) ([]byte, error) {
-	var raw map[string]interface{}
-	if err := json.Unmarshal(b, &raw); err != nil {
-		return nil, err
-	}
-
-	if _, ok := raw["firewallrule"]; ok {
-		return convertFirewallServiceListResponse(b)
-	}
-
-	for _, k := range []string{"endport", "startport"} {
-		if sVal, ok := raw[k].(string); ok {
-			iVal, err := strconv.Atoi(sVal)
-			if err != nil {
-				return nil, err
-			}
-			raw[k] = iVal
-		}
-	}
-
-	return json.Marshal(raw)
-}
-
-// Helper function for maintaining backwards compatibility
-func convertFirewallServiceListResponse(b []byte) ([]byte, error) {
-	var rawList struct {
-		Count         int                      `json:"count"`
-		FirewallRules []map[string]interface{} `json:"firewallrule"`
-	}
-
-	if err := json.Unmarshal(b, &rawList); err != nil {
-		return nil, err
-	}
-
-	for _, r := range rawList.FirewallRules {
-		for _, k := range []string{"endport", "startport"} {
-			if sVal, ok := r[k].(string); ok {
-				iVal, err := strconv.Atoi(sVal)
-				if err != nil {
-					return nil, err
-				}
-				r[k] = iVal
-			}
-		}
-	}
-
-	return json.Marshal(rawList)
-}
-
 type ListFirewallRulesParams struct {
 	p map[string]interface{}
 }
@@ -94,9 +45,33 @@ func (p *ListFirewallRulesParams) toURLValues() url.Values {
 	if v, found := p.p["networkid"]; found {
 		u.Set("networkid", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	return u
 }
 
+func (p *ListFirewallRulesParams) APIName() string {
+	return "listFirewallRules"
+}
+
+func (p *ListFirewallRulesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListFirewallRulesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListFirewallRulesParams) Response() interface{} {
+	return &ListFirewallRulesResponse{}
+}
+
 func (p *ListFirewallRulesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -127,6 +102,22 @@ func (p *ListFirewallRulesParams) SetNetworkid(v string) {
 	return
 }
 
+func (p *ListFirewallRulesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListFirewallRulesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 // You should always use this function to get a new ListFirewallRulesParams instance,
 // as then you are sure you have configured all required params
 func (s *FirewallService) NewListFirewallRulesParams() *ListFirewallRulesParams {
@@ -137,43 +128,54 @@ func (s *FirewallService) NewListFirewallRulesParams() *ListFirewallRulesParams
 
 // Lists all firewall rules for an IP address.
 func (s *FirewallService) ListFirewallRules(p *ListFirewallRulesParams) (*ListFirewallRulesResponse, error) {
-	resp, err := s.cs.newRequest("listFirewallRules", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = convertFirewallServiceResponse(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListFirewallRulesWithContext(context.Background(), p)
+}
 
+// ListFirewallRulesWithContext is like ListFirewallRules, but the request is bound to ctx.
+func (s *FirewallService) ListFirewallRulesWithContext(ctx context.Context, p *ListFirewallRulesParams) (*ListFirewallRulesResponse, error) {
 	var r ListFirewallRulesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListFirewallRulesPager returns a Pager over the items of all pages of ListFirewallRules.
+func (s *FirewallService) ListFirewallRulesPager(p *ListFirewallRulesParams) *Pager[*FirewallRule] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*FirewallRule, int, error) {
+		r, err := s.ListFirewallRulesWithContext(ctx, &ListFirewallRulesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.FirewallRules, r.Count, nil
+	})
+}
+
+// ListFirewallRulesAll is like ListFirewallRulesWithContext, but requests all pages and returns the items of all of them.
+func (s *FirewallService) ListFirewallRulesAll(ctx context.Context, p *ListFirewallRulesParams) ([]*FirewallRule, error) {
+	return s.ListFirewallRulesPager(p).All(ctx)
+}
+
 type ListFirewallRulesResponse struct {
 	Count         int             `json:"count"`
 	FirewallRules []*FirewallRule `json:"firewallrule"`
 }
 
 type FirewallRule struct {
-	Cidrlist    string `json:"cidrlist,omitempty"`
-	Endport     int    `json:"endport,omitempty"`
-	Fordisplay  bool   `json:"fordisplay,omitempty"`
-	Icmpcode    int    `json:"icmpcode,omitempty"`
-	Icmptype    int    `json:"icmptype,omitempty"`
-	Id          string `json:"id,omitempty"`
-	Ipaddress   string `json:"ipaddress,omitempty"`
-	Ipaddressid string `json:"ipaddressid,omitempty"`
-	Networkid   string `json:"networkid,omitempty"`
-	Protocol    string `json:"protocol,omitempty"`
-	Startport   int    `json:"startport,omitempty"`
-	State       string `json:"state,omitempty"`
-	Tags        []Tag  `json:"tags,omitempty"`
+	Cidrlist    string  `json:"cidrlist,omitempty"`
+	Endport     FlexInt `json:"endport,omitempty"`
+	Fordisplay  bool    `json:"fordisplay,omitempty"`
+	Icmpcode    FlexInt `json:"icmpcode,omitempty"`
+	Icmptype    FlexInt `json:"icmptype,omitempty"`
+	Id          string  `json:"id,omitempty"`
+	Ipaddress   string  `json:"ipaddress,omitempty"`
+	Ipaddressid string  `json:"ipaddressid,omitempty"`
+	Networkid   string  `json:"networkid,omitempty"`
+	Protocol    string  `json:"protocol,omitempty"`
+	Startport   FlexInt `json:"startport,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Tags        []Tag   `json:"tags,omitempty"`
 }
 
 type CreateFirewallRuleParams struct {
@@ -214,6 +216,22 @@ func (p *CreateFirewallRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateFirewallRuleParams) APIName() string {
+	return "createFirewallRule"
+}
+
+func (p *CreateFirewallRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateFirewallRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateFirewallRuleParams) Response() interface{} {
+	return &CreateFirewallRuleResponse{}
+}
+
 func (p *CreateFirewallRuleParams) SetIpaddressid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -283,59 +301,48 @@ func (s *FirewallService) NewCreateFirewallRuleParams(ipaddressid string, protoc
 
 // Creates a firewall rule for a given IP address
 func (s *FirewallService) CreateFirewallRule(p *CreateFirewallRuleParams) (*CreateFirewallRuleResponse, error) {
-	resp, err := s.cs.newRequest("createFirewallRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateFirewallRuleWithContext(context.Background(), p)
+}
 
+// CreateFirewallRuleWithContext is like CreateFirewallRule, but the request is bound to ctx.
+func (s *FirewallService) CreateFirewallRuleWithContext(ctx context.Context, p *CreateFirewallRuleParams) (*CreateFirewallRuleResponse, error) {
 	var r CreateFirewallRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		b, err = convertFirewallServiceResponse(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateFirewallRuleJob is like CreateFirewallRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *FirewallService) CreateFirewallRuleJob(ctx context.Context, p *CreateFirewallRuleParams) (*Job[*CreateFirewallRuleResponse], error) {
+	var r CreateFirewallRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateFirewallRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Cidrlist    string `json:"cidrlist,omitempty"`
-	Endport     int    `json:"endport,omitempty"`
-	Fordisplay  bool   `json:"fordisplay,omitempty"`
-	Icmpcode    int    `json:"icmpcode,omitempty"`
-	Icmptype    int    `json:"icmptype,omitempty"`
-	Id          string `json:"id,omitempty"`
-	Ipaddress   string `json:"ipaddress,omitempty"`
-	Ipaddressid string `json:"ipaddressid,omitempty"`
-	Networkid   string `json:"networkid,omitempty"`
-	Protocol    string `json:"protocol,omitempty"`
-	Startport   int    `json:"startport,omitempty"`
-	State       string `json:"state,omitempty"`
-	Tags        []Tag  `json:"tags,omitempty"`
+	JobID       string  `json:"jobid,omitempty"`
+	Cidrlist    string  `json:"cidrlist,omitempty"`
+	Endport     FlexInt `json:"endport,omitempty"`
+	Fordisplay  bool    `json:"fordisplay,omitempty"`
+	Icmpcode    FlexInt `json:"icmpcode,omitempty"`
+	Icmptype    FlexInt `json:"icmptype,omitempty"`
+	Id          string  `json:"id,omitempty"`
+	Ipaddress   string  `json:"ipaddress,omitempty"`
+	Ipaddressid string  `json:"ipaddressid,omitempty"`
+	Networkid   string  `json:"networkid,omitempty"`
+	Protocol    string  `json:"protocol,omitempty"`
+	Startport   FlexInt `json:"startport,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Tags        []Tag   `json:"tags,omitempty"`
 }
 
 type DeleteFirewallRuleParams struct {
@@ -353,6 +360,22 @@ func (p *DeleteFirewallRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteFirewallRuleParams) APIName() string {
+	return "deleteFirewallRule"
+}
+
+func (p *DeleteFirewallRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteFirewallRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteFirewallRuleParams) Response() interface{} {
+	return &DeleteFirewallRuleResponse{}
+}
+
 func (p *DeleteFirewallRuleParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -372,42 +395,36 @@ func (s *FirewallService) NewDeleteFirewallRuleParams(id string) *DeleteFirewall
 
 // Deletes a firewall rule
 func (s *FirewallService) DeleteFirewallRule(p *DeleteFirewallRuleParams) (*DeleteFirewallRuleResponse, error) {
-	resp, err := s.cs.newRequest("deleteFirewallRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteFirewallRuleWithContext(context.Background(), p)
+}
 
+// DeleteFirewallRuleWithContext is like DeleteFirewallRule, but the request is bound to ctx.
+func (s *FirewallService) DeleteFirewallRuleWithContext(ctx context.Context, p *DeleteFirewallRuleParams) (*DeleteFirewallRuleResponse, error) {
 	var r DeleteFirewallRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = convertFirewallServiceResponse(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteFirewallRuleJob is like DeleteFirewallRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *FirewallService) DeleteFirewallRuleJob(ctx context.Context, p *DeleteFirewallRuleParams) (*Job[*DeleteFirewallRuleResponse], error) {
+	var r DeleteFirewallRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteFirewallRuleResponse struct {
-	JobID   string `json:"jobid,omitempty"`
-	Success bool   `json:"success,omitempty"`
+	JobID   string   `json:"jobid,omitempty"`
+	Success FlexBool `json:"success,omitempty"`
 }
 
 type EnableStaticNatParams struct {
@@ -434,6 +451,22 @@ func (p *EnableStaticNatParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *EnableStaticNatParams) APIName() string {
+	return "enableStaticNat"
+}
+
+func (p *EnableStaticNatParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *EnableStaticNatParams) IsAsync() bool {
+	return false
+}
+
+func (p *EnableStaticNatParams) Response() interface{} {
+	return &EnableStaticNatResponse{}
+}
+
 func (p *EnableStaticNatParams) SetIpaddressid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -478,13 +511,13 @@ func (s *FirewallService) NewEnableStaticNatParams(ipaddressid string, virtualma
 
 // Enables static NAT for given IP address
 func (s *FirewallService) EnableStaticNat(p *EnableStaticNatParams) (*EnableStaticNatResponse, error) {
-	resp, err := s.cs.newRequest("enableStaticNat", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.EnableStaticNatWithContext(context.Background(), p)
+}
 
+// EnableStaticNatWithContext is like EnableStaticNat, but the request is bound to ctx.
+func (s *FirewallService) EnableStaticNatWithContext(ctx context.Context, p *EnableStaticNatParams) (*EnableStaticNatResponse, error) {
 	var r EnableStaticNatResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -492,8 +525,8 @@ func (s *FirewallService) EnableStaticNat(p *EnableStaticNatParams) (*EnableStat
 }
 
 type EnableStaticNatResponse struct {
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     string `json:"success,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type DisableStaticNatParams struct {
@@ -511,6 +544,22 @@ func (p *DisableStaticNatParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DisableStaticNatParams) APIName() string {
+	return "disableStaticNat"
+}
+
+func (p *DisableStaticNatParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DisableStaticNatParams) IsAsync() bool {
+	return true
+}
+
+func (p *DisableStaticNatParams) Response() interface{} {
+	return &DisableStaticNatResponse{}
+}
+
 func (p *DisableStaticNatParams) SetIpaddressid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -530,36 +579,35 @@ func (s *FirewallService) NewDisableStaticNatParams(ipaddressid string) *Disable
 
 // Disables static rule for given IP address
 func (s *FirewallService) DisableStaticNat(p *DisableStaticNatParams) (*DisableStaticNatResponse, error) {
-	resp, err := s.cs.newRequest("disableStaticNat", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DisableStaticNatWithContext(context.Background(), p)
+}
 
+// DisableStaticNatWithContext is like DisableStaticNat, but the request is bound to ctx.
+func (s *FirewallService) DisableStaticNatWithContext(ctx context.Context, p *DisableStaticNatParams) (*DisableStaticNatResponse, error) {
 	var r DisableStaticNatResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DisableStaticNatJob is like DisableStaticNatWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *FirewallService) DisableStaticNatJob(ctx context.Context, p *DisableStaticNatParams) (*Job[*DisableStaticNatResponse], error) {
+	var r DisableStaticNatResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DisableStaticNatResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
@@ -17,10 +17,9 @@
 package gokcps
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/url"
-	"strings"
+	"strconv"
 )
 
 type ListOsTypesParams struct {
@@ -44,9 +43,33 @@ func (p *ListOsTypesParams) toURLValues() url.Values {
 	if v, found := p.p["oscategoryid"]; found {
 		u.Set("oscategoryid", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	return u
 }
 
+func (p *ListOsTypesParams) APIName() string {
+	return "listOsTypes"
+}
+
+func (p *ListOsTypesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListOsTypesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListOsTypesParams) Response() interface{} {
+	return &ListOsTypesResponse{}
+}
+
 func (p *ListOsTypesParams) SetDescription(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -79,6 +102,22 @@ func (p *ListOsTypesParams) SetOscategoryid(v string) {
 	return
 }
 
+func (p *ListOsTypesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListOsTypesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 // You should always use this function to get a new ListOsTypesParams instance,
 // as then you are sure you have configured all required params
 func (s *GuestOSService) NewListOsTypesParams() *ListOsTypesParams {
@@ -87,8 +126,14 @@ func (s *GuestOSService) NewListOsTypesParams() *ListOsTypesParams {
 	return p
 }
 
-// This is a courtesy helper function, which in some cases may not work as expected!
+// GetOsTypeByID returns the OS type with the given ID. The error is a *NotFoundError if there
+// is no such OS type.
 func (s *GuestOSService) GetOsTypeByID(id string, opts ...OptionFunc) (*OsType, int, error) {
+	return s.GetOsTypeByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetOsTypeByIDWithContext is like GetOsTypeByID, but the requests are bound to ctx.
+func (s *GuestOSService) GetOsTypeByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*OsType, int, error) {
 	p := &ListOsTypesParams{}
 	p.p = make(map[string]interface{})
 
@@ -100,41 +145,47 @@ func (s *GuestOSService) GetOsTypeByID(id string, opts ...OptionFunc) (*OsType,
 		}
 	}
 
-	l, err := s.ListOsTypes(p)
+	l, err := s.ListOsTypesAll(ctx, p)
 	if err != nil {
-		if strings.Contains(err.Error(), fmt.Sprintf(
-			"Invalid parameter id value=%s due to incorrect long value format, "+
-				"or entity does not exist", id)) {
-			return nil, 0, fmt.Errorf("No match found for %s: %+v", id, l)
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "OsType", Key: id}
 		}
 		return nil, -1, err
 	}
-
-	if l.Count == 0 {
-		return nil, l.Count, fmt.Errorf("No match found for %s: %+v", id, l)
-	}
-
-	if l.Count == 1 {
-		return l.OsTypes[0], l.Count, nil
-	}
-	return nil, l.Count, fmt.Errorf("There is more then one result for OsType UUID: %s!", id)
+	return pickByID("OsType", id, l, func(v *OsType) string { return v.Id })
 }
 
 // Lists all supported OS types for this cloud.
 func (s *GuestOSService) ListOsTypes(p *ListOsTypesParams) (*ListOsTypesResponse, error) {
-	resp, err := s.cs.newRequest("listOsTypes", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListOsTypesWithContext(context.Background(), p)
+}
 
+// ListOsTypesWithContext is like ListOsTypes, but the request is bound to ctx.
+func (s *GuestOSService) ListOsTypesWithContext(ctx context.Context, p *ListOsTypesParams) (*ListOsTypesResponse, error) {
 	var r ListOsTypesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListOsTypesPager returns a Pager over the items of all pages of ListOsTypes.
+func (s *GuestOSService) ListOsTypesPager(p *ListOsTypesParams) *Pager[*OsType] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*OsType, int, error) {
+		r, err := s.ListOsTypesWithContext(ctx, &ListOsTypesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.OsTypes, r.Count, nil
+	})
+}
+
+// ListOsTypesAll is like ListOsTypesWithContext, but requests all pages and returns the items of all of them.
+func (s *GuestOSService) ListOsTypesAll(ctx context.Context, p *ListOsTypesParams) ([]*OsType, error) {
+	return s.ListOsTypesPager(p).All(ctx)
+}
+
 type ListOsTypesResponse struct {
 	Count   int       `json:"count"`
 	OsTypes []*OsType `json:"ostype"`
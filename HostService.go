@@ -17,11 +17,9 @@
 package gokcps
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 type ListPremiumHostsParams struct {
@@ -33,12 +31,52 @@ func (p *ListPremiumHostsParams) toURLValues() url.Values {
 	if p.p == nil {
 		return u
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["zoneid"]; found {
 		u.Set("zoneid", v.(string))
 	}
 	return u
 }
 
+func (p *ListPremiumHostsParams) APIName() string {
+	return "listPremiumHosts"
+}
+
+func (p *ListPremiumHostsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListPremiumHostsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListPremiumHostsParams) Response() interface{} {
+	return &ListPremiumHostsResponse{}
+}
+
+func (p *ListPremiumHostsParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListPremiumHostsParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListPremiumHostsParams) SetZoneid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -55,46 +93,82 @@ func (s *HostService) NewListPremiumHostsParams() *ListPremiumHostsParams {
 	return p
 }
 
-// Lists hosts.
-func (s *HostService) ListPremiumHosts(p *ListPremiumHostsParams) (*ListPremiumHostsResponse, error) {
-	resp, err := s.cs.newRequest("listPremiumHosts", p.toURLValues())
-	if err != nil {
-		return nil, err
+// GetPremiumHostByName returns the premium host with the given name, as premium hosts are
+// identified by their name. The error is a *NotFoundError if there is no such premium host
+// and an *AmbiguousError if there are several.
+func (s *HostService) GetPremiumHostByName(name string, opts ...OptionFunc) (*PremiumHost, int, error) {
+	return s.GetPremiumHostByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetPremiumHostByNameWithContext is like GetPremiumHostByName, but the requests are bound to ctx.
+func (s *HostService) GetPremiumHostByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*PremiumHost, int, error) {
+	p := &ListPremiumHostsParams{}
+	p.p = make(map[string]interface{})
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
 	}
 
-	resp, err = cnvCorrectPremiumHostJson(resp)
+	l, err := s.ListPremiumHostsAll(ctx, p)
 	if err != nil {
-		return nil, err
+		return nil, -1, err
 	}
+	return pickByName("PremiumHost", name, l, func(v *PremiumHost) string { return v.Name }, func(v *PremiumHost) string { return v.Name })
+}
+
+// Lists hosts.
+func (s *HostService) ListPremiumHosts(p *ListPremiumHostsParams) (*ListPremiumHostsResponse, error) {
+	return s.ListPremiumHostsWithContext(context.Background(), p)
+}
 
+// ListPremiumHostsWithContext is like ListPremiumHosts, but the request is bound to ctx.
+func (s *HostService) ListPremiumHostsWithContext(ctx context.Context, p *ListPremiumHostsParams) (*ListPremiumHostsResponse, error) {
 	var r ListPremiumHostsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListPremiumHostsPager returns a Pager over the items of all pages of ListPremiumHosts.
+func (s *HostService) ListPremiumHostsPager(p *ListPremiumHostsParams) *Pager[*PremiumHost] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*PremiumHost, int, error) {
+		r, err := s.ListPremiumHostsWithContext(ctx, &ListPremiumHostsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.PremiumHosts, r.Count, nil
+	})
+}
+
+// ListPremiumHostsAll is like ListPremiumHostsWithContext, but requests all pages and returns the items of all of them.
+func (s *HostService) ListPremiumHostsAll(ctx context.Context, p *ListPremiumHostsParams) ([]*PremiumHost, error) {
+	return s.ListPremiumHostsPager(p).All(ctx)
+}
+
 type ListPremiumHostsResponse struct {
 	Count        int            `json:"count"`
 	PremiumHosts []*PremiumHost `json:"host"`
 }
 
 type PremiumHost struct {
-	Cpuallocated          string `json:"cpuallocated,omitempty"`
-	Cpunumber             int64  `json:"cpunumber,omitempty"`
-	Cpuspeed              int64  `json:"cpuspeed,omitempty"`
-	Cpuused               string `json:"cpuused,omitempty"`
-	Hypervisor            string `json:"hypervisor,omitempty"`
-	Memoryallocated       int64  `json:"memoryallocated,omitempty"`
-	Memorytotal           int64  `json:"memorytotal,omitempty"`
-	Memoryused            int64  `json:"memoryused,omitempty"`
-	Name                  string `json:"name,omitempty"`
-	Zoneid                string `json:"zoneid,omitempty"`
-	Resourcestate         string `json:"resourcestate,omitempty"`
-	State                 string `json:"state,omitempty"`
-	Zonename              string `json:"zonename,omitempty"`
-	DistributionGroupname string `json:"distributionGroupName,omitempty"`
+	Cpuallocated          string    `json:"cpuallocated,omitempty"`
+	Cpunumber             FlexInt64 `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt64 `json:"cpuspeed,omitempty"`
+	Cpuused               string    `json:"cpuused,omitempty"`
+	Hypervisor            string    `json:"hypervisor,omitempty"`
+	Memoryallocated       FlexInt64 `json:"memoryallocated,omitempty"`
+	Memorytotal           FlexInt64 `json:"memorytotal,omitempty"`
+	Memoryused            FlexInt64 `json:"memoryused,omitempty"`
+	Name                  string    `json:"name,omitempty"`
+	Zoneid                string    `json:"zoneid,omitempty"`
+	Resourcestate         string    `json:"resourcestate,omitempty"`
+	State                 string    `json:"state,omitempty"`
+	Zonename              string    `json:"zonename,omitempty"`
+	DistributionGroupname string    `json:"distributionGroupName,omitempty"`
 }
 
 type ListDistributionGroupsParams struct {
@@ -106,9 +180,49 @@ func (p *ListDistributionGroupsParams) toURLValues() url.Values {
 	if p.p == nil {
 		return u
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	return u
 }
 
+func (p *ListDistributionGroupsParams) APIName() string {
+	return "listDistributionGroups"
+}
+
+func (p *ListDistributionGroupsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListDistributionGroupsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListDistributionGroupsParams) Response() interface{} {
+	return &ListDistributionGroupsResponse{}
+}
+
+func (p *ListDistributionGroupsParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListDistributionGroupsParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (s *HostService) NewListDistributionGroupsParams() *ListDistributionGroupsParams {
 	p := &ListDistributionGroupsParams{}
 	p.p = make(map[string]interface{})
@@ -116,19 +230,35 @@ func (s *HostService) NewListDistributionGroupsParams() *ListDistributionGroupsP
 }
 
 func (s *HostService) ListDistributionGroups(p *ListDistributionGroupsParams) (*ListDistributionGroupsResponse, error) {
-	resp, err := s.cs.newRequest("listDistributionGroups", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListDistributionGroupsWithContext(context.Background(), p)
+}
 
+// ListDistributionGroupsWithContext is like ListDistributionGroups, but the request is bound to ctx.
+func (s *HostService) ListDistributionGroupsWithContext(ctx context.Context, p *ListDistributionGroupsParams) (*ListDistributionGroupsResponse, error) {
 	var r ListDistributionGroupsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListDistributionGroupsPager returns a Pager over the items of all pages of ListDistributionGroups.
+func (s *HostService) ListDistributionGroupsPager(p *ListDistributionGroupsParams) *Pager[*DistributionGroup] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*DistributionGroup, int, error) {
+		r, err := s.ListDistributionGroupsWithContext(ctx, &ListDistributionGroupsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.DistributionGroups, r.Count, nil
+	})
+}
+
+// ListDistributionGroupsAll is like ListDistributionGroupsWithContext, but requests all pages and returns the items of all of them.
+func (s *HostService) ListDistributionGroupsAll(ctx context.Context, p *ListDistributionGroupsParams) ([]*DistributionGroup, error) {
+	return s.ListDistributionGroupsPager(p).All(ctx)
+}
+
 type ListDistributionGroupsResponse struct {
 	Count              int                  `json:"count"`
 	DistributionGroups []*DistributionGroup `json:"distributiongroup"`
@@ -156,6 +286,14 @@ func (p *ListPremiumVirtualMachinesParams) toURLValues() url.Values {
 	if v, found := p.p["name"]; found {
 		u.Set("name", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["state"]; found {
 		u.Set("state", v.(string))
 	}
@@ -168,6 +306,22 @@ func (p *ListPremiumVirtualMachinesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListPremiumVirtualMachinesParams) APIName() string {
+	return "listPremiumVirtualMachines"
+}
+
+func (p *ListPremiumVirtualMachinesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListPremiumVirtualMachinesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListPremiumVirtualMachinesParams) Response() interface{} {
+	return &ListPremiumVirtualMachinesResponse{}
+}
+
 func (p *ListPremiumVirtualMachinesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -192,6 +346,22 @@ func (p *ListPremiumVirtualMachinesParams) SetName(v string) {
 	return
 }
 
+func (p *ListPremiumVirtualMachinesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListPremiumVirtualMachinesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListPremiumVirtualMachinesParams) SetState(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -223,38 +393,33 @@ func (s *HostService) NewListPremiumVirtualMachines() *ListPremiumVirtualMachine
 }
 
 func (s *HostService) ListPremiumVirtualMachines(p *ListPremiumVirtualMachinesParams) (*ListPremiumVirtualMachinesResponse, error) {
+	return s.ListPremiumVirtualMachinesWithContext(context.Background(), p)
+}
 
+// ListPremiumVirtualMachinesWithContext is like ListPremiumVirtualMachines, but the request is bound to ctx.
+func (s *HostService) ListPremiumVirtualMachinesWithContext(ctx context.Context, p *ListPremiumVirtualMachinesParams) (*ListPremiumVirtualMachinesResponse, error) {
 	var r ListPremiumVirtualMachinesResponse
-
-	resp, _ := s.cs.newRequest("listPremiumVirtualMachines", p.toURLValues())
-	var i interface{}
-	if err := json.Unmarshal(resp, &i); err != nil {
-		fmt.Println(err)
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		return nil, err
 	}
 
-	v := i.(map[string]interface{})["virtualmachine"].([]interface{})
-	for _, m := range v {
-		vmtmp := m.(map[string]interface{})
-		rdint, err := strconv.Atoi(vmtmp["rootdeviceid"].(string))
+	return &r, nil
+}
+
+// ListPremiumVirtualMachinesPager returns a Pager over the items of all pages of ListPremiumVirtualMachines.
+func (s *HostService) ListPremiumVirtualMachinesPager(p *ListPremiumVirtualMachinesParams) *Pager[*VirtualMachine] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*VirtualMachine, int, error) {
+		r, err := s.ListPremiumVirtualMachinesWithContext(ctx, &ListPremiumVirtualMachinesParams{p: params})
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		vmtmp["rootdeviceid"] = int64(rdint)
-
-		utime := int64(vmtmp["created"].(float64)) / 1000
-		t := time.Unix(utime, 0)
-		vmtmp["created"] = t.Format("2006-01-02T15:04:05+09:00")
-		fmt.Println("")
-	}
+		return r.VirtualMachines, r.Count, nil
+	})
+}
 
-	b, err := json.Marshal(i)
-	if err != nil {
-		return nil, err
-	}
-	if err := json.Unmarshal(json.RawMessage(b), &r); err != nil {
-		return nil, err
-	}
-	return &r, nil
+// ListPremiumVirtualMachinesAll is like ListPremiumVirtualMachinesWithContext, but requests all pages and returns the items of all of them.
+func (s *HostService) ListPremiumVirtualMachinesAll(ctx context.Context, p *ListPremiumVirtualMachinesParams) ([]*VirtualMachine, error) {
+	return s.ListPremiumVirtualMachinesPager(p).All(ctx)
 }
 
 type ListPremiumVirtualMachinesResponse struct {
@@ -288,6 +453,22 @@ func (p *AddPremiumHostParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AddPremiumHostParams) APIName() string {
+	return "addPremiumHosts"
+}
+
+func (p *AddPremiumHostParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AddPremiumHostParams) IsAsync() bool {
+	return false
+}
+
+func (p *AddPremiumHostParams) Response() interface{} {
+	return &AddPremiumHostResponse{}
+}
+
 func (p *AddPremiumHostParams) SetZoneid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -312,7 +493,7 @@ func (p *AddPremiumHostParams) SetNumber(v int) {
 	return
 }
 
-func (p *AddPremiumHostParams) SetDistributiongroup(v int) {
+func (p *AddPremiumHostParams) SetDistributiongroup(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
 	}
@@ -333,18 +514,13 @@ func (s *HostService) NewAddPremiumHostParams(hypervisor, zoneid string, number
 
 // Adds a new host.
 func (s *HostService) AddPremiumHost(p *AddPremiumHostParams) (*AddPremiumHostResponse, error) {
-	resp, err := s.cs.newRequest("addPremiumHosts", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = cnvCorrectPremiumHostJson(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.AddPremiumHostWithContext(context.Background(), p)
+}
 
+// AddPremiumHostWithContext is like AddPremiumHost, but the request is bound to ctx.
+func (s *HostService) AddPremiumHostWithContext(ctx context.Context, p *AddPremiumHostParams) (*AddPremiumHostResponse, error) {
 	var r AddPremiumHostResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -371,6 +547,22 @@ func (p *RemovePremiumHostParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RemovePremiumHostParams) APIName() string {
+	return "removePremiumHost"
+}
+
+func (p *RemovePremiumHostParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RemovePremiumHostParams) IsAsync() bool {
+	return false
+}
+
+func (p *RemovePremiumHostParams) Response() interface{} {
+	return &RemovePremiumHostResponse{}
+}
+
 func (p *RemovePremiumHostParams) SetName(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -390,14 +582,13 @@ func (s *HostService) NewRemovePremiumHostParams(name string) *RemovePremiumHost
 
 // Deletes a host.
 func (s *HostService) RemovePremiumHost(p *RemovePremiumHostParams) (*RemovePremiumHostResponse, error) {
-	resp, err := s.cs.newRequest("removePremiumHost", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RemovePremiumHostWithContext(context.Background(), p)
+}
 
+// RemovePremiumHostWithContext is like RemovePremiumHost, but the request is bound to ctx.
+func (s *HostService) RemovePremiumHostWithContext(ctx context.Context, p *RemovePremiumHostParams) (*RemovePremiumHostResponse, error) {
 	var r RemovePremiumHostResponse
-
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -405,53 +596,5 @@ func (s *HostService) RemovePremiumHost(p *RemovePremiumHostParams) (*RemovePrem
 }
 
 type RemovePremiumHostResponse struct {
-	Success bool `json:"success,omitempty"`
-}
-
-func cnvCorrectPremiumHostJson(message json.RawMessage) (json.RawMessage, error) {
-	var i interface{}
-	if err := json.Unmarshal(message, &i); err != nil {
-		return nil, err
-	}
-	v := i.(map[string]interface{})["host"].([]interface{})
-	for _, m := range v {
-		premhost := m.(map[string]interface{})
-
-		cpunum, err := strconv.Atoi(premhost["cpunumber"].(string))
-		if err != nil {
-			return nil, err
-		}
-		premhost["cpunumber"] = int64(cpunum)
-
-		cpuspeed, err := strconv.Atoi(premhost["cpuspeed"].(string))
-		if err != nil {
-			return nil, err
-		}
-		premhost["cpuspeed"] = int64(cpuspeed)
-
-		memalloc, err := strconv.Atoi(premhost["memoryallocated"].(string))
-		if err != nil {
-			return nil, err
-		}
-		premhost["memoryallocated"] = int64(memalloc)
-
-		memtotal, err := strconv.Atoi(premhost["memorytotal"].(string))
-		if err != nil {
-			return nil, err
-		}
-		premhost["memorytotal"] = int64(memtotal)
-
-		memused, err := strconv.Atoi(premhost["memoryused"].(string))
-		if err != nil {
-			return nil, err
-		}
-		premhost["memoryused"] = int64(memused)
-	}
-
-	b, err := json.Marshal(i)
-	if err != nil {
-		return nil, err
-	}
-
-	return json.RawMessage(b), nil
+	Success FlexBool `json:"success,omitempty"`
 }
@@ -0,0 +1,259 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Starts a server answering every request with status and body, and returns a client using it.
+// The client does not retry, so every call sends a single request.
+func newCannedClient(t *testing.T, status int, body string) *KCPSClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	cs, err := New(srv.URL, "apikey", "secret", WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return cs
+}
+
+func TestListPremiumVirtualMachines(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+		check   func(t *testing.T, r *ListPremiumVirtualMachinesResponse)
+	}{
+		{
+			name:   "empty envelope",
+			status: http.StatusOK,
+			body:   `{"listpremiumvirtualmachinesresponse":{}}`,
+			check: func(t *testing.T, r *ListPremiumVirtualMachinesResponse) {
+				if r.Count != 0 || len(r.VirtualMachines) != 0 {
+					t.Errorf("response = %+v, want no virtual machines", r)
+				}
+			},
+		},
+		{
+			name:   "single virtual machine",
+			status: http.StatusOK,
+			body: `{"listpremiumvirtualmachinesresponse":{"count":1,"virtualmachine":[{"id":"vm-1","name":"premium1",` +
+				`"cpunumber":"4","memory":"8192","rootdeviceid":"0","created":1700000000000,"state":"Running"}]}}`,
+			check: func(t *testing.T, r *ListPremiumVirtualMachinesResponse) {
+				if r.Count != 1 || len(r.VirtualMachines) != 1 {
+					t.Fatalf("response = %+v, want a single virtual machine", r)
+				}
+				vm := r.VirtualMachines[0]
+				if vm.Id != "vm-1" || vm.Name != "premium1" || vm.State != "Running" {
+					t.Errorf("vm = %s %s %s, want vm-1 premium1 Running", vm.Id, vm.Name, vm.State)
+				}
+				if vm.Cpunumber != 4 || vm.Memory != 8192 || vm.Rootdeviceid != 0 {
+					t.Errorf("cpunumber, memory, rootdeviceid = %d, %d, %d, want 4, 8192, 0", vm.Cpunumber, vm.Memory, vm.Rootdeviceid)
+				}
+				if !vm.Created.Equal(time.UnixMilli(1700000000000)) {
+					t.Errorf("created = %s, want %s", vm.Created, time.UnixMilli(1700000000000))
+				}
+			},
+		},
+		{
+			name:    "malformed cpunumber",
+			status:  http.StatusOK,
+			body:    `{"listpremiumvirtualmachinesresponse":{"count":1,"virtualmachine":[{"id":"vm-1","cpunumber":"four"}]}}`,
+			wantErr: "Unable to decode four as an integer",
+		},
+		{
+			name:    "virtualmachine not a list",
+			status:  http.StatusOK,
+			body:    `{"listpremiumvirtualmachinesresponse":{"count":1,"virtualmachine":"vm-1"}}`,
+			wantErr: "cannot unmarshal",
+		},
+		{
+			name:    "empty response",
+			status:  http.StatusOK,
+			body:    `{}`,
+			wantErr: "empty response",
+		},
+		{
+			name:   "error status",
+			status: 431,
+			body: `{"listpremiumvirtualmachinesresponse":{"uuidList":[],"errorcode":431,"cserrorcode":9999,` +
+				`"errortext":"Unable to execute API command listpremiumvirtualmachines due to missing parameter zoneid"}}`,
+			wantErr: "missing parameter zoneid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := newCannedClient(t, tt.status, tt.body)
+
+			r, err := cs.Host.ListPremiumVirtualMachines(cs.Host.NewListPremiumVirtualMachines())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want an error containing %q", err, tt.wantErr)
+				}
+				if r != nil {
+					t.Errorf("response = %+v, want nil with an error", r)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListPremiumVirtualMachines: %v", err)
+			}
+			tt.check(t, r)
+		})
+	}
+}
+
+func TestListPremiumHosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+		want    []PremiumHost
+	}{
+		{
+			name:   "empty envelope",
+			status: http.StatusOK,
+			body:   `{"listpremiumhostsresponse":{}}`,
+		},
+		{
+			name:   "single host",
+			status: http.StatusOK,
+			body: `{"listpremiumhostsresponse":{"count":1,"host":[{"name":"host1","cpunumber":"16","cpuspeed":2600,` +
+				`"memorytotal":"68719476736","memoryused":"0","state":"Up","distributionGroupName":"group1"}]}}`,
+			want: []PremiumHost{{
+				Name:                  "host1",
+				Cpunumber:             16,
+				Cpuspeed:              2600,
+				Memorytotal:           68719476736,
+				State:                 "Up",
+				DistributionGroupname: "group1",
+			}},
+		},
+		{
+			name:    "malformed memorytotal",
+			status:  http.StatusOK,
+			body:    `{"listpremiumhostsresponse":{"count":1,"host":[{"name":"host1","memorytotal":"64GB"}]}}`,
+			wantErr: "Unable to decode 64GB as an integer",
+		},
+		{
+			name:    "error status",
+			status:  http.StatusUnauthorized,
+			body:    `{"listpremiumhostsresponse":{"uuidList":[],"errorcode":401,"errortext":"unable to verify user credentials"}}`,
+			wantErr: "unable to verify user credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := newCannedClient(t, tt.status, tt.body)
+
+			r, err := cs.Host.ListPremiumHosts(cs.Host.NewListPremiumHostsParams())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListPremiumHosts: %v", err)
+			}
+			if r.Count != len(tt.want) || len(r.PremiumHosts) != len(tt.want) {
+				t.Fatalf("response = %+v, want %d hosts", r, len(tt.want))
+			}
+			for i, h := range r.PremiumHosts {
+				if *h != tt.want[i] {
+					t.Errorf("host %d = %+v, want %+v", i, *h, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAddPremiumHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+		want    int
+	}{
+		{
+			name:   "empty envelope",
+			status: http.StatusOK,
+			body:   `{"addpremiumhostsresponse":{}}`,
+		},
+		{
+			name:   "single host",
+			status: http.StatusOK,
+			body:   `{"addpremiumhostsresponse":{"count":1,"host":[{"name":"host1","cpunumber":"16","state":"Up"}]}}`,
+			want:   1,
+		},
+		{
+			name:    "malformed cpunumber",
+			status:  http.StatusOK,
+			body:    `{"addpremiumhostsresponse":{"count":1,"host":[{"name":"host1","cpunumber":true}]}}`,
+			wantErr: "Unable to decode true as an integer",
+		},
+		{
+			name:   "error status",
+			status: 530,
+			body: `{"addpremiumhostsresponse":{"uuidList":[],"errorcode":530,"cserrorcode":4250,` +
+				`"errortext":"Insufficient capacity"}}`,
+			wantErr: "Insufficient capacity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := newCannedClient(t, tt.status, tt.body)
+
+			p := cs.Host.NewAddPremiumHostParams("VMware", "zone-1", 1)
+			p.SetDistributiongroup("group1")
+			r, err := cs.Host.AddPremiumHost(p)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddPremiumHost: %v", err)
+			}
+			if r.Count != tt.want || len(r.PremiumHosts) != tt.want {
+				t.Fatalf("response = %+v, want %d hosts", r, tt.want)
+			}
+			if tt.want > 0 && (r.PremiumHosts[0].Name != "host1" || r.PremiumHosts[0].Cpunumber != 16) {
+				t.Errorf("host = %+v, want host1 with 16 cpus", *r.PremiumHosts[0])
+			}
+		})
+	}
+}
@@ -17,7 +17,8 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -41,6 +42,22 @@ func (p *AttachIsoParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AttachIsoParams) APIName() string {
+	return "attachIso"
+}
+
+func (p *AttachIsoParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AttachIsoParams) IsAsync() bool {
+	return true
+}
+
+func (p *AttachIsoParams) Response() interface{} {
+	return &AttachIsoResponse{}
+}
+
 func (p *AttachIsoParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -69,37 +86,31 @@ func (s *ISOService) NewAttachIsoParams(id string, virtualmachineid string) *Att
 
 // Attaches an ISO to a virtual machine.
 func (s *ISOService) AttachIso(p *AttachIsoParams) (*AttachIsoResponse, error) {
-	resp, err := s.cs.newRequest("attachIso", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.AttachIsoWithContext(context.Background(), p)
+}
 
+// AttachIsoWithContext is like AttachIso, but the request is bound to ctx.
+func (s *ISOService) AttachIsoWithContext(ctx context.Context, p *AttachIsoParams) (*AttachIsoResponse, error) {
 	var r AttachIsoResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// AttachIsoJob is like AttachIsoWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *ISOService) AttachIsoJob(ctx context.Context, p *AttachIsoParams) (*Job[*AttachIsoResponse], error) {
+	var r AttachIsoResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type AttachIsoResponse struct {
@@ -117,10 +128,10 @@ type AttachIsoResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -147,7 +158,7 @@ type AttachIsoResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -159,7 +170,7 @@ type AttachIsoResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -192,6 +203,22 @@ func (p *DetachIsoParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DetachIsoParams) APIName() string {
+	return "detachIso"
+}
+
+func (p *DetachIsoParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DetachIsoParams) IsAsync() bool {
+	return true
+}
+
+func (p *DetachIsoParams) Response() interface{} {
+	return &DetachIsoResponse{}
+}
+
 func (p *DetachIsoParams) SetVirtualmachineid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -211,37 +238,31 @@ func (s *ISOService) NewDetachIsoParams(virtualmachineid string) *DetachIsoParam
 
 // Detaches any ISO file (if any) currently attached to a virtual machine.
 func (s *ISOService) DetachIso(p *DetachIsoParams) (*DetachIsoResponse, error) {
-	resp, err := s.cs.newRequest("detachIso", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DetachIsoWithContext(context.Background(), p)
+}
 
+// DetachIsoWithContext is like DetachIso, but the request is bound to ctx.
+func (s *ISOService) DetachIsoWithContext(ctx context.Context, p *DetachIsoParams) (*DetachIsoResponse, error) {
 	var r DetachIsoResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DetachIsoJob is like DetachIsoWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *ISOService) DetachIsoJob(ctx context.Context, p *DetachIsoParams) (*Job[*DetachIsoResponse], error) {
+	var r DetachIsoResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type DetachIsoResponse struct {
@@ -259,10 +280,10 @@ type DetachIsoResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -289,7 +310,7 @@ type DetachIsoResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -301,7 +322,7 @@ type DetachIsoResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -348,6 +369,14 @@ func (p *ListIsosParams) toURLValues() url.Values {
 	if v, found := p.p["name"]; found {
 		u.Set("name", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["tags"]; found {
 		i := 0
 		for k, vv := range v.(map[string]string) {
@@ -362,6 +391,22 @@ func (p *ListIsosParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListIsosParams) APIName() string {
+	return "listIsos"
+}
+
+func (p *ListIsosParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListIsosParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListIsosParams) Response() interface{} {
+	return &ListIsosResponse{}
+}
+
 func (p *ListIsosParams) SetBootable(v bool) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -410,6 +455,22 @@ func (p *ListIsosParams) SetName(v string) {
 	return
 }
 
+func (p *ListIsosParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListIsosParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListIsosParams) SetTags(v map[string]string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -434,21 +495,106 @@ func (s *ISOService) NewListIsosParams() *ListIsosParams {
 	return p
 }
 
-// Lists all available ISO files.
-func (s *ISOService) ListIsos(p *ListIsosParams) (*ListIsosResponse, error) {
-	resp, err := s.cs.newRequest("listIsos", p.toURLValues())
+// GetIsoID returns the ID of the ISO with the given name. The error is a
+// *NotFoundError if there is no such ISO and an *AmbiguousError if there are several.
+func (s *ISOService) GetIsoID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetIsoIDWithContext(context.Background(), name, opts...)
+}
+
+// GetIsoIDWithContext is like GetIsoID, but the requests are bound to ctx.
+func (s *ISOService) GetIsoIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetIsoByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetIsoByName returns the ISO with the given name, like GetIsoID.
+func (s *ISOService) GetIsoByName(name string, opts ...OptionFunc) (*Iso, int, error) {
+	return s.GetIsoByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetIsoByNameWithContext is like GetIsoByName, but the requests are bound to ctx.
+func (s *ISOService) GetIsoByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*Iso, int, error) {
+	p := &ListIsosParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListIsosAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("Iso", name, l, func(v *Iso) string { return v.Name }, func(v *Iso) string { return v.Id })
+}
+
+// GetIsoByID returns the ISO with the given ID. The error is a *NotFoundError if there
+// is no such ISO.
+func (s *ISOService) GetIsoByID(id string, opts ...OptionFunc) (*Iso, int, error) {
+	return s.GetIsoByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetIsoByIDWithContext is like GetIsoByID, but the requests are bound to ctx.
+func (s *ISOService) GetIsoByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*Iso, int, error) {
+	p := &ListIsosParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListIsosAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "Iso", Key: id}
+		}
+		return nil, -1, err
 	}
+	return pickByID("Iso", id, l, func(v *Iso) string { return v.Id })
+}
+
+// Lists all available ISO files.
+func (s *ISOService) ListIsos(p *ListIsosParams) (*ListIsosResponse, error) {
+	return s.ListIsosWithContext(context.Background(), p)
+}
 
+// ListIsosWithContext is like ListIsos, but the request is bound to ctx.
+func (s *ISOService) ListIsosWithContext(ctx context.Context, p *ListIsosParams) (*ListIsosResponse, error) {
 	var r ListIsosResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListIsosPager returns a Pager over the items of all pages of ListIsos.
+func (s *ISOService) ListIsosPager(p *ListIsosParams) *Pager[*Iso] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Iso, int, error) {
+		r, err := s.ListIsosWithContext(ctx, &ListIsosParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Isos, r.Count, nil
+	})
+}
+
+// ListIsosAll is like ListIsosWithContext, but requests all pages and returns the items of all of them.
+func (s *ISOService) ListIsosAll(ctx context.Context, p *ListIsosParams) ([]*Iso, error) {
+	return s.ListIsosPager(p).All(ctx)
+}
+
 type ListIsosResponse struct {
 	Count int    `json:"count"`
 	Isos  []*Iso `json:"iso"`
@@ -459,7 +605,7 @@ type Iso struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -481,7 +627,7 @@ type Iso struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -520,6 +666,22 @@ func (p *RegisterIsoParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RegisterIsoParams) APIName() string {
+	return "registerIso"
+}
+
+func (p *RegisterIsoParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RegisterIsoParams) IsAsync() bool {
+	return false
+}
+
+func (p *RegisterIsoParams) Response() interface{} {
+	return &RegisterIsoResponse{}
+}
+
 func (p *RegisterIsoParams) SetName(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -575,13 +737,13 @@ func (s *ISOService) NewRegisterIsoParams(displaytext string, name string, url s
 
 // Registers an existing ISO into the CloudStack Cloud.
 func (s *ISOService) RegisterIso(p *RegisterIsoParams) (*RegisterIsoResponse, error) {
-	resp, err := s.cs.newRequest("registerIso", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RegisterIsoWithContext(context.Background(), p)
+}
 
+// RegisterIsoWithContext is like RegisterIso, but the request is bound to ctx.
+func (s *ISOService) RegisterIsoWithContext(ctx context.Context, p *RegisterIsoParams) (*RegisterIsoResponse, error) {
 	var r RegisterIsoResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -593,7 +755,7 @@ type RegisterIsoResponse struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -615,7 +777,7 @@ type RegisterIsoResponse struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -651,6 +813,22 @@ func (p *UpdateIsoParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *UpdateIsoParams) APIName() string {
+	return "updateIso"
+}
+
+func (p *UpdateIsoParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *UpdateIsoParams) IsAsync() bool {
+	return false
+}
+
+func (p *UpdateIsoParams) Response() interface{} {
+	return &UpdateIsoResponse{}
+}
+
 func (p *UpdateIsoParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -693,13 +871,13 @@ func (s *ISOService) NewUpdateIsoParams(id string) *UpdateIsoParams {
 
 // Updates an ISO file.
 func (s *ISOService) UpdateIso(p *UpdateIsoParams) (*UpdateIsoResponse, error) {
-	resp, err := s.cs.newRequest("updateIso", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.UpdateIsoWithContext(context.Background(), p)
+}
 
+// UpdateIsoWithContext is like UpdateIso, but the request is bound to ctx.
+func (s *ISOService) UpdateIsoWithContext(ctx context.Context, p *UpdateIsoParams) (*UpdateIsoResponse, error) {
 	var r UpdateIsoResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -711,7 +889,7 @@ type UpdateIsoResponse struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -733,7 +911,7 @@ type UpdateIsoResponse struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -763,6 +941,22 @@ func (p *DeleteIsoParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteIsoParams) APIName() string {
+	return "deleteIso"
+}
+
+func (p *DeleteIsoParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteIsoParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteIsoParams) Response() interface{} {
+	return &DeleteIsoResponse{}
+}
+
 func (p *DeleteIsoParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -790,38 +984,37 @@ func (s *ISOService) NewDeleteIsoParams(id string) *DeleteIsoParams {
 
 // Deletes an ISO file.
 func (s *ISOService) DeleteIso(p *DeleteIsoParams) (*DeleteIsoResponse, error) {
-	resp, err := s.cs.newRequest("deleteIso", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteIsoWithContext(context.Background(), p)
+}
 
+// DeleteIsoWithContext is like DeleteIso, but the request is bound to ctx.
+func (s *ISOService) DeleteIsoWithContext(ctx context.Context, p *DeleteIsoParams) (*DeleteIsoResponse, error) {
 	var r DeleteIsoResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteIsoJob is like DeleteIsoWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *ISOService) DeleteIsoJob(ctx context.Context, p *DeleteIsoParams) (*Job[*DeleteIsoResponse], error) {
+	var r DeleteIsoResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteIsoResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type UpdateIsoPermissionsParams struct {
@@ -843,6 +1036,22 @@ func (p *UpdateIsoPermissionsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *UpdateIsoPermissionsParams) APIName() string {
+	return "updateIsoPermissions"
+}
+
+func (p *UpdateIsoPermissionsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *UpdateIsoPermissionsParams) IsAsync() bool {
+	return false
+}
+
+func (p *UpdateIsoPermissionsParams) Response() interface{} {
+	return &UpdateIsoPermissionsResponse{}
+}
+
 func (p *UpdateIsoPermissionsParams) SetAccounts(v []string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -910,13 +1119,13 @@ func (s *ISOService) NewUpdateIsoPermissionsParams(id string) *UpdateIsoPermissi
 
 // Updates ISO permissions
 func (s *ISOService) UpdateIsoPermissions(p *UpdateIsoPermissionsParams) (*UpdateIsoPermissionsResponse, error) {
-	resp, err := s.cs.newRequest("updateIsoPermissions", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.UpdateIsoPermissionsWithContext(context.Background(), p)
+}
 
+// UpdateIsoPermissionsWithContext is like UpdateIsoPermissions, but the request is bound to ctx.
+func (s *ISOService) UpdateIsoPermissionsWithContext(ctx context.Context, p *UpdateIsoPermissionsParams) (*UpdateIsoPermissionsResponse, error) {
 	var r UpdateIsoPermissionsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -924,8 +1133,8 @@ func (s *ISOService) UpdateIsoPermissions(p *UpdateIsoPermissionsParams) (*Updat
 }
 
 type UpdateIsoPermissionsResponse struct {
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     string `json:"success,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListIsoPermissionsParams struct {
@@ -943,6 +1152,22 @@ func (p *ListIsoPermissionsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListIsoPermissionsParams) APIName() string {
+	return "listIsoPermissions"
+}
+
+func (p *ListIsoPermissionsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListIsoPermissionsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListIsoPermissionsParams) Response() interface{} {
+	return &ListIsoPermissionsResponse{}
+}
+
 func (p *ListIsoPermissionsParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -962,19 +1187,36 @@ func (s *ISOService) NewListIsoPermissionsParams(id string) *ListIsoPermissionsP
 
 // List iso visibility and all accounts that have permissions to view this iso.
 func (s *ISOService) ListIsoPermissions(p *ListIsoPermissionsParams) (*ListIsoPermissionsResponse, error) {
-	resp, err := s.cs.newRequest("listIsoPermissions", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListIsoPermissionsWithContext(context.Background(), p)
+}
 
+// ListIsoPermissionsWithContext is like ListIsoPermissions, but the request is bound to ctx.
+func (s *ISOService) ListIsoPermissionsWithContext(ctx context.Context, p *ListIsoPermissionsParams) (*ListIsoPermissionsResponse, error) {
 	var r ListIsoPermissionsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListIsoPermissionsPager returns a Pager over the items of ListIsoPermissions, which does not support paging and
+// returns all items at once.
+func (s *ISOService) ListIsoPermissionsPager(p *ListIsoPermissionsParams) *Pager[*IsoPermission] {
+	return newUnpagedPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*IsoPermission, int, error) {
+		r, err := s.ListIsoPermissionsWithContext(ctx, &ListIsoPermissionsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.IsoPermissions, r.Count, nil
+	})
+}
+
+// ListIsoPermissionsAll is like ListIsoPermissionsWithContext, but returns the items only.
+func (s *ISOService) ListIsoPermissionsAll(ctx context.Context, p *ListIsoPermissionsParams) ([]*IsoPermission, error) {
+	return s.ListIsoPermissionsPager(p).All(ctx)
+}
+
 type ListIsoPermissionsResponse struct {
 	Count          int              `json:"count"`
 	IsoPermissions []*IsoPermission `json:"isopermission"`
@@ -17,7 +17,8 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -57,6 +58,22 @@ func (p *CreateLoadBalancerRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateLoadBalancerRuleParams) APIName() string {
+	return "createLoadBalancerRule"
+}
+
+func (p *CreateLoadBalancerRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateLoadBalancerRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateLoadBalancerRuleParams) Response() interface{} {
+	return &CreateLoadBalancerRuleResponse{}
+}
+
 func (p *CreateLoadBalancerRuleParams) SetAlgorithm(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -121,61 +138,55 @@ func (s *LoadBalancerService) NewCreateLoadBalancerRuleParams(algorithm string,
 
 // Creates a load balancer rule
 func (s *LoadBalancerService) CreateLoadBalancerRule(p *CreateLoadBalancerRuleParams) (*CreateLoadBalancerRuleResponse, error) {
-	resp, err := s.cs.newRequest("createLoadBalancerRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateLoadBalancerRuleWithContext(context.Background(), p)
+}
 
+// CreateLoadBalancerRuleWithContext is like CreateLoadBalancerRule, but the request is bound to ctx.
+func (s *LoadBalancerService) CreateLoadBalancerRuleWithContext(ctx context.Context, p *CreateLoadBalancerRuleParams) (*CreateLoadBalancerRuleResponse, error) {
 	var r CreateLoadBalancerRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateLoadBalancerRuleJob is like CreateLoadBalancerRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) CreateLoadBalancerRuleJob(ctx context.Context, p *CreateLoadBalancerRuleParams) (*Job[*CreateLoadBalancerRuleResponse], error) {
+	var r CreateLoadBalancerRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateLoadBalancerRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Account     string `json:"account,omitempty"`
-	Algorithm   string `json:"algorithm,omitempty"`
-	Cidrlist    string `json:"cidrlist,omitempty"`
-	Description string `json:"description,omitempty"`
-	Domain      string `json:"domain,omitempty"`
-	Domainid    string `json:"domainid,omitempty"`
-	Fordisplay  bool   `json:"fordisplay,omitempty"`
-	Id          string `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Networkid   string `json:"networkid,omitempty"`
-	Privateport string `json:"privateport,omitempty"`
-	Project     string `json:"project,omitempty"`
-	Projectid   string `json:"projectid,omitempty"`
-	Protocol    string `json:"protocol,omitempty"`
-	Publicip    string `json:"publicip,omitempty"`
-	Publicipid  string `json:"publicipid,omitempty"`
-	Publicport  string `json:"publicport,omitempty"`
-	State       string `json:"state,omitempty"`
-	Tags        []Tag  `json:"tags,omitempty"`
-	Zoneid      string `json:"zoneid,omitempty"`
+	JobID       string  `json:"jobid,omitempty"`
+	Account     string  `json:"account,omitempty"`
+	Algorithm   string  `json:"algorithm,omitempty"`
+	Cidrlist    string  `json:"cidrlist,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Domain      string  `json:"domain,omitempty"`
+	Domainid    string  `json:"domainid,omitempty"`
+	Fordisplay  bool    `json:"fordisplay,omitempty"`
+	Id          string  `json:"id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Networkid   string  `json:"networkid,omitempty"`
+	Privateport FlexInt `json:"privateport,omitempty"`
+	Project     string  `json:"project,omitempty"`
+	Projectid   string  `json:"projectid,omitempty"`
+	Protocol    string  `json:"protocol,omitempty"`
+	Publicip    string  `json:"publicip,omitempty"`
+	Publicipid  string  `json:"publicipid,omitempty"`
+	Publicport  FlexInt `json:"publicport,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Tags        []Tag   `json:"tags,omitempty"`
+	Zoneid      string  `json:"zoneid,omitempty"`
 }
 
 type DeleteLoadBalancerRuleParams struct {
@@ -193,6 +204,22 @@ func (p *DeleteLoadBalancerRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteLoadBalancerRuleParams) APIName() string {
+	return "deleteLoadBalancerRule"
+}
+
+func (p *DeleteLoadBalancerRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteLoadBalancerRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteLoadBalancerRuleParams) Response() interface{} {
+	return &DeleteLoadBalancerRuleResponse{}
+}
+
 func (p *DeleteLoadBalancerRuleParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -212,38 +239,37 @@ func (s *LoadBalancerService) NewDeleteLoadBalancerRuleParams(id string) *Delete
 
 // Deletes a load balancer rule.
 func (s *LoadBalancerService) DeleteLoadBalancerRule(p *DeleteLoadBalancerRuleParams) (*DeleteLoadBalancerRuleResponse, error) {
-	resp, err := s.cs.newRequest("deleteLoadBalancerRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteLoadBalancerRuleWithContext(context.Background(), p)
+}
 
+// DeleteLoadBalancerRuleWithContext is like DeleteLoadBalancerRule, but the request is bound to ctx.
+func (s *LoadBalancerService) DeleteLoadBalancerRuleWithContext(ctx context.Context, p *DeleteLoadBalancerRuleParams) (*DeleteLoadBalancerRuleResponse, error) {
 	var r DeleteLoadBalancerRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteLoadBalancerRuleJob is like DeleteLoadBalancerRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) DeleteLoadBalancerRuleJob(ctx context.Context, p *DeleteLoadBalancerRuleParams) (*Job[*DeleteLoadBalancerRuleResponse], error) {
+	var r DeleteLoadBalancerRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteLoadBalancerRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type RemoveFromLoadBalancerRuleParams struct {
@@ -265,6 +291,22 @@ func (p *RemoveFromLoadBalancerRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RemoveFromLoadBalancerRuleParams) APIName() string {
+	return "removeFromLoadBalancerRule"
+}
+
+func (p *RemoveFromLoadBalancerRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RemoveFromLoadBalancerRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *RemoveFromLoadBalancerRuleParams) Response() interface{} {
+	return &RemoveFromLoadBalancerRuleResponse{}
+}
+
 func (p *RemoveFromLoadBalancerRuleParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -293,38 +335,37 @@ func (s *LoadBalancerService) NewRemoveFromLoadBalancerRuleParams(id string, vir
 
 // Removes a virtual machine or a list of virtual machines from a load balancer rule.
 func (s *LoadBalancerService) RemoveFromLoadBalancerRule(p *RemoveFromLoadBalancerRuleParams) (*RemoveFromLoadBalancerRuleResponse, error) {
-	resp, err := s.cs.newRequest("removeFromLoadBalancerRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RemoveFromLoadBalancerRuleWithContext(context.Background(), p)
+}
 
+// RemoveFromLoadBalancerRuleWithContext is like RemoveFromLoadBalancerRule, but the request is bound to ctx.
+func (s *LoadBalancerService) RemoveFromLoadBalancerRuleWithContext(ctx context.Context, p *RemoveFromLoadBalancerRuleParams) (*RemoveFromLoadBalancerRuleResponse, error) {
 	var r RemoveFromLoadBalancerRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// RemoveFromLoadBalancerRuleJob is like RemoveFromLoadBalancerRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) RemoveFromLoadBalancerRuleJob(ctx context.Context, p *RemoveFromLoadBalancerRuleParams) (*Job[*RemoveFromLoadBalancerRuleResponse], error) {
+	var r RemoveFromLoadBalancerRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type RemoveFromLoadBalancerRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type AssignToLoadBalancerRuleParams struct {
@@ -346,6 +387,22 @@ func (p *AssignToLoadBalancerRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AssignToLoadBalancerRuleParams) APIName() string {
+	return "assignToLoadBalancerRule"
+}
+
+func (p *AssignToLoadBalancerRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AssignToLoadBalancerRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *AssignToLoadBalancerRuleParams) Response() interface{} {
+	return &AssignToLoadBalancerRuleResponse{}
+}
+
 func (p *AssignToLoadBalancerRuleParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -374,38 +431,37 @@ func (s *LoadBalancerService) NewAssignToLoadBalancerRuleParams(id string, virtu
 
 // Assigns virtual machine or a list of virtual machines to a load balancer rule.
 func (s *LoadBalancerService) AssignToLoadBalancerRule(p *AssignToLoadBalancerRuleParams) (*AssignToLoadBalancerRuleResponse, error) {
-	resp, err := s.cs.newRequest("assignToLoadBalancerRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.AssignToLoadBalancerRuleWithContext(context.Background(), p)
+}
 
+// AssignToLoadBalancerRuleWithContext is like AssignToLoadBalancerRule, but the request is bound to ctx.
+func (s *LoadBalancerService) AssignToLoadBalancerRuleWithContext(ctx context.Context, p *AssignToLoadBalancerRuleParams) (*AssignToLoadBalancerRuleResponse, error) {
 	var r AssignToLoadBalancerRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// AssignToLoadBalancerRuleJob is like AssignToLoadBalancerRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) AssignToLoadBalancerRuleJob(ctx context.Context, p *AssignToLoadBalancerRuleParams) (*Job[*AssignToLoadBalancerRuleResponse], error) {
+	var r AssignToLoadBalancerRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type AssignToLoadBalancerRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type CreateLBStickinessPolicyParams struct {
@@ -437,6 +493,22 @@ func (p *CreateLBStickinessPolicyParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateLBStickinessPolicyParams) APIName() string {
+	return "createLBStickinessPolicy"
+}
+
+func (p *CreateLBStickinessPolicyParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateLBStickinessPolicyParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateLBStickinessPolicyParams) Response() interface{} {
+	return &CreateLBStickinessPolicyResponse{}
+}
+
 func (p *CreateLBStickinessPolicyParams) SetLbruleid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -482,37 +554,31 @@ func (s *LoadBalancerService) NewCreateLBStickinessPolicyParams(lbruleid string,
 
 // Creates a load balancer stickiness policy
 func (s *LoadBalancerService) CreateLBStickinessPolicy(p *CreateLBStickinessPolicyParams) (*CreateLBStickinessPolicyResponse, error) {
-	resp, err := s.cs.newRequest("createLBStickinessPolicy", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateLBStickinessPolicyWithContext(context.Background(), p)
+}
 
+// CreateLBStickinessPolicyWithContext is like CreateLBStickinessPolicy, but the request is bound to ctx.
+func (s *LoadBalancerService) CreateLBStickinessPolicyWithContext(ctx context.Context, p *CreateLBStickinessPolicyParams) (*CreateLBStickinessPolicyResponse, error) {
 	var r CreateLBStickinessPolicyResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateLBStickinessPolicyJob is like CreateLBStickinessPolicyWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) CreateLBStickinessPolicyJob(ctx context.Context, p *CreateLBStickinessPolicyParams) (*Job[*CreateLBStickinessPolicyResponse], error) {
+	var r CreateLBStickinessPolicyResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateLBStickinessPolicyResponse struct {
@@ -551,6 +617,22 @@ func (p *DeleteLBStickinessPolicyParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteLBStickinessPolicyParams) APIName() string {
+	return "deleteLBStickinessPolicy"
+}
+
+func (p *DeleteLBStickinessPolicyParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteLBStickinessPolicyParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteLBStickinessPolicyParams) Response() interface{} {
+	return &DeleteLBStickinessPolicyResponse{}
+}
+
 func (p *DeleteLBStickinessPolicyParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -570,38 +652,37 @@ func (s *LoadBalancerService) NewDeleteLBStickinessPolicyParams(id string) *Dele
 
 // Deletes a load balancer stickiness policy.
 func (s *LoadBalancerService) DeleteLBStickinessPolicy(p *DeleteLBStickinessPolicyParams) (*DeleteLBStickinessPolicyResponse, error) {
-	resp, err := s.cs.newRequest("deleteLBStickinessPolicy", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteLBStickinessPolicyWithContext(context.Background(), p)
+}
 
+// DeleteLBStickinessPolicyWithContext is like DeleteLBStickinessPolicy, but the request is bound to ctx.
+func (s *LoadBalancerService) DeleteLBStickinessPolicyWithContext(ctx context.Context, p *DeleteLBStickinessPolicyParams) (*DeleteLBStickinessPolicyResponse, error) {
 	var r DeleteLBStickinessPolicyResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteLBStickinessPolicyJob is like DeleteLBStickinessPolicyWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) DeleteLBStickinessPolicyJob(ctx context.Context, p *DeleteLBStickinessPolicyParams) (*Job[*DeleteLBStickinessPolicyResponse], error) {
+	var r DeleteLBStickinessPolicyResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteLBStickinessPolicyResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListLoadBalancerRulesParams struct {
@@ -625,6 +706,14 @@ func (p *ListLoadBalancerRulesParams) toURLValues() url.Values {
 	if v, found := p.p["networkid"]; found {
 		u.Set("networkid", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["publicipid"]; found {
 		u.Set("publicipid", v.(string))
 	}
@@ -637,6 +726,22 @@ func (p *ListLoadBalancerRulesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListLoadBalancerRulesParams) APIName() string {
+	return "listLoadBalancerRules"
+}
+
+func (p *ListLoadBalancerRulesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListLoadBalancerRulesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListLoadBalancerRulesParams) Response() interface{} {
+	return &ListLoadBalancerRulesResponse{}
+}
+
 func (p *ListLoadBalancerRulesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -669,6 +774,22 @@ func (p *ListLoadBalancerRulesParams) SetNetworkid(v string) {
 	return
 }
 
+func (p *ListLoadBalancerRulesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListLoadBalancerRulesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListLoadBalancerRulesParams) SetPublicipid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -701,47 +822,132 @@ func (s *LoadBalancerService) NewListLoadBalancerRulesParams() *ListLoadBalancer
 	return p
 }
 
-// Lists load balancer rules.
-func (s *LoadBalancerService) ListLoadBalancerRules(p *ListLoadBalancerRulesParams) (*ListLoadBalancerRulesResponse, error) {
-	resp, err := s.cs.newRequest("listLoadBalancerRules", p.toURLValues())
+// GetLoadBalancerRuleID returns the ID of the load balancer rule with the given name. The error is a
+// *NotFoundError if there is no such load balancer rule and an *AmbiguousError if there are several.
+func (s *LoadBalancerService) GetLoadBalancerRuleID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetLoadBalancerRuleIDWithContext(context.Background(), name, opts...)
+}
+
+// GetLoadBalancerRuleIDWithContext is like GetLoadBalancerRuleID, but the requests are bound to ctx.
+func (s *LoadBalancerService) GetLoadBalancerRuleIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetLoadBalancerRuleByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
 	}
+	return r.Id, count, nil
+}
 
+// GetLoadBalancerRuleByName returns the load balancer rule with the given name, like GetLoadBalancerRuleID.
+func (s *LoadBalancerService) GetLoadBalancerRuleByName(name string, opts ...OptionFunc) (*LoadBalancerRule, int, error) {
+	return s.GetLoadBalancerRuleByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetLoadBalancerRuleByNameWithContext is like GetLoadBalancerRuleByName, but the requests are bound to ctx.
+func (s *LoadBalancerService) GetLoadBalancerRuleByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*LoadBalancerRule, int, error) {
+	p := &ListLoadBalancerRulesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListLoadBalancerRulesAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("LoadBalancerRule", name, l, func(v *LoadBalancerRule) string { return v.Name }, func(v *LoadBalancerRule) string { return v.Id })
+}
+
+// GetLoadBalancerRuleByID returns the load balancer rule with the given ID. The error is a *NotFoundError if there
+// is no such load balancer rule.
+func (s *LoadBalancerService) GetLoadBalancerRuleByID(id string, opts ...OptionFunc) (*LoadBalancerRule, int, error) {
+	return s.GetLoadBalancerRuleByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetLoadBalancerRuleByIDWithContext is like GetLoadBalancerRuleByID, but the requests are bound to ctx.
+func (s *LoadBalancerService) GetLoadBalancerRuleByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*LoadBalancerRule, int, error) {
+	p := &ListLoadBalancerRulesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListLoadBalancerRulesAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "LoadBalancerRule", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("LoadBalancerRule", id, l, func(v *LoadBalancerRule) string { return v.Id })
+}
+
+// Lists load balancer rules.
+func (s *LoadBalancerService) ListLoadBalancerRules(p *ListLoadBalancerRulesParams) (*ListLoadBalancerRulesResponse, error) {
+	return s.ListLoadBalancerRulesWithContext(context.Background(), p)
+}
+
+// ListLoadBalancerRulesWithContext is like ListLoadBalancerRules, but the request is bound to ctx.
+func (s *LoadBalancerService) ListLoadBalancerRulesWithContext(ctx context.Context, p *ListLoadBalancerRulesParams) (*ListLoadBalancerRulesResponse, error) {
 	var r ListLoadBalancerRulesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListLoadBalancerRulesPager returns a Pager over the items of all pages of ListLoadBalancerRules.
+func (s *LoadBalancerService) ListLoadBalancerRulesPager(p *ListLoadBalancerRulesParams) *Pager[*LoadBalancerRule] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*LoadBalancerRule, int, error) {
+		r, err := s.ListLoadBalancerRulesWithContext(ctx, &ListLoadBalancerRulesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.LoadBalancerRules, r.Count, nil
+	})
+}
+
+// ListLoadBalancerRulesAll is like ListLoadBalancerRulesWithContext, but requests all pages and returns the items of all of them.
+func (s *LoadBalancerService) ListLoadBalancerRulesAll(ctx context.Context, p *ListLoadBalancerRulesParams) ([]*LoadBalancerRule, error) {
+	return s.ListLoadBalancerRulesPager(p).All(ctx)
+}
+
 type ListLoadBalancerRulesResponse struct {
 	Count             int                 `json:"count"`
 	LoadBalancerRules []*LoadBalancerRule `json:"loadbalancerrule"`
 }
 
 type LoadBalancerRule struct {
-	Account     string `json:"account,omitempty"`
-	Algorithm   string `json:"algorithm,omitempty"`
-	Cidrlist    string `json:"cidrlist,omitempty"`
-	Description string `json:"description,omitempty"`
-	Domain      string `json:"domain,omitempty"`
-	Domainid    string `json:"domainid,omitempty"`
-	Fordisplay  bool   `json:"fordisplay,omitempty"`
-	Id          string `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Networkid   string `json:"networkid,omitempty"`
-	Privateport string `json:"privateport,omitempty"`
-	Project     string `json:"project,omitempty"`
-	Projectid   string `json:"projectid,omitempty"`
-	Protocol    string `json:"protocol,omitempty"`
-	Publicip    string `json:"publicip,omitempty"`
-	Publicipid  string `json:"publicipid,omitempty"`
-	Publicport  string `json:"publicport,omitempty"`
-	State       string `json:"state,omitempty"`
-	Tags        []Tag  `json:"tags,omitempty"`
-	Zoneid      string `json:"zoneid,omitempty"`
+	Account     string  `json:"account,omitempty"`
+	Algorithm   string  `json:"algorithm,omitempty"`
+	Cidrlist    string  `json:"cidrlist,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Domain      string  `json:"domain,omitempty"`
+	Domainid    string  `json:"domainid,omitempty"`
+	Fordisplay  bool    `json:"fordisplay,omitempty"`
+	Id          string  `json:"id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Networkid   string  `json:"networkid,omitempty"`
+	Privateport FlexInt `json:"privateport,omitempty"`
+	Project     string  `json:"project,omitempty"`
+	Projectid   string  `json:"projectid,omitempty"`
+	Protocol    string  `json:"protocol,omitempty"`
+	Publicip    string  `json:"publicip,omitempty"`
+	Publicipid  string  `json:"publicipid,omitempty"`
+	Publicport  FlexInt `json:"publicport,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Tags        []Tag   `json:"tags,omitempty"`
+	Zoneid      string  `json:"zoneid,omitempty"`
 }
 
 type ListLBStickinessPoliciesParams struct {
@@ -759,9 +965,33 @@ func (p *ListLBStickinessPoliciesParams) toURLValues() url.Values {
 	if v, found := p.p["keyword"]; found {
 		u.Set("keyword", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	return u
 }
 
+func (p *ListLBStickinessPoliciesParams) APIName() string {
+	return "listLBStickinessPolicies"
+}
+
+func (p *ListLBStickinessPoliciesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListLBStickinessPoliciesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListLBStickinessPoliciesParams) Response() interface{} {
+	return &ListLBStickinessPoliciesResponse{}
+}
+
 func (p *ListLBStickinessPoliciesParams) SetLbruleid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -778,6 +1008,22 @@ func (p *ListLBStickinessPoliciesParams) SetKeyword(v string) {
 	return
 }
 
+func (p *ListLBStickinessPoliciesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListLBStickinessPoliciesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 // You should always use this function to get a new ListLBStickinessPoliciesParams instance,
 // as then you are sure you have configured all required params
 func (s *LoadBalancerService) NewListLBStickinessPoliciesParams(lbruleid string) *ListLBStickinessPoliciesParams {
@@ -789,19 +1035,35 @@ func (s *LoadBalancerService) NewListLBStickinessPoliciesParams(lbruleid string)
 
 // Lists load balancer stickiness policies.
 func (s *LoadBalancerService) ListLBStickinessPolicies(p *ListLBStickinessPoliciesParams) (*ListLBStickinessPoliciesResponse, error) {
-	resp, err := s.cs.newRequest("listLBStickinessPolicies", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListLBStickinessPoliciesWithContext(context.Background(), p)
+}
 
+// ListLBStickinessPoliciesWithContext is like ListLBStickinessPolicies, but the request is bound to ctx.
+func (s *LoadBalancerService) ListLBStickinessPoliciesWithContext(ctx context.Context, p *ListLBStickinessPoliciesParams) (*ListLBStickinessPoliciesResponse, error) {
 	var r ListLBStickinessPoliciesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListLBStickinessPoliciesPager returns a Pager over the items of all pages of ListLBStickinessPolicies.
+func (s *LoadBalancerService) ListLBStickinessPoliciesPager(p *ListLBStickinessPoliciesParams) *Pager[*LBStickinessPolicy] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*LBStickinessPolicy, int, error) {
+		r, err := s.ListLBStickinessPoliciesWithContext(ctx, &ListLBStickinessPoliciesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.LBStickinessPolicies, r.Count, nil
+	})
+}
+
+// ListLBStickinessPoliciesAll is like ListLBStickinessPoliciesWithContext, but requests all pages and returns the items of all of them.
+func (s *LoadBalancerService) ListLBStickinessPoliciesAll(ctx context.Context, p *ListLBStickinessPoliciesParams) ([]*LBStickinessPolicy, error) {
+	return s.ListLBStickinessPoliciesPager(p).All(ctx)
+}
+
 type ListLBStickinessPoliciesResponse struct {
 	Count                int                   `json:"count"`
 	LBStickinessPolicies []*LBStickinessPolicy `json:"lbstickinesspolicy"`
@@ -842,9 +1104,33 @@ func (p *ListLoadBalancerRuleInstancesParams) toURLValues() url.Values {
 	if v, found := p.p["keyword"]; found {
 		u.Set("keyword", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	return u
 }
 
+func (p *ListLoadBalancerRuleInstancesParams) APIName() string {
+	return "listLoadBalancerRuleInstances"
+}
+
+func (p *ListLoadBalancerRuleInstancesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListLoadBalancerRuleInstancesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListLoadBalancerRuleInstancesParams) Response() interface{} {
+	return &ListLoadBalancerRuleInstancesResponse{}
+}
+
 func (p *ListLoadBalancerRuleInstancesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -861,6 +1147,22 @@ func (p *ListLoadBalancerRuleInstancesParams) SetKeyword(v string) {
 	return
 }
 
+func (p *ListLoadBalancerRuleInstancesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListLoadBalancerRuleInstancesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 // You should always use this function to get a new ListLoadBalancerRuleInstancesParams instance,
 // as then you are sure you have configured all required params
 func (s *LoadBalancerService) NewListLoadBalancerRuleInstancesParams(id string) *ListLoadBalancerRuleInstancesParams {
@@ -872,19 +1174,36 @@ func (s *LoadBalancerService) NewListLoadBalancerRuleInstancesParams(id string)
 
 // List all virtual machine instances that are assigned to a load balancer rule.
 func (s *LoadBalancerService) ListLoadBalancerRuleInstances(p *ListLoadBalancerRuleInstancesParams) (*ListLoadBalancerRuleInstancesResponse, error) {
-	resp, err := s.cs.newRequest("listLoadBalancerRuleInstances", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListLoadBalancerRuleInstancesWithContext(context.Background(), p)
+}
 
+// ListLoadBalancerRuleInstancesWithContext is like ListLoadBalancerRuleInstances, but the request is bound to ctx.
+func (s *LoadBalancerService) ListLoadBalancerRuleInstancesWithContext(ctx context.Context, p *ListLoadBalancerRuleInstancesParams) (*ListLoadBalancerRuleInstancesResponse, error) {
 	var r ListLoadBalancerRuleInstancesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListLoadBalancerRuleInstancesPager returns a Pager over the items of all pages of ListLoadBalancerRuleInstances.
+// The items are the virtual machines, so it does not work with lbvmips set.
+func (s *LoadBalancerService) ListLoadBalancerRuleInstancesPager(p *ListLoadBalancerRuleInstancesParams) *Pager[*VirtualMachine] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*VirtualMachine, int, error) {
+		r, err := s.ListLoadBalancerRuleInstancesWithContext(ctx, &ListLoadBalancerRuleInstancesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.LoadBalancerRuleInstances, r.Count, nil
+	})
+}
+
+// ListLoadBalancerRuleInstancesAll is like ListLoadBalancerRuleInstancesWithContext, but requests all pages and returns the items of all of them.
+func (s *LoadBalancerService) ListLoadBalancerRuleInstancesAll(ctx context.Context, p *ListLoadBalancerRuleInstancesParams) ([]*VirtualMachine, error) {
+	return s.ListLoadBalancerRuleInstancesPager(p).All(ctx)
+}
+
 type ListLoadBalancerRuleInstancesResponse struct {
 	Count                     int                         `json:"count"`
 	LBRuleVMIDIPs             []*LoadBalancerRuleInstance `json:"lbrulevmidip,omitempty"`
@@ -917,6 +1236,22 @@ func (p *UpdateLoadBalancerRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *UpdateLoadBalancerRuleParams) APIName() string {
+	return "updateLoadBalancerRule"
+}
+
+func (p *UpdateLoadBalancerRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *UpdateLoadBalancerRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *UpdateLoadBalancerRuleParams) Response() interface{} {
+	return &UpdateLoadBalancerRuleResponse{}
+}
+
 func (p *UpdateLoadBalancerRuleParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -953,59 +1288,53 @@ func (s *LoadBalancerService) NewUpdateLoadBalancerRuleParams(id string, algorit
 
 // Updates load balancer
 func (s *LoadBalancerService) UpdateLoadBalancerRule(p *UpdateLoadBalancerRuleParams) (*UpdateLoadBalancerRuleResponse, error) {
-	resp, err := s.cs.newRequest("updateLoadBalancerRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.UpdateLoadBalancerRuleWithContext(context.Background(), p)
+}
 
+// UpdateLoadBalancerRuleWithContext is like UpdateLoadBalancerRule, but the request is bound to ctx.
+func (s *LoadBalancerService) UpdateLoadBalancerRuleWithContext(ctx context.Context, p *UpdateLoadBalancerRuleParams) (*UpdateLoadBalancerRuleResponse, error) {
 	var r UpdateLoadBalancerRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// UpdateLoadBalancerRuleJob is like UpdateLoadBalancerRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *LoadBalancerService) UpdateLoadBalancerRuleJob(ctx context.Context, p *UpdateLoadBalancerRuleParams) (*Job[*UpdateLoadBalancerRuleResponse], error) {
+	var r UpdateLoadBalancerRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type UpdateLoadBalancerRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Account     string `json:"account,omitempty"`
-	Algorithm   string `json:"algorithm,omitempty"`
-	Cidrlist    string `json:"cidrlist,omitempty"`
-	Description string `json:"description,omitempty"`
-	Domain      string `json:"domain,omitempty"`
-	Domainid    string `json:"domainid,omitempty"`
-	Fordisplay  bool   `json:"fordisplay,omitempty"`
-	Id          string `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Networkid   string `json:"networkid,omitempty"`
-	Privateport string `json:"privateport,omitempty"`
-	Project     string `json:"project,omitempty"`
-	Projectid   string `json:"projectid,omitempty"`
-	Protocol    string `json:"protocol,omitempty"`
-	Publicip    string `json:"publicip,omitempty"`
-	Publicipid  string `json:"publicipid,omitempty"`
-	Publicport  string `json:"publicport,omitempty"`
-	State       string `json:"state,omitempty"`
-	Tags        []Tag  `json:"tags,omitempty"`
-	Zoneid      string `json:"zoneid,omitempty"`
+	JobID       string  `json:"jobid,omitempty"`
+	Account     string  `json:"account,omitempty"`
+	Algorithm   string  `json:"algorithm,omitempty"`
+	Cidrlist    string  `json:"cidrlist,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Domain      string  `json:"domain,omitempty"`
+	Domainid    string  `json:"domainid,omitempty"`
+	Fordisplay  bool    `json:"fordisplay,omitempty"`
+	Id          string  `json:"id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Networkid   string  `json:"networkid,omitempty"`
+	Privateport FlexInt `json:"privateport,omitempty"`
+	Project     string  `json:"project,omitempty"`
+	Projectid   string  `json:"projectid,omitempty"`
+	Protocol    string  `json:"protocol,omitempty"`
+	Publicip    string  `json:"publicip,omitempty"`
+	Publicipid  string  `json:"publicipid,omitempty"`
+	Publicport  FlexInt `json:"publicport,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Tags        []Tag   `json:"tags,omitempty"`
+	Zoneid      string  `json:"zoneid,omitempty"`
 }
@@ -17,7 +17,8 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -84,6 +85,22 @@ func (p *ListPortForwardingRulesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListPortForwardingRulesParams) APIName() string {
+	return "listPortForwardingRules"
+}
+
+func (p *ListPortForwardingRulesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListPortForwardingRulesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListPortForwardingRulesParams) Response() interface{} {
+	return &ListPortForwardingRulesResponse{}
+}
+
 func (p *ListPortForwardingRulesParams) SetAccount(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -198,47 +215,58 @@ func (s *NatPortForwardService) NewListPortForwardingRulesParams() *ListPortForw
 
 // Lists all port forwarding rules for an IP address.
 func (s *NatPortForwardService) ListPortForwardingRules(p *ListPortForwardingRulesParams) (*ListPortForwardingRulesResponse, error) {
-	resp, err := s.cs.newRequest("listPortForwardingRules", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = convertFirewallServiceResponse(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.ListPortForwardingRulesWithContext(context.Background(), p)
+}
 
+// ListPortForwardingRulesWithContext is like ListPortForwardingRules, but the request is bound to ctx.
+func (s *NatPortForwardService) ListPortForwardingRulesWithContext(ctx context.Context, p *ListPortForwardingRulesParams) (*ListPortForwardingRulesResponse, error) {
 	var r ListPortForwardingRulesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListPortForwardingRulesPager returns a Pager over the items of all pages of ListPortForwardingRules.
+func (s *NatPortForwardService) ListPortForwardingRulesPager(p *ListPortForwardingRulesParams) *Pager[*PortForwardingRule] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*PortForwardingRule, int, error) {
+		r, err := s.ListPortForwardingRulesWithContext(ctx, &ListPortForwardingRulesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.PortForwardingRules, r.Count, nil
+	})
+}
+
+// ListPortForwardingRulesAll is like ListPortForwardingRulesWithContext, but requests all pages and returns the items of all of them.
+func (s *NatPortForwardService) ListPortForwardingRulesAll(ctx context.Context, p *ListPortForwardingRulesParams) ([]*PortForwardingRule, error) {
+	return s.ListPortForwardingRulesPager(p).All(ctx)
+}
+
 type ListPortForwardingRulesResponse struct {
 	Count               int                   `json:"count"`
 	PortForwardingRules []*PortForwardingRule `json:"portforwardingrule"`
 }
 
 type PortForwardingRule struct {
-	Cidrlist                  string `json:"cidrlist,omitempty"`
-	Fordisplay                bool   `json:"fordisplay,omitempty"`
-	Id                        string `json:"id,omitempty"`
-	Ipaddress                 string `json:"ipaddress,omitempty"`
-	Ipaddressid               string `json:"ipaddressid,omitempty"`
-	Networkid                 string `json:"networkid,omitempty"`
-	Privateendport            string `json:"privateendport,omitempty"`
-	Privateport               string `json:"privateport,omitempty"`
-	Protocol                  string `json:"protocol,omitempty"`
-	Publicendport             string `json:"publicendport,omitempty"`
-	Publicport                string `json:"publicport,omitempty"`
-	State                     string `json:"state,omitempty"`
-	Tags                      []Tag  `json:"tags,omitempty"`
-	Virtualmachinedisplayname string `json:"virtualmachinedisplayname,omitempty"`
-	Virtualmachineid          string `json:"virtualmachineid,omitempty"`
-	Virtualmachinename        string `json:"virtualmachinename,omitempty"`
-	Vmguestip                 string `json:"vmguestip,omitempty"`
+	Cidrlist                  string  `json:"cidrlist,omitempty"`
+	Fordisplay                bool    `json:"fordisplay,omitempty"`
+	Id                        string  `json:"id,omitempty"`
+	Ipaddress                 string  `json:"ipaddress,omitempty"`
+	Ipaddressid               string  `json:"ipaddressid,omitempty"`
+	Networkid                 string  `json:"networkid,omitempty"`
+	Privateendport            FlexInt `json:"privateendport,omitempty"`
+	Privateport               FlexInt `json:"privateport,omitempty"`
+	Protocol                  string  `json:"protocol,omitempty"`
+	Publicendport             FlexInt `json:"publicendport,omitempty"`
+	Publicport                FlexInt `json:"publicport,omitempty"`
+	State                     string  `json:"state,omitempty"`
+	Tags                      []Tag   `json:"tags,omitempty"`
+	Virtualmachinedisplayname string  `json:"virtualmachinedisplayname,omitempty"`
+	Virtualmachineid          string  `json:"virtualmachineid,omitempty"`
+	Virtualmachinename        string  `json:"virtualmachinename,omitempty"`
+	Vmguestip                 string  `json:"vmguestip,omitempty"`
 }
 
 type CreatePortForwardingRuleParams struct {
@@ -285,6 +313,22 @@ func (p *CreatePortForwardingRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreatePortForwardingRuleParams) APIName() string {
+	return "createPortForwardingRule"
+}
+
+func (p *CreatePortForwardingRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreatePortForwardingRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreatePortForwardingRuleParams) Response() interface{} {
+	return &CreatePortForwardingRuleResponse{}
+}
+
 func (p *CreatePortForwardingRuleParams) SetIpaddressid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -373,63 +417,52 @@ func (s *NatPortForwardService) NewCreatePortForwardingRuleParams(ipaddressid st
 
 // Creates a port forwarding rule
 func (s *NatPortForwardService) CreatePortForwardingRule(p *CreatePortForwardingRuleParams) (*CreatePortForwardingRuleResponse, error) {
-	resp, err := s.cs.newRequest("createPortForwardingRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreatePortForwardingRuleWithContext(context.Background(), p)
+}
 
+// CreatePortForwardingRuleWithContext is like CreatePortForwardingRule, but the request is bound to ctx.
+func (s *NatPortForwardService) CreatePortForwardingRuleWithContext(ctx context.Context, p *CreatePortForwardingRuleParams) (*CreatePortForwardingRuleResponse, error) {
 	var r CreatePortForwardingRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		b, err = convertFirewallServiceResponse(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreatePortForwardingRuleJob is like CreatePortForwardingRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NatPortForwardService) CreatePortForwardingRuleJob(ctx context.Context, p *CreatePortForwardingRuleParams) (*Job[*CreatePortForwardingRuleResponse], error) {
+	var r CreatePortForwardingRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreatePortForwardingRuleResponse struct {
-	JobID                     string `json:"jobid,omitempty"`
-	Cidrlist                  string `json:"cidrlist,omitempty"`
-	Fordisplay                bool   `json:"fordisplay,omitempty"`
-	Id                        string `json:"id,omitempty"`
-	Ipaddress                 string `json:"ipaddress,omitempty"`
-	Ipaddressid               string `json:"ipaddressid,omitempty"`
-	Networkid                 string `json:"networkid,omitempty"`
-	Privateendport            string `json:"privateendport,omitempty"`
-	Privateport               string `json:"privateport,omitempty"`
-	Protocol                  string `json:"protocol,omitempty"`
-	Publicendport             string `json:"publicendport,omitempty"`
-	Publicport                string `json:"publicport,omitempty"`
-	State                     string `json:"state,omitempty"`
-	Tags                      []Tag  `json:"tags,omitempty"`
-	Virtualmachinedisplayname string `json:"virtualmachinedisplayname,omitempty"`
-	Virtualmachineid          string `json:"virtualmachineid,omitempty"`
-	Virtualmachinename        string `json:"virtualmachinename,omitempty"`
-	Vmguestip                 string `json:"vmguestip,omitempty"`
+	JobID                     string  `json:"jobid,omitempty"`
+	Cidrlist                  string  `json:"cidrlist,omitempty"`
+	Fordisplay                bool    `json:"fordisplay,omitempty"`
+	Id                        string  `json:"id,omitempty"`
+	Ipaddress                 string  `json:"ipaddress,omitempty"`
+	Ipaddressid               string  `json:"ipaddressid,omitempty"`
+	Networkid                 string  `json:"networkid,omitempty"`
+	Privateendport            FlexInt `json:"privateendport,omitempty"`
+	Privateport               FlexInt `json:"privateport,omitempty"`
+	Protocol                  string  `json:"protocol,omitempty"`
+	Publicendport             FlexInt `json:"publicendport,omitempty"`
+	Publicport                FlexInt `json:"publicport,omitempty"`
+	State                     string  `json:"state,omitempty"`
+	Tags                      []Tag   `json:"tags,omitempty"`
+	Virtualmachinedisplayname string  `json:"virtualmachinedisplayname,omitempty"`
+	Virtualmachineid          string  `json:"virtualmachineid,omitempty"`
+	Virtualmachinename        string  `json:"virtualmachinename,omitempty"`
+	Vmguestip                 string  `json:"vmguestip,omitempty"`
 }
 
 type DeletePortForwardingRuleParams struct {
@@ -447,6 +480,22 @@ func (p *DeletePortForwardingRuleParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeletePortForwardingRuleParams) APIName() string {
+	return "deletePortForwardingRule"
+}
+
+func (p *DeletePortForwardingRuleParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeletePortForwardingRuleParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeletePortForwardingRuleParams) Response() interface{} {
+	return &DeletePortForwardingRuleResponse{}
+}
+
 func (p *DeletePortForwardingRuleParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -466,41 +515,35 @@ func (s *NatPortForwardService) NewDeletePortForwardingRuleParams(id string) *De
 
 // Deletes a port forwarding rule
 func (s *NatPortForwardService) DeletePortForwardingRule(p *DeletePortForwardingRuleParams) (*DeletePortForwardingRuleResponse, error) {
-	resp, err := s.cs.newRequest("deletePortForwardingRule", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeletePortForwardingRuleWithContext(context.Background(), p)
+}
 
+// DeletePortForwardingRuleWithContext is like DeletePortForwardingRule, but the request is bound to ctx.
+func (s *NatPortForwardService) DeletePortForwardingRuleWithContext(ctx context.Context, p *DeletePortForwardingRuleParams) (*DeletePortForwardingRuleResponse, error) {
 	var r DeletePortForwardingRuleResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = convertFirewallServiceResponse(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeletePortForwardingRuleJob is like DeletePortForwardingRuleWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NatPortForwardService) DeletePortForwardingRuleJob(ctx context.Context, p *DeletePortForwardingRuleParams) (*Job[*DeletePortForwardingRuleResponse], error) {
+	var r DeletePortForwardingRuleResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeletePortForwardingRuleResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
@@ -17,7 +17,8 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -41,6 +42,22 @@ func (p *AddIpToNicParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AddIpToNicParams) APIName() string {
+	return "addIpToNic"
+}
+
+func (p *AddIpToNicParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AddIpToNicParams) IsAsync() bool {
+	return true
+}
+
+func (p *AddIpToNicParams) Response() interface{} {
+	return &AddIpToNicResponse{}
+}
+
 func (p *AddIpToNicParams) SetNicid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -68,37 +85,31 @@ func (s *NicService) NewAddIpToNicParams(nicid string) *AddIpToNicParams {
 
 // Assigns secondary IP to NIC
 func (s *NicService) AddIpToNic(p *AddIpToNicParams) (*AddIpToNicResponse, error) {
-	resp, err := s.cs.newRequest("addIpToNic", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.AddIpToNicWithContext(context.Background(), p)
+}
 
+// AddIpToNicWithContext is like AddIpToNic, but the request is bound to ctx.
+func (s *NicService) AddIpToNicWithContext(ctx context.Context, p *AddIpToNicParams) (*AddIpToNicResponse, error) {
 	var r AddIpToNicResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// AddIpToNicJob is like AddIpToNicWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NicService) AddIpToNicJob(ctx context.Context, p *AddIpToNicParams) (*Job[*AddIpToNicResponse], error) {
+	var r AddIpToNicResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type AddIpToNicResponse struct {
@@ -125,6 +136,22 @@ func (p *RemoveIpFromNicParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RemoveIpFromNicParams) APIName() string {
+	return "removeIpFromNic"
+}
+
+func (p *RemoveIpFromNicParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RemoveIpFromNicParams) IsAsync() bool {
+	return true
+}
+
+func (p *RemoveIpFromNicParams) Response() interface{} {
+	return &RemoveIpFromNicResponse{}
+}
+
 func (p *RemoveIpFromNicParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -144,38 +171,37 @@ func (s *NicService) NewRemoveIpFromNicParams(id string) *RemoveIpFromNicParams
 
 // Removes secondary IP from the NIC.
 func (s *NicService) RemoveIpFromNic(p *RemoveIpFromNicParams) (*RemoveIpFromNicResponse, error) {
-	resp, err := s.cs.newRequest("removeIpFromNic", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RemoveIpFromNicWithContext(context.Background(), p)
+}
 
+// RemoveIpFromNicWithContext is like RemoveIpFromNic, but the request is bound to ctx.
+func (s *NicService) RemoveIpFromNicWithContext(ctx context.Context, p *RemoveIpFromNicParams) (*RemoveIpFromNicResponse, error) {
 	var r RemoveIpFromNicResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// RemoveIpFromNicJob is like RemoveIpFromNicWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NicService) RemoveIpFromNicJob(ctx context.Context, p *RemoveIpFromNicParams) (*Job[*RemoveIpFromNicResponse], error) {
+	var r RemoveIpFromNicResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type RemoveIpFromNicResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListNicsParams struct {
@@ -190,12 +216,36 @@ func (p *ListNicsParams) toURLValues() url.Values {
 	if v, found := p.p["nicid"]; found {
 		u.Set("nicid", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["virtualmachineid"]; found {
 		u.Set("virtualmachineid", v.(string))
 	}
 	return u
 }
 
+func (p *ListNicsParams) APIName() string {
+	return "listNics"
+}
+
+func (p *ListNicsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListNicsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListNicsParams) Response() interface{} {
+	return &ListNicsResponse{}
+}
+
 func (p *ListNicsParams) SetNicid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -204,6 +254,22 @@ func (p *ListNicsParams) SetNicid(v string) {
 	return
 }
 
+func (p *ListNicsParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListNicsParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListNicsParams) SetVirtualmachineid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -223,19 +289,35 @@ func (s *NicService) NewListNicsParams(virtualmachineid string) *ListNicsParams
 
 // list the vm nics  IP to NIC
 func (s *NicService) ListNics(p *ListNicsParams) (*ListNicsResponse, error) {
-	resp, err := s.cs.newRequest("listNics", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListNicsWithContext(context.Background(), p)
+}
 
+// ListNicsWithContext is like ListNics, but the request is bound to ctx.
+func (s *NicService) ListNicsWithContext(ctx context.Context, p *ListNicsParams) (*ListNicsResponse, error) {
 	var r ListNicsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListNicsPager returns a Pager over the items of all pages of ListNics.
+func (s *NicService) ListNicsPager(p *ListNicsParams) *Pager[Nic] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]Nic, int, error) {
+		r, err := s.ListNicsWithContext(ctx, &ListNicsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Nics, r.Count, nil
+	})
+}
+
+// ListNicsAll is like ListNicsWithContext, but requests all pages and returns the items of all of them.
+func (s *NicService) ListNicsAll(ctx context.Context, p *ListNicsParams) ([]Nic, error) {
+	return s.ListNicsPager(p).All(ctx)
+}
+
 type ListNicsResponse struct {
 	Count int   `json:"count"`
 	Nics  []Nic `json:"nic"`
@@ -297,6 +379,14 @@ func (p *ListPublicIpAddressesParams) toURLValues() url.Values {
 	if v, found := p.p["keyword"]; found {
 		u.Set("keyword", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["tags"]; found {
 		i := 0
 		for k, vv := range v.(map[string]string) {
@@ -311,6 +401,22 @@ func (p *ListPublicIpAddressesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListPublicIpAddressesParams) APIName() string {
+	return "listPublicIpAddresses"
+}
+
+func (p *ListPublicIpAddressesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListPublicIpAddressesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListPublicIpAddressesParams) Response() interface{} {
+	return &ListPublicIpAddressesResponse{}
+}
+
 func (p *ListPublicIpAddressesParams) SetAssociatednetworkid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -367,6 +473,22 @@ func (p *ListPublicIpAddressesParams) SetKeyword(v string) {
 	return
 }
 
+func (p *ListPublicIpAddressesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListPublicIpAddressesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListPublicIpAddressesParams) SetTags(v map[string]string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -391,21 +513,133 @@ func (s *NicService) NewListPublicIpAddressesParams() *ListPublicIpAddressesPara
 	return p
 }
 
-// Lists all public ip addresses
-func (s *NicService) ListPublicIpAddresses(p *ListPublicIpAddressesParams) (*ListPublicIpAddressesResponse, error) {
-	resp, err := s.cs.newRequest("listPublicIpAddresses", p.toURLValues())
+// GetPublicIpAddressID returns the ID of the public IP address with the given address, e.g. "203.0.113.10",
+// as public IP addresses have no name. The error is a *NotFoundError if there is no such public IP address
+// and an *AmbiguousError if there are several.
+func (s *NicService) GetPublicIpAddressID(ipaddress string, opts ...OptionFunc) (string, int, error) {
+	return s.GetPublicIpAddressIDWithContext(context.Background(), ipaddress, opts...)
+}
+
+// GetPublicIpAddressIDWithContext is like GetPublicIpAddressID, but the requests are bound to ctx.
+func (s *NicService) GetPublicIpAddressIDWithContext(ctx context.Context, ipaddress string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetPublicIpAddressByNameWithContext(ctx, ipaddress, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
 	}
+	return r.Id, count, nil
+}
+
+// GetPublicIpAddressByName returns the public IP address with the given address, like GetPublicIpAddressID.
+func (s *NicService) GetPublicIpAddressByName(ipaddress string, opts ...OptionFunc) (*PublicIpAddress, int, error) {
+	return s.GetPublicIpAddressByNameWithContext(context.Background(), ipaddress, opts...)
+}
+
+// GetPublicIpAddressByNameWithContext is like GetPublicIpAddressByName, but the requests are bound to ctx.
+func (s *NicService) GetPublicIpAddressByNameWithContext(ctx context.Context, ipaddress string, opts ...OptionFunc) (*PublicIpAddress, int, error) {
+	p := &ListPublicIpAddressesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["ipaddress"] = ipaddress
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListPublicIpAddressesAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("PublicIpAddress", ipaddress, l, func(v *PublicIpAddress) string { return v.Ipaddress }, func(v *PublicIpAddress) string { return v.Id })
+}
+
+// GetPublicIpAddressByID returns the public IP address with the given ID. The error is a *NotFoundError if there
+// is no such public IP address.
+func (s *NicService) GetPublicIpAddressByID(id string, opts ...OptionFunc) (*PublicIpAddress, int, error) {
+	return s.GetPublicIpAddressByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetPublicIpAddressByIDWithContext is like GetPublicIpAddressByID, but the requests are bound to ctx.
+func (s *NicService) GetPublicIpAddressByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*PublicIpAddress, int, error) {
+	p := &ListPublicIpAddressesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
 
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListPublicIpAddressesAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "PublicIpAddress", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("PublicIpAddress", id, l, func(v *PublicIpAddress) string { return v.Id })
+}
+
+// GetPublicIpAddressByTag returns the public IP address with a tag with the given key and value. The error is a
+// *NotFoundError if there is no such public IP address and an *AmbiguousError if there are several.
+func (s *NicService) GetPublicIpAddressByTag(key string, value string, opts ...OptionFunc) (*PublicIpAddress, int, error) {
+	return s.GetPublicIpAddressByTagWithContext(context.Background(), key, value, opts...)
+}
+
+// GetPublicIpAddressByTagWithContext is like GetPublicIpAddressByTag, but the requests are bound to ctx.
+func (s *NicService) GetPublicIpAddressByTagWithContext(ctx context.Context, key string, value string, opts ...OptionFunc) (*PublicIpAddress, int, error) {
+	p := &ListPublicIpAddressesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["tags"] = map[string]string{key: value}
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListPublicIpAddressesAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByTag("PublicIpAddress", key, value, l, func(v *PublicIpAddress) []Tag { return v.Tags }, func(v *PublicIpAddress) string { return v.Id })
+}
+
+// Lists all public ip addresses
+func (s *NicService) ListPublicIpAddresses(p *ListPublicIpAddressesParams) (*ListPublicIpAddressesResponse, error) {
+	return s.ListPublicIpAddressesWithContext(context.Background(), p)
+}
+
+// ListPublicIpAddressesWithContext is like ListPublicIpAddresses, but the request is bound to ctx.
+func (s *NicService) ListPublicIpAddressesWithContext(ctx context.Context, p *ListPublicIpAddressesParams) (*ListPublicIpAddressesResponse, error) {
 	var r ListPublicIpAddressesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListPublicIpAddressesPager returns a Pager over the items of all pages of ListPublicIpAddresses.
+func (s *NicService) ListPublicIpAddressesPager(p *ListPublicIpAddressesParams) *Pager[*PublicIpAddress] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*PublicIpAddress, int, error) {
+		r, err := s.ListPublicIpAddressesWithContext(ctx, &ListPublicIpAddressesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.PublicIpAddresses, r.Count, nil
+	})
+}
+
+// ListPublicIpAddressesAll is like ListPublicIpAddressesWithContext, but requests all pages and returns the items of all of them.
+func (s *NicService) ListPublicIpAddressesAll(ctx context.Context, p *ListPublicIpAddressesParams) ([]*PublicIpAddress, error) {
+	return s.ListPublicIpAddressesPager(p).All(ctx)
+}
+
 type ListPublicIpAddressesResponse struct {
 	Count             int                `json:"count"`
 	PublicIpAddresses []*PublicIpAddress `json:"publicipaddress"`
@@ -465,6 +699,22 @@ func (p *AddNicToVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AddNicToVirtualMachineParams) APIName() string {
+	return "addNicToVirtualMachine"
+}
+
+func (p *AddNicToVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AddNicToVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *AddNicToVirtualMachineParams) Response() interface{} {
+	return &AddNicToVirtualMachineResponse{}
+}
+
 func (p *AddNicToVirtualMachineParams) SetIpaddress(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -501,37 +751,31 @@ func (s *NicService) NewAddNicToVirtualMachineParams(networkid string, virtualma
 
 // Adds VM to specified network by creating a NIC
 func (s *NicService) AddNicToVirtualMachine(p *AddNicToVirtualMachineParams) (*AddNicToVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("addNicToVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.AddNicToVirtualMachineWithContext(context.Background(), p)
+}
 
+// AddNicToVirtualMachineWithContext is like AddNicToVirtualMachine, but the request is bound to ctx.
+func (s *NicService) AddNicToVirtualMachineWithContext(ctx context.Context, p *AddNicToVirtualMachineParams) (*AddNicToVirtualMachineResponse, error) {
 	var r AddNicToVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// AddNicToVirtualMachineJob is like AddNicToVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NicService) AddNicToVirtualMachineJob(ctx context.Context, p *AddNicToVirtualMachineParams) (*Job[*AddNicToVirtualMachineResponse], error) {
+	var r AddNicToVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type AddNicToVirtualMachineResponse struct {
@@ -549,10 +793,10 @@ type AddNicToVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -579,7 +823,7 @@ type AddNicToVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -591,7 +835,7 @@ type AddNicToVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -627,6 +871,22 @@ func (p *RemoveNicFromVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RemoveNicFromVirtualMachineParams) APIName() string {
+	return "removeNicFromVirtualMachine"
+}
+
+func (p *RemoveNicFromVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RemoveNicFromVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *RemoveNicFromVirtualMachineParams) Response() interface{} {
+	return &RemoveNicFromVirtualMachineResponse{}
+}
+
 func (p *RemoveNicFromVirtualMachineParams) SetNicid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -655,37 +915,31 @@ func (s *NicService) NewRemoveNicFromVirtualMachineParams(nicid string, virtualm
 
 // Removes VM from specified network by deleting a NIC
 func (s *NicService) RemoveNicFromVirtualMachine(p *RemoveNicFromVirtualMachineParams) (*RemoveNicFromVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("removeNicFromVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RemoveNicFromVirtualMachineWithContext(context.Background(), p)
+}
 
+// RemoveNicFromVirtualMachineWithContext is like RemoveNicFromVirtualMachine, but the request is bound to ctx.
+func (s *NicService) RemoveNicFromVirtualMachineWithContext(ctx context.Context, p *RemoveNicFromVirtualMachineParams) (*RemoveNicFromVirtualMachineResponse, error) {
 	var r RemoveNicFromVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// RemoveNicFromVirtualMachineJob is like RemoveNicFromVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NicService) RemoveNicFromVirtualMachineJob(ctx context.Context, p *RemoveNicFromVirtualMachineParams) (*Job[*RemoveNicFromVirtualMachineResponse], error) {
+	var r RemoveNicFromVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type RemoveNicFromVirtualMachineResponse struct {
@@ -703,10 +957,10 @@ type RemoveNicFromVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -733,7 +987,7 @@ type RemoveNicFromVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -745,7 +999,7 @@ type RemoveNicFromVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -778,6 +1032,22 @@ func (p *AssociateIpAddressParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AssociateIpAddressParams) APIName() string {
+	return "associateIpAddress"
+}
+
+func (p *AssociateIpAddressParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AssociateIpAddressParams) IsAsync() bool {
+	return true
+}
+
+func (p *AssociateIpAddressParams) Response() interface{} {
+	return &AssociateIpAddressResponse{}
+}
+
 func (p *AssociateIpAddressParams) SetNetworkid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -797,37 +1067,31 @@ func (s *NicService) NewAssociateIpAddressParams(networkid string) *AssociateIpA
 
 // Acquires and associates a public IP to an account.
 func (s *NicService) AssociateIpAddress(p *AssociateIpAddressParams) (*AssociateIpAddressResponse, error) {
-	resp, err := s.cs.newRequest("associateIpAddress", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.AssociateIpAddressWithContext(context.Background(), p)
+}
 
+// AssociateIpAddressWithContext is like AssociateIpAddress, but the request is bound to ctx.
+func (s *NicService) AssociateIpAddressWithContext(ctx context.Context, p *AssociateIpAddressParams) (*AssociateIpAddressResponse, error) {
 	var r AssociateIpAddressResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// AssociateIpAddressJob is like AssociateIpAddressWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NicService) AssociateIpAddressJob(ctx context.Context, p *AssociateIpAddressParams) (*Job[*AssociateIpAddressResponse], error) {
+	var r AssociateIpAddressResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type AssociateIpAddressResponse struct {
@@ -879,6 +1143,22 @@ func (p *DisassociateIpAddressParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DisassociateIpAddressParams) APIName() string {
+	return "disassociateIpAddress"
+}
+
+func (p *DisassociateIpAddressParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DisassociateIpAddressParams) IsAsync() bool {
+	return true
+}
+
+func (p *DisassociateIpAddressParams) Response() interface{} {
+	return &DisassociateIpAddressResponse{}
+}
+
 func (p *DisassociateIpAddressParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -898,36 +1178,35 @@ func (s *NicService) NewDisassociateIpAddressParams(id string) *DisassociateIpAd
 
 // Disassociates an IP address from the account.
 func (s *NicService) DisassociateIpAddress(p *DisassociateIpAddressParams) (*DisassociateIpAddressResponse, error) {
-	resp, err := s.cs.newRequest("disassociateIpAddress", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DisassociateIpAddressWithContext(context.Background(), p)
+}
 
+// DisassociateIpAddressWithContext is like DisassociateIpAddress, but the request is bound to ctx.
+func (s *NicService) DisassociateIpAddressWithContext(ctx context.Context, p *DisassociateIpAddressParams) (*DisassociateIpAddressResponse, error) {
 	var r DisassociateIpAddressResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DisassociateIpAddressJob is like DisassociateIpAddressWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *NicService) DisassociateIpAddressJob(ctx context.Context, p *DisassociateIpAddressParams) (*Job[*DisassociateIpAddressResponse], error) {
+	var r DisassociateIpAddressResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DisassociateIpAddressResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
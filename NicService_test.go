@@ -0,0 +1,67 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetPublicIpAddressByTag(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true))
+
+	var ips []*AssociateIpAddressResponse
+	for i := 0; i < 2; i++ {
+		ip, err := cs.Nic.AssociateIpAddress(cs.Nic.NewAssociateIpAddressParams(srv.NetworkID))
+		if err != nil {
+			t.Fatalf("AssociateIpAddress: %v", err)
+		}
+		ips = append(ips, ip)
+	}
+	tag := func(id, value string) {
+		t.Helper()
+		p := cs.Tags.NewCreateTagsParams([]string{id}, "PublicIpAddress", map[string]string{"role": value})
+		if _, err := cs.Tags.CreateTags(p); err != nil {
+			t.Fatalf("CreateTags: %v", err)
+		}
+	}
+	tag(ips[0].Id, "web")
+	tag(ips[1].Id, "db")
+
+	ip, count, err := cs.Nic.GetPublicIpAddressByTag("role", "web")
+	if err != nil || count != 1 || ip.Id != ips[0].Id {
+		t.Errorf("GetPublicIpAddressByTag = %v, %d, %v, want %s", ip, count, err, ips[0].Id)
+	}
+	if id, _, err := cs.Nic.GetPublicIpAddressID(ips[1].Ipaddress); err != nil || id != ips[1].Id {
+		t.Errorf("GetPublicIpAddressID(%s) = %q, %v, want %s", ips[1].Ipaddress, id, err, ips[1].Id)
+	}
+
+	_, _, err = cs.Nic.GetPublicIpAddressByTag("role", "cache")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) || nf.Key != "role=cache" {
+		t.Errorf("err = %v, want a *NotFoundError for role=cache", err)
+	}
+
+	p := cs.Tags.NewCreateTagsParams([]string{ips[0].Id, ips[1].Id}, "PublicIpAddress", map[string]string{"env": "prod"})
+	if _, err := cs.Tags.CreateTags(p); err != nil {
+		t.Fatalf("CreateTags: %v", err)
+	}
+	_, count, err = cs.Nic.GetPublicIpAddressByTag("env", "prod")
+	if !IsAmbiguous(err) || count != 2 {
+		t.Errorf("GetPublicIpAddressByTag = %d, %v, want an *AmbiguousError for 2 addresses", count, err)
+	}
+}
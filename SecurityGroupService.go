@@ -1,29 +1,29 @@
 package gokcps
 
 type Egressrule struct {
-	Account           string `json:"account,omitempty"`
-	Cidr              string `json:"cidr,omitempty"`
-	Endport           int    `json:"endport,omitempty"`
-	Icmpcode          int    `json:"icmpcode,omitempty"`
-	Icmptype          int    `json:"icmptype,omitempty"`
-	Protocol          string `json:"protocol,omitempty"`
-	Ruleid            string `json:"ruleid,omitempty"`
-	Securitygroupname string `json:"securitygroupname,omitempty"`
-	Startport         int    `json:"startport,omitempty"`
-	Tags              []Tag  `json:"tags,omitempty"`
+	Account           string  `json:"account,omitempty"`
+	Cidr              string  `json:"cidr,omitempty"`
+	Endport           FlexInt `json:"endport,omitempty"`
+	Icmpcode          FlexInt `json:"icmpcode,omitempty"`
+	Icmptype          FlexInt `json:"icmptype,omitempty"`
+	Protocol          string  `json:"protocol,omitempty"`
+	Ruleid            string  `json:"ruleid,omitempty"`
+	Securitygroupname string  `json:"securitygroupname,omitempty"`
+	Startport         FlexInt `json:"startport,omitempty"`
+	Tags              []Tag   `json:"tags,omitempty"`
 }
 
 type Ingressrule struct {
-	Account           string `json:"account,omitempty"`
-	Cidr              string `json:"cidr,omitempty"`
-	Endport           int    `json:"endport,omitempty"`
-	Icmpcode          int    `json:"icmpcode,omitempty"`
-	Icmptype          int    `json:"icmptype,omitempty"`
-	Protocol          string `json:"protocol,omitempty"`
-	Ruleid            string `json:"ruleid,omitempty"`
-	Securitygroupname string `json:"securitygroupname,omitempty"`
-	Startport         int    `json:"startport,omitempty"`
-	Tags              []Tag  `json:"tags,omitempty"`
+	Account           string  `json:"account,omitempty"`
+	Cidr              string  `json:"cidr,omitempty"`
+	Endport           FlexInt `json:"endport,omitempty"`
+	Icmpcode          FlexInt `json:"icmpcode,omitempty"`
+	Icmptype          FlexInt `json:"icmptype,omitempty"`
+	Protocol          string  `json:"protocol,omitempty"`
+	Ruleid            string  `json:"ruleid,omitempty"`
+	Securitygroupname string  `json:"securitygroupname,omitempty"`
+	Startport         FlexInt `json:"startport,omitempty"`
+	Tags              []Tag   `json:"tags,omitempty"`
 }
 
 type Securitygroup struct {
@@ -17,7 +17,8 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -39,6 +40,22 @@ func (p *CreateSnapshotParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateSnapshotParams) APIName() string {
+	return "createSnapshot"
+}
+
+func (p *CreateSnapshotParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateSnapshotParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateSnapshotParams) Response() interface{} {
+	return &CreateSnapshotResponse{}
+}
+
 func (p *CreateSnapshotParams) SetVolumeid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -58,59 +75,53 @@ func (s *SnapshotService) NewCreateSnapshotParams(volumeid string) *CreateSnapsh
 
 // Creates an instant snapshot of a volume.
 func (s *SnapshotService) CreateSnapshot(p *CreateSnapshotParams) (*CreateSnapshotResponse, error) {
-	resp, err := s.cs.newRequest("createSnapshot", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateSnapshotWithContext(context.Background(), p)
+}
 
+// CreateSnapshotWithContext is like CreateSnapshot, but the request is bound to ctx.
+func (s *SnapshotService) CreateSnapshotWithContext(ctx context.Context, p *CreateSnapshotParams) (*CreateSnapshotResponse, error) {
 	var r CreateSnapshotResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateSnapshotJob is like CreateSnapshotWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *SnapshotService) CreateSnapshotJob(ctx context.Context, p *CreateSnapshotParams) (*Job[*CreateSnapshotResponse], error) {
+	var r CreateSnapshotResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateSnapshotResponse struct {
-	JobID        string `json:"jobid,omitempty"`
-	Account      string `json:"account,omitempty"`
-	Created      string `json:"created,omitempty"`
-	Domain       string `json:"domain,omitempty"`
-	Domainid     string `json:"domainid,omitempty"`
-	Id           string `json:"id,omitempty"`
-	Intervaltype string `json:"intervaltype,omitempty"`
-	Name         string `json:"name,omitempty"`
-	Physicalsize int64  `json:"physicalsize,omitempty"`
-	Project      string `json:"project,omitempty"`
-	Projectid    string `json:"projectid,omitempty"`
-	Revertable   bool   `json:"revertable,omitempty"`
-	Snapshottype string `json:"snapshottype,omitempty"`
-	State        string `json:"state,omitempty"`
-	Tags         []Tag  `json:"tags,omitempty"`
-	Volumeid     string `json:"volumeid,omitempty"`
-	Volumename   string `json:"volumename,omitempty"`
-	Volumetype   string `json:"volumetype,omitempty"`
-	Zoneid       string `json:"zoneid,omitempty"`
+	JobID        string    `json:"jobid,omitempty"`
+	Account      string    `json:"account,omitempty"`
+	Created      Timestamp `json:"created,omitempty"`
+	Domain       string    `json:"domain,omitempty"`
+	Domainid     string    `json:"domainid,omitempty"`
+	Id           string    `json:"id,omitempty"`
+	Intervaltype string    `json:"intervaltype,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Physicalsize int64     `json:"physicalsize,omitempty"`
+	Project      string    `json:"project,omitempty"`
+	Projectid    string    `json:"projectid,omitempty"`
+	Revertable   bool      `json:"revertable,omitempty"`
+	Snapshottype string    `json:"snapshottype,omitempty"`
+	State        string    `json:"state,omitempty"`
+	Tags         []Tag     `json:"tags,omitempty"`
+	Volumeid     string    `json:"volumeid,omitempty"`
+	Volumename   string    `json:"volumename,omitempty"`
+	Volumetype   string    `json:"volumetype,omitempty"`
+	Zoneid       string    `json:"zoneid,omitempty"`
 }
 
 type ListSnapshotsParams struct {
@@ -134,6 +145,14 @@ func (p *ListSnapshotsParams) toURLValues() url.Values {
 	if v, found := p.p["name"]; found {
 		u.Set("name", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["tags"]; found {
 		i := 0
 		for k, vv := range v.(map[string]string) {
@@ -151,6 +170,22 @@ func (p *ListSnapshotsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListSnapshotsParams) APIName() string {
+	return "listSnapshots"
+}
+
+func (p *ListSnapshotsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListSnapshotsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListSnapshotsParams) Response() interface{} {
+	return &ListSnapshotsResponse{}
+}
+
 func (p *ListSnapshotsParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -183,6 +218,22 @@ func (p *ListSnapshotsParams) SetName(v string) {
 	return
 }
 
+func (p *ListSnapshotsParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListSnapshotsParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListSnapshotsParams) SetTags(v map[string]string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -215,45 +266,154 @@ func (s *SnapshotService) NewListSnapshotsParams() *ListSnapshotsParams {
 	return p
 }
 
-// Lists all available snapshots for the account.
-func (s *SnapshotService) ListSnapshots(p *ListSnapshotsParams) (*ListSnapshotsResponse, error) {
-	resp, err := s.cs.newRequest("listSnapshots", p.toURLValues())
+// GetSnapshotID returns the ID of the snapshot with the given name. The error is a
+// *NotFoundError if there is no such snapshot and an *AmbiguousError if there are several.
+func (s *SnapshotService) GetSnapshotID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetSnapshotIDWithContext(context.Background(), name, opts...)
+}
+
+// GetSnapshotIDWithContext is like GetSnapshotID, but the requests are bound to ctx.
+func (s *SnapshotService) GetSnapshotIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetSnapshotByNameWithContext(ctx, name, opts...)
+	if err != nil {
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetSnapshotByName returns the snapshot with the given name, like GetSnapshotID.
+func (s *SnapshotService) GetSnapshotByName(name string, opts ...OptionFunc) (*Snapshot, int, error) {
+	return s.GetSnapshotByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetSnapshotByNameWithContext is like GetSnapshotByName, but the requests are bound to ctx.
+func (s *SnapshotService) GetSnapshotByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*Snapshot, int, error) {
+	p := &ListSnapshotsParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListSnapshotsAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("Snapshot", name, l, func(v *Snapshot) string { return v.Name }, func(v *Snapshot) string { return v.Id })
+}
+
+// GetSnapshotByID returns the snapshot with the given ID. The error is a *NotFoundError if there
+// is no such snapshot.
+func (s *SnapshotService) GetSnapshotByID(id string, opts ...OptionFunc) (*Snapshot, int, error) {
+	return s.GetSnapshotByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetSnapshotByIDWithContext is like GetSnapshotByID, but the requests are bound to ctx.
+func (s *SnapshotService) GetSnapshotByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*Snapshot, int, error) {
+	p := &ListSnapshotsParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListSnapshotsAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "Snapshot", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("Snapshot", id, l, func(v *Snapshot) string { return v.Id })
+}
+
+// WaitUntilSnapshotBackedUp waits until the snapshot with the given ID is BackedUp and returns
+// it. The wait is spaced and limited like KCPSClient.WaitFor, using w or the JobWaiter of the
+// client if w is nil.
+func (s *SnapshotService) WaitUntilSnapshotBackedUp(ctx context.Context, id string, w *JobWaiter) (*Snapshot, error) {
+	var v *Snapshot
+	_, err := s.cs.waitFor(ctx, w, "Snapshot", id, func(ctx context.Context) (string, bool, error) {
+		var err error
+		if v, _, err = s.GetSnapshotByIDWithContext(ctx, id); err != nil {
+			return "", false, err
+		}
+		switch v.State {
+		case "BackedUp":
+			return v.State, true, nil
+		case "Error", "Destroyed":
+			return v.State, false, &UnexpectedStateError{Kind: "Snapshot", ID: id, State: v.State}
+		}
+		return v.State, false, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return v, nil
+}
+
+// Lists all available snapshots for the account.
+func (s *SnapshotService) ListSnapshots(p *ListSnapshotsParams) (*ListSnapshotsResponse, error) {
+	return s.ListSnapshotsWithContext(context.Background(), p)
+}
 
+// ListSnapshotsWithContext is like ListSnapshots, but the request is bound to ctx.
+func (s *SnapshotService) ListSnapshotsWithContext(ctx context.Context, p *ListSnapshotsParams) (*ListSnapshotsResponse, error) {
 	var r ListSnapshotsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListSnapshotsPager returns a Pager over the items of all pages of ListSnapshots.
+func (s *SnapshotService) ListSnapshotsPager(p *ListSnapshotsParams) *Pager[*Snapshot] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Snapshot, int, error) {
+		r, err := s.ListSnapshotsWithContext(ctx, &ListSnapshotsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Snapshots, r.Count, nil
+	})
+}
+
+// ListSnapshotsAll is like ListSnapshotsWithContext, but requests all pages and returns the items of all of them.
+func (s *SnapshotService) ListSnapshotsAll(ctx context.Context, p *ListSnapshotsParams) ([]*Snapshot, error) {
+	return s.ListSnapshotsPager(p).All(ctx)
+}
+
 type ListSnapshotsResponse struct {
 	Count     int         `json:"count"`
 	Snapshots []*Snapshot `json:"snapshot"`
 }
 
 type Snapshot struct {
-	Account      string `json:"account,omitempty"`
-	Created      string `json:"created,omitempty"`
-	Domain       string `json:"domain,omitempty"`
-	Domainid     string `json:"domainid,omitempty"`
-	Id           string `json:"id,omitempty"`
-	Intervaltype string `json:"intervaltype,omitempty"`
-	Name         string `json:"name,omitempty"`
-	Physicalsize int64  `json:"physicalsize,omitempty"`
-	Project      string `json:"project,omitempty"`
-	Projectid    string `json:"projectid,omitempty"`
-	Revertable   bool   `json:"revertable,omitempty"`
-	Snapshottype string `json:"snapshottype,omitempty"`
-	State        string `json:"state,omitempty"`
-	Tags         []Tag  `json:"tags,omitempty"`
-	Volumeid     string `json:"volumeid,omitempty"`
-	Volumename   string `json:"volumename,omitempty"`
-	Volumetype   string `json:"volumetype,omitempty"`
-	Zoneid       string `json:"zoneid,omitempty"`
+	Account      string    `json:"account,omitempty"`
+	Created      Timestamp `json:"created,omitempty"`
+	Domain       string    `json:"domain,omitempty"`
+	Domainid     string    `json:"domainid,omitempty"`
+	Id           string    `json:"id,omitempty"`
+	Intervaltype string    `json:"intervaltype,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Physicalsize int64     `json:"physicalsize,omitempty"`
+	Project      string    `json:"project,omitempty"`
+	Projectid    string    `json:"projectid,omitempty"`
+	Revertable   bool      `json:"revertable,omitempty"`
+	Snapshottype string    `json:"snapshottype,omitempty"`
+	State        string    `json:"state,omitempty"`
+	Tags         []Tag     `json:"tags,omitempty"`
+	Volumeid     string    `json:"volumeid,omitempty"`
+	Volumename   string    `json:"volumename,omitempty"`
+	Volumetype   string    `json:"volumetype,omitempty"`
+	Zoneid       string    `json:"zoneid,omitempty"`
 }
 
 type DeleteSnapshotParams struct {
@@ -271,6 +431,22 @@ func (p *DeleteSnapshotParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteSnapshotParams) APIName() string {
+	return "deleteSnapshot"
+}
+
+func (p *DeleteSnapshotParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteSnapshotParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteSnapshotParams) Response() interface{} {
+	return &DeleteSnapshotResponse{}
+}
+
 func (p *DeleteSnapshotParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -290,38 +466,37 @@ func (s *SnapshotService) NewDeleteSnapshotParams(id string) *DeleteSnapshotPara
 
 // Deletes a snapshot of a disk volume.
 func (s *SnapshotService) DeleteSnapshot(p *DeleteSnapshotParams) (*DeleteSnapshotResponse, error) {
-	resp, err := s.cs.newRequest("deleteSnapshot", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteSnapshotWithContext(context.Background(), p)
+}
 
+// DeleteSnapshotWithContext is like DeleteSnapshot, but the request is bound to ctx.
+func (s *SnapshotService) DeleteSnapshotWithContext(ctx context.Context, p *DeleteSnapshotParams) (*DeleteSnapshotResponse, error) {
 	var r DeleteSnapshotResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteSnapshotJob is like DeleteSnapshotWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *SnapshotService) DeleteSnapshotJob(ctx context.Context, p *DeleteSnapshotParams) (*Job[*DeleteSnapshotResponse], error) {
+	var r DeleteSnapshotResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteSnapshotResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type CreateVMSnapshotParams struct {
@@ -346,6 +521,22 @@ func (p *CreateVMSnapshotParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateVMSnapshotParams) APIName() string {
+	return "createVMSnapshot"
+}
+
+func (p *CreateVMSnapshotParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateVMSnapshotParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateVMSnapshotParams) Response() interface{} {
+	return &CreateVMSnapshotResponse{}
+}
+
 func (p *CreateVMSnapshotParams) SetName(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -381,58 +572,52 @@ func (s *SnapshotService) NewCreateVMSnapshotParams(virtualmachineid string) *Cr
 
 // Creates snapshot for a vm.
 func (s *SnapshotService) CreateVMSnapshot(p *CreateVMSnapshotParams) (*CreateVMSnapshotResponse, error) {
-	resp, err := s.cs.newRequest("createVMSnapshot", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateVMSnapshotWithContext(context.Background(), p)
+}
 
+// CreateVMSnapshotWithContext is like CreateVMSnapshot, but the request is bound to ctx.
+func (s *SnapshotService) CreateVMSnapshotWithContext(ctx context.Context, p *CreateVMSnapshotParams) (*CreateVMSnapshotResponse, error) {
 	var r CreateVMSnapshotResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateVMSnapshotJob is like CreateVMSnapshotWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *SnapshotService) CreateVMSnapshotJob(ctx context.Context, p *CreateVMSnapshotParams) (*Job[*CreateVMSnapshotResponse], error) {
+	var r CreateVMSnapshotResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateVMSnapshotResponse struct {
-	JobID            string `json:"jobid,omitempty"`
-	Account          string `json:"account,omitempty"`
-	Created          string `json:"created,omitempty"`
-	Current          bool   `json:"current,omitempty"`
-	Description      string `json:"description,omitempty"`
-	Displayname      string `json:"displayname,omitempty"`
-	Domain           string `json:"domain,omitempty"`
-	Domainid         string `json:"domainid,omitempty"`
-	Id               string `json:"id,omitempty"`
-	Name             string `json:"name,omitempty"`
-	Parent           string `json:"parent,omitempty"`
-	ParentName       string `json:"parentName,omitempty"`
-	Project          string `json:"project,omitempty"`
-	Projectid        string `json:"projectid,omitempty"`
-	State            string `json:"state,omitempty"`
-	Type             string `json:"type,omitempty"`
-	Virtualmachineid string `json:"virtualmachineid,omitempty"`
-	Zoneid           string `json:"zoneid,omitempty"`
+	JobID            string    `json:"jobid,omitempty"`
+	Account          string    `json:"account,omitempty"`
+	Created          Timestamp `json:"created,omitempty"`
+	Current          bool      `json:"current,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Displayname      string    `json:"displayname,omitempty"`
+	Domain           string    `json:"domain,omitempty"`
+	Domainid         string    `json:"domainid,omitempty"`
+	Id               string    `json:"id,omitempty"`
+	Name             string    `json:"name,omitempty"`
+	Parent           string    `json:"parent,omitempty"`
+	ParentName       string    `json:"parentName,omitempty"`
+	Project          string    `json:"project,omitempty"`
+	Projectid        string    `json:"projectid,omitempty"`
+	State            string    `json:"state,omitempty"`
+	Type             string    `json:"type,omitempty"`
+	Virtualmachineid string    `json:"virtualmachineid,omitempty"`
+	Zoneid           string    `json:"zoneid,omitempty"`
 }
 
 type DeleteVMSnapshotParams struct {
@@ -450,6 +635,22 @@ func (p *DeleteVMSnapshotParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteVMSnapshotParams) APIName() string {
+	return "deleteVMSnapshot"
+}
+
+func (p *DeleteVMSnapshotParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteVMSnapshotParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteVMSnapshotParams) Response() interface{} {
+	return &DeleteVMSnapshotResponse{}
+}
+
 func (p *DeleteVMSnapshotParams) SetVmsnapshotid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -469,38 +670,37 @@ func (s *SnapshotService) NewDeleteVMSnapshotParams(vmsnapshotid string) *Delete
 
 // Deletes a vmsnapshot.
 func (s *SnapshotService) DeleteVMSnapshot(p *DeleteVMSnapshotParams) (*DeleteVMSnapshotResponse, error) {
-	resp, err := s.cs.newRequest("deleteVMSnapshot", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteVMSnapshotWithContext(context.Background(), p)
+}
 
+// DeleteVMSnapshotWithContext is like DeleteVMSnapshot, but the request is bound to ctx.
+func (s *SnapshotService) DeleteVMSnapshotWithContext(ctx context.Context, p *DeleteVMSnapshotParams) (*DeleteVMSnapshotResponse, error) {
 	var r DeleteVMSnapshotResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteVMSnapshotJob is like DeleteVMSnapshotWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *SnapshotService) DeleteVMSnapshotJob(ctx context.Context, p *DeleteVMSnapshotParams) (*Job[*DeleteVMSnapshotResponse], error) {
+	var r DeleteVMSnapshotResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteVMSnapshotResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type RevertToVMSnapshotParams struct {
@@ -518,6 +718,22 @@ func (p *RevertToVMSnapshotParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RevertToVMSnapshotParams) APIName() string {
+	return "revertToVMSnapshot"
+}
+
+func (p *RevertToVMSnapshotParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RevertToVMSnapshotParams) IsAsync() bool {
+	return true
+}
+
+func (p *RevertToVMSnapshotParams) Response() interface{} {
+	return &RevertToVMSnapshotResponse{}
+}
+
 func (p *RevertToVMSnapshotParams) SetVmsnapshotid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -537,37 +753,31 @@ func (s *SnapshotService) NewRevertToVMSnapshotParams(vmsnapshotid string) *Reve
 
 // Revert VM from a vmsnapshot.
 func (s *SnapshotService) RevertToVMSnapshot(p *RevertToVMSnapshotParams) (*RevertToVMSnapshotResponse, error) {
-	resp, err := s.cs.newRequest("revertToVMSnapshot", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RevertToVMSnapshotWithContext(context.Background(), p)
+}
 
+// RevertToVMSnapshotWithContext is like RevertToVMSnapshot, but the request is bound to ctx.
+func (s *SnapshotService) RevertToVMSnapshotWithContext(ctx context.Context, p *RevertToVMSnapshotParams) (*RevertToVMSnapshotResponse, error) {
 	var r RevertToVMSnapshotResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// RevertToVMSnapshotJob is like RevertToVMSnapshotWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *SnapshotService) RevertToVMSnapshotJob(ctx context.Context, p *RevertToVMSnapshotParams) (*Job[*RevertToVMSnapshotResponse], error) {
+	var r RevertToVMSnapshotResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type RevertToVMSnapshotResponse struct {
@@ -585,10 +795,10 @@ type RevertToVMSnapshotResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -615,7 +825,7 @@ type RevertToVMSnapshotResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -627,7 +837,7 @@ type RevertToVMSnapshotResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -654,12 +864,52 @@ func (p *ListSnapshotPoliciesParams) toURLValues() url.Values {
 	if p.p == nil {
 		return u
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["volumeid"]; found {
 		u.Set("volumeid", v.(string))
 	}
 	return u
 }
 
+func (p *ListSnapshotPoliciesParams) APIName() string {
+	return "listSnapshotPolicies"
+}
+
+func (p *ListSnapshotPoliciesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListSnapshotPoliciesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListSnapshotPoliciesParams) Response() interface{} {
+	return &ListSnapshotPoliciesResponse{}
+}
+
+func (p *ListSnapshotPoliciesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListSnapshotPoliciesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListSnapshotPoliciesParams) SetVolumeid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -678,31 +928,47 @@ func (s *SnapshotService) NewListSnapshotPoliciesParams() *ListSnapshotPoliciesP
 
 // Lists snapshot policies.
 func (s *SnapshotService) ListSnapshotPolicies(p *ListSnapshotPoliciesParams) (*ListSnapshotPoliciesResponse, error) {
-	resp, err := s.cs.newRequest("listSnapshotPolicies", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListSnapshotPoliciesWithContext(context.Background(), p)
+}
 
+// ListSnapshotPoliciesWithContext is like ListSnapshotPolicies, but the request is bound to ctx.
+func (s *SnapshotService) ListSnapshotPoliciesWithContext(ctx context.Context, p *ListSnapshotPoliciesParams) (*ListSnapshotPoliciesResponse, error) {
 	var r ListSnapshotPoliciesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListSnapshotPoliciesPager returns a Pager over the items of all pages of ListSnapshotPolicies.
+func (s *SnapshotService) ListSnapshotPoliciesPager(p *ListSnapshotPoliciesParams) *Pager[*SnapshotPolicy] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*SnapshotPolicy, int, error) {
+		r, err := s.ListSnapshotPoliciesWithContext(ctx, &ListSnapshotPoliciesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.SnapshotPolicies, r.Count, nil
+	})
+}
+
+// ListSnapshotPoliciesAll is like ListSnapshotPoliciesWithContext, but requests all pages and returns the items of all of them.
+func (s *SnapshotService) ListSnapshotPoliciesAll(ctx context.Context, p *ListSnapshotPoliciesParams) ([]*SnapshotPolicy, error) {
+	return s.ListSnapshotPoliciesPager(p).All(ctx)
+}
+
 type ListSnapshotPoliciesResponse struct {
 	Count            int               `json:"count"`
 	SnapshotPolicies []*SnapshotPolicy `json:"snapshotpolicy"`
 }
 
 type SnapshotPolicy struct {
-	Id           string `json:"id,omitempty"`
-	Intervaltype int    `json:"intervaltype,omitempty"`
-	Maxsnaps     int    `json:"maxsnaps,omitempty"`
-	Schedule     string `json:"schedule,omitempty"`
-	Timezone     string `json:"timezone,omitempty"`
-	Volumeid     string `json:"volumeid,omitempty"`
+	Id           string  `json:"id,omitempty"`
+	Intervaltype FlexInt `json:"intervaltype,omitempty"`
+	Maxsnaps     FlexInt `json:"maxsnaps,omitempty"`
+	Schedule     string  `json:"schedule,omitempty"`
+	Timezone     string  `json:"timezone,omitempty"`
+	Volumeid     string  `json:"volumeid,omitempty"`
 }
 
 type CreateSnapshotPolicyParams struct {
@@ -733,6 +999,22 @@ func (p *CreateSnapshotPolicyParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateSnapshotPolicyParams) APIName() string {
+	return "createSnapshotPolicy"
+}
+
+func (p *CreateSnapshotPolicyParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateSnapshotPolicyParams) IsAsync() bool {
+	return false
+}
+
+func (p *CreateSnapshotPolicyParams) Response() interface{} {
+	return &CreateSnapshotPolicyResponse{}
+}
+
 func (p *CreateSnapshotPolicyParams) SetIntervaltype(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -788,15 +1070,16 @@ func (s *SnapshotService) NewCreateSnapshotPolicyParams(intervaltype string, max
 
 // Creates a snapshot policy for the account.
 func (s *SnapshotService) CreateSnapshotPolicy(p *CreateSnapshotPolicyParams) (*CreateSnapshotPolicyResponse, error) {
-	resp, err := s.cs.newRequest("createSnapshotPolicy", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateSnapshotPolicyWithContext(context.Background(), p)
+}
 
+// CreateSnapshotPolicyWithContext is like CreateSnapshotPolicy, but the request is bound to ctx.
+func (s *SnapshotService) CreateSnapshotPolicyWithContext(ctx context.Context, p *CreateSnapshotPolicyParams) (*CreateSnapshotPolicyResponse, error) {
 	var r CreateSnapshotPolicyResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
+
 	return &r, nil
 }
 
@@ -823,6 +1106,22 @@ func (p *DeleteSnapshotPoliciesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteSnapshotPoliciesParams) APIName() string {
+	return "deleteSnapshotPolicies"
+}
+
+func (p *DeleteSnapshotPoliciesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteSnapshotPoliciesParams) IsAsync() bool {
+	return false
+}
+
+func (p *DeleteSnapshotPoliciesParams) Response() interface{} {
+	return &DeleteSnapshotPoliciesResponse{}
+}
+
 func (p *DeleteSnapshotPoliciesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -849,13 +1148,13 @@ func (s *SnapshotService) NewDeleteSnapshotPoliciesParams() *DeleteSnapshotPolic
 
 // Deletes snapshot policies for the account.
 func (s *SnapshotService) DeleteSnapshotPolicies(p *DeleteSnapshotPoliciesParams) (*DeleteSnapshotPoliciesResponse, error) {
-	resp, err := s.cs.newRequest("deleteSnapshotPolicies", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteSnapshotPoliciesWithContext(context.Background(), p)
+}
 
+// DeleteSnapshotPoliciesWithContext is like DeleteSnapshotPolicies, but the request is bound to ctx.
+func (s *SnapshotService) DeleteSnapshotPoliciesWithContext(ctx context.Context, p *DeleteSnapshotPoliciesParams) (*DeleteSnapshotPoliciesResponse, error) {
 	var r DeleteSnapshotPoliciesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -863,8 +1162,8 @@ func (s *SnapshotService) DeleteSnapshotPolicies(p *DeleteSnapshotPoliciesParams
 }
 
 type DeleteSnapshotPoliciesResponse struct {
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     string `json:"success,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListVMSnapshotParams struct {
@@ -879,6 +1178,14 @@ func (p *ListVMSnapshotParams) toURLValues() url.Values {
 	if v, found := p.p["name"]; found {
 		u.Set("name", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["state"]; found {
 		u.Set("state", v.(string))
 	}
@@ -891,6 +1198,22 @@ func (p *ListVMSnapshotParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListVMSnapshotParams) APIName() string {
+	return "listVMSnapshot"
+}
+
+func (p *ListVMSnapshotParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListVMSnapshotParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListVMSnapshotParams) Response() interface{} {
+	return &ListVMSnapshotResponse{}
+}
+
 func (p *ListVMSnapshotParams) SetName(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -899,6 +1222,22 @@ func (p *ListVMSnapshotParams) SetName(v string) {
 	return
 }
 
+func (p *ListVMSnapshotParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListVMSnapshotParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListVMSnapshotParams) SetState(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -931,42 +1270,127 @@ func (s *SnapshotService) NewListVMSnapshotParams() *ListVMSnapshotParams {
 	return p
 }
 
-// List virtual machine snapshot by conditions
-func (s *SnapshotService) ListVMSnapshot(p *ListVMSnapshotParams) (*ListVMSnapshotResponse, error) {
-	resp, err := s.cs.newRequest("listVMSnapshot", p.toURLValues())
+// GetVMSnapshotID returns the ID of the VM snapshot with the given name. The error is a
+// *NotFoundError if there is no such VM snapshot and an *AmbiguousError if there are several.
+func (s *SnapshotService) GetVMSnapshotID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetVMSnapshotIDWithContext(context.Background(), name, opts...)
+}
+
+// GetVMSnapshotIDWithContext is like GetVMSnapshotID, but the requests are bound to ctx.
+func (s *SnapshotService) GetVMSnapshotIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetVMSnapshotByNameWithContext(ctx, name, opts...)
 	if err != nil {
-		return nil, err
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetVMSnapshotByName returns the VM snapshot with the given name, like GetVMSnapshotID.
+func (s *SnapshotService) GetVMSnapshotByName(name string, opts ...OptionFunc) (*VMSnapshot, int, error) {
+	return s.GetVMSnapshotByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetVMSnapshotByNameWithContext is like GetVMSnapshotByName, but the requests are bound to ctx.
+func (s *SnapshotService) GetVMSnapshotByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*VMSnapshot, int, error) {
+	p := &ListVMSnapshotParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
 	}
 
+	l, err := s.ListVMSnapshotAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("VMSnapshot", name, l, func(v *VMSnapshot) string { return v.Name }, func(v *VMSnapshot) string { return v.Id })
+}
+
+// GetVMSnapshotByID returns the VM snapshot with the given ID. The error is a *NotFoundError if there
+// is no such VM snapshot.
+func (s *SnapshotService) GetVMSnapshotByID(id string, opts ...OptionFunc) (*VMSnapshot, int, error) {
+	return s.GetVMSnapshotByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetVMSnapshotByIDWithContext is like GetVMSnapshotByID, but the requests are bound to ctx.
+func (s *SnapshotService) GetVMSnapshotByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*VMSnapshot, int, error) {
+	p := &ListVMSnapshotParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["vmsnapshotid"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListVMSnapshotAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "VMSnapshot", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("VMSnapshot", id, l, func(v *VMSnapshot) string { return v.Id })
+}
+
+// List virtual machine snapshot by conditions
+func (s *SnapshotService) ListVMSnapshot(p *ListVMSnapshotParams) (*ListVMSnapshotResponse, error) {
+	return s.ListVMSnapshotWithContext(context.Background(), p)
+}
+
+// ListVMSnapshotWithContext is like ListVMSnapshot, but the request is bound to ctx.
+func (s *SnapshotService) ListVMSnapshotWithContext(ctx context.Context, p *ListVMSnapshotParams) (*ListVMSnapshotResponse, error) {
 	var r ListVMSnapshotResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListVMSnapshotPager returns a Pager over the items of all pages of ListVMSnapshot.
+func (s *SnapshotService) ListVMSnapshotPager(p *ListVMSnapshotParams) *Pager[*VMSnapshot] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*VMSnapshot, int, error) {
+		r, err := s.ListVMSnapshotWithContext(ctx, &ListVMSnapshotParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.VMSnapshot, r.Count, nil
+	})
+}
+
+// ListVMSnapshotAll is like ListVMSnapshotWithContext, but requests all pages and returns the items of all of them.
+func (s *SnapshotService) ListVMSnapshotAll(ctx context.Context, p *ListVMSnapshotParams) ([]*VMSnapshot, error) {
+	return s.ListVMSnapshotPager(p).All(ctx)
+}
+
 type ListVMSnapshotResponse struct {
 	Count      int           `json:"count"`
 	VMSnapshot []*VMSnapshot `json:"vmsnapshot"`
 }
 
 type VMSnapshot struct {
-	Account          string `json:"account,omitempty"`
-	Created          string `json:"created,omitempty"`
-	Current          bool   `json:"current,omitempty"`
-	Description      string `json:"description,omitempty"`
-	Displayname      string `json:"displayname,omitempty"`
-	Domain           string `json:"domain,omitempty"`
-	Domainid         string `json:"domainid,omitempty"`
-	Id               string `json:"id,omitempty"`
-	Name             string `json:"name,omitempty"`
-	Parent           string `json:"parent,omitempty"`
-	ParentName       string `json:"parentName,omitempty"`
-	Project          string `json:"project,omitempty"`
-	Projectid        string `json:"projectid,omitempty"`
-	State            string `json:"state,omitempty"`
-	Type             string `json:"type,omitempty"`
-	Virtualmachineid string `json:"virtualmachineid,omitempty"`
-	Zoneid           string `json:"zoneid,omitempty"`
+	Account          string    `json:"account,omitempty"`
+	Created          Timestamp `json:"created,omitempty"`
+	Current          bool      `json:"current,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Displayname      string    `json:"displayname,omitempty"`
+	Domain           string    `json:"domain,omitempty"`
+	Domainid         string    `json:"domainid,omitempty"`
+	Id               string    `json:"id,omitempty"`
+	Name             string    `json:"name,omitempty"`
+	Parent           string    `json:"parent,omitempty"`
+	ParentName       string    `json:"parentName,omitempty"`
+	Project          string    `json:"project,omitempty"`
+	Projectid        string    `json:"projectid,omitempty"`
+	State            string    `json:"state,omitempty"`
+	Type             string    `json:"type,omitempty"`
+	Virtualmachineid string    `json:"virtualmachineid,omitempty"`
+	Zoneid           string    `json:"zoneid,omitempty"`
 }
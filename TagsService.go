@@ -17,7 +17,8 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -69,6 +70,22 @@ func (p *CreateTagsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateTagsParams) APIName() string {
+	return "createTags"
+}
+
+func (p *CreateTagsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateTagsParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateTagsParams) Response() interface{} {
+	return &CreateTagsResponse{}
+}
+
 func (p *CreateTagsParams) SetCustomer(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -114,38 +131,37 @@ func (s *TagsService) NewCreateTagsParams(resourceids []string, resourcetype str
 
 // Creates resource tag(s)
 func (s *TagsService) CreateTags(p *CreateTagsParams) (*CreateTagsResponse, error) {
-	resp, err := s.cs.newRequest("createTags", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateTagsWithContext(context.Background(), p)
+}
 
+// CreateTagsWithContext is like CreateTags, but the request is bound to ctx.
+func (s *TagsService) CreateTagsWithContext(ctx context.Context, p *CreateTagsParams) (*CreateTagsResponse, error) {
 	var r CreateTagsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateTagsJob is like CreateTagsWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *TagsService) CreateTagsJob(ctx context.Context, p *CreateTagsParams) (*Job[*CreateTagsResponse], error) {
+	var r CreateTagsResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type CreateTagsResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type DeleteTagsParams struct {
@@ -175,6 +191,22 @@ func (p *DeleteTagsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteTagsParams) APIName() string {
+	return "deleteTags"
+}
+
+func (p *DeleteTagsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteTagsParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteTagsParams) Response() interface{} {
+	return &DeleteTagsResponse{}
+}
+
 func (p *DeleteTagsParams) SetResourceids(v []string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -211,38 +243,37 @@ func (s *TagsService) NewDeleteTagsParams(resourceids []string, resourcetype str
 
 // Deleting resource tag(s)
 func (s *TagsService) DeleteTags(p *DeleteTagsParams) (*DeleteTagsResponse, error) {
-	resp, err := s.cs.newRequest("deleteTags", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteTagsWithContext(context.Background(), p)
+}
 
+// DeleteTagsWithContext is like DeleteTags, but the request is bound to ctx.
+func (s *TagsService) DeleteTagsWithContext(ctx context.Context, p *DeleteTagsParams) (*DeleteTagsResponse, error) {
 	var r DeleteTagsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteTagsJob is like DeleteTagsWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *TagsService) DeleteTagsJob(ctx context.Context, p *DeleteTagsParams) (*Job[*DeleteTagsResponse], error) {
+	var r DeleteTagsResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteTagsResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListTagsParams struct {
@@ -268,6 +299,14 @@ func (p *ListTagsParams) toURLValues() url.Values {
 		vv := strconv.FormatBool(v.(bool))
 		u.Set("listall", vv)
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["resourceid"]; found {
 		u.Set("resourceid", v.(string))
 	}
@@ -280,6 +319,22 @@ func (p *ListTagsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListTagsParams) APIName() string {
+	return "listTags"
+}
+
+func (p *ListTagsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListTagsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListTagsParams) Response() interface{} {
+	return &ListTagsResponse{}
+}
+
 func (p *ListTagsParams) SetIsrecursive(v bool) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -312,6 +367,22 @@ func (p *ListTagsParams) SetListall(v bool) {
 	return
 }
 
+func (p *ListTagsParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListTagsParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListTagsParams) SetResourceid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -346,19 +417,35 @@ func (s *TagsService) NewListTagsParams() *ListTagsParams {
 
 // List resource tag(s)
 func (s *TagsService) ListTags(p *ListTagsParams) (*ListTagsResponse, error) {
-	resp, err := s.cs.newRequest("listTags", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListTagsWithContext(context.Background(), p)
+}
 
+// ListTagsWithContext is like ListTags, but the request is bound to ctx.
+func (s *TagsService) ListTagsWithContext(ctx context.Context, p *ListTagsParams) (*ListTagsResponse, error) {
 	var r ListTagsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListTagsPager returns a Pager over the items of all pages of ListTags.
+func (s *TagsService) ListTagsPager(p *ListTagsParams) *Pager[Tag] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]Tag, int, error) {
+		r, err := s.ListTagsWithContext(ctx, &ListTagsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Tags, r.Count, nil
+	})
+}
+
+// ListTagsAll is like ListTagsWithContext, but requests all pages and returns the items of all of them.
+func (s *TagsService) ListTagsAll(ctx context.Context, p *ListTagsParams) ([]Tag, error) {
+	return s.ListTagsPager(p).All(ctx)
+}
+
 type ListTagsResponse struct {
 	Count int   `json:"count"`
 	Tags  []Tag `json:"tag"`
@@ -17,10 +17,12 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 type CreateTemplateParams struct {
@@ -62,6 +64,22 @@ func (p *CreateTemplateParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateTemplateParams) APIName() string {
+	return "createTemplate"
+}
+
+func (p *CreateTemplateParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateTemplateParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateTemplateParams) Response() interface{} {
+	return &CreateTemplateResponse{}
+}
+
 func (p *CreateTemplateParams) SetDisplaytext(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -139,37 +157,31 @@ func (s *TemplateService) NewCreateTemplateParams(displaytext string, name strin
 
 // Creates a template of a virtual machine. The virtual machine must be in a STOPPED state. A template created from this command is automatically designated as a private template visible to the account that created it.
 func (s *TemplateService) CreateTemplate(p *CreateTemplateParams) (*CreateTemplateResponse, error) {
-	resp, err := s.cs.newRequest("createTemplate", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateTemplateWithContext(context.Background(), p)
+}
 
+// CreateTemplateWithContext is like CreateTemplate, but the request is bound to ctx.
+func (s *TemplateService) CreateTemplateWithContext(ctx context.Context, p *CreateTemplateParams) (*CreateTemplateResponse, error) {
 	var r CreateTemplateResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateTemplateJob is like CreateTemplateWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *TemplateService) CreateTemplateJob(ctx context.Context, p *CreateTemplateParams) (*Job[*CreateTemplateResponse], error) {
+	var r CreateTemplateResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateTemplateResponse struct {
@@ -178,7 +190,7 @@ type CreateTemplateResponse struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -200,7 +212,7 @@ type CreateTemplateResponse struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -227,6 +239,22 @@ func (p *DeleteTemplateParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteTemplateParams) APIName() string {
+	return "deleteTemplate"
+}
+
+func (p *DeleteTemplateParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteTemplateParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeleteTemplateParams) Response() interface{} {
+	return &DeleteTemplateResponse{}
+}
+
 func (p *DeleteTemplateParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -246,38 +274,37 @@ func (s *TemplateService) NewDeleteTemplateParams(id string) *DeleteTemplatePara
 
 // Deletes a template from the system. All virtual machines using the deleted template will not be affected.
 func (s *TemplateService) DeleteTemplate(p *DeleteTemplateParams) (*DeleteTemplateResponse, error) {
-	resp, err := s.cs.newRequest("deleteTemplate", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteTemplateWithContext(context.Background(), p)
+}
 
+// DeleteTemplateWithContext is like DeleteTemplate, but the request is bound to ctx.
+func (s *TemplateService) DeleteTemplateWithContext(ctx context.Context, p *DeleteTemplateParams) (*DeleteTemplateResponse, error) {
 	var r DeleteTemplateResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DeleteTemplateJob is like DeleteTemplateWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *TemplateService) DeleteTemplateJob(ctx context.Context, p *DeleteTemplateParams) (*Job[*DeleteTemplateResponse], error) {
+	var r DeleteTemplateResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
 }
 
 type DeleteTemplateResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListTemplatesParams struct {
@@ -298,6 +325,14 @@ func (p *ListTemplatesParams) toURLValues() url.Values {
 	if v, found := p.p["name"]; found {
 		u.Set("name", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["tags"]; found {
 		i := 0
 		for k, vv := range v.(map[string]string) {
@@ -315,6 +350,22 @@ func (p *ListTemplatesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListTemplatesParams) APIName() string {
+	return "listTemplates"
+}
+
+func (p *ListTemplatesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListTemplatesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListTemplatesParams) Response() interface{} {
+	return &ListTemplatesResponse{}
+}
+
 func (p *ListTemplatesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -339,6 +390,22 @@ func (p *ListTemplatesParams) SetName(v string) {
 	return
 }
 
+func (p *ListTemplatesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListTemplatesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListTemplatesParams) SetTags(v map[string]string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -372,8 +439,14 @@ func (s *TemplateService) NewListTemplatesParams(templatefilter string) *ListTem
 	return p
 }
 
-// This is a courtesy helper function, which in some cases may not work as expected!
+// GetTemplateID returns the ID of the template with the given name. The error is a
+// *NotFoundError if there is no such template and an *AmbiguousError if there are several.
 func (s *TemplateService) GetTemplateID(name string, templatefilter string, zoneid string, opts ...OptionFunc) (string, int, error) {
+	return s.GetTemplateIDWithContext(context.Background(), name, templatefilter, zoneid, opts...)
+}
+
+// GetTemplateIDWithContext is like GetTemplateID, but the requests are bound to ctx.
+func (s *TemplateService) GetTemplateIDWithContext(ctx context.Context, name string, templatefilter string, zoneid string, opts ...OptionFunc) (string, int, error) {
 	p := &ListTemplatesParams{}
 	p.p = make(map[string]interface{})
 
@@ -387,44 +460,82 @@ func (s *TemplateService) GetTemplateID(name string, templatefilter string, zone
 		}
 	}
 
-	l, err := s.ListTemplates(p)
+	l, err := s.ListTemplatesAll(ctx, p)
 	if err != nil {
 		return "", -1, err
 	}
 
-	if l.Count == 0 {
-		return "", l.Count, fmt.Errorf("No match found for %s: %+v", name, l)
+	r, count, err := pickByName("Template", name, l, func(v *Template) string { return v.Name }, func(v *Template) string { return v.Id })
+	if err != nil {
+		return "", count, err
 	}
+	return r.Id, count, nil
+}
 
-	if l.Count == 1 {
-		return l.Templates[0].Id, l.Count, nil
-	}
+// WaitUntilTemplateReady waits until the template with the given ID is ready in the given zone
+// and returns it. Registering a template only starts its download, which is not reported by the
+// async job, so use this before deploying from a new template. The state of the wait is the
+// download status of the template. The wait is spaced and limited like KCPSClient.WaitFor,
+// using w or the JobWaiter of the client if w is nil.
+func (s *TemplateService) WaitUntilTemplateReady(ctx context.Context, id string, zoneid string, w *JobWaiter) (*Template, error) {
+	p := s.NewListTemplatesParams("self")
+	p.SetId(id)
+	p.SetZoneid(zoneid)
 
-	if l.Count > 1 {
-		for _, v := range l.Templates {
-			if v.Name == name {
-				return v.Id, l.Count, nil
-			}
+	var t *Template
+	_, err := s.cs.waitFor(ctx, w, "Template", id, func(ctx context.Context) (string, bool, error) {
+		l, err := s.ListTemplatesAll(ctx, p)
+		if err != nil {
+			return "", false, err
+		}
+		if t, _, err = pickByID("Template", id, l, func(v *Template) string { return v.Id }); err != nil {
+			return "", false, err
+		}
+		if t.Isready {
+			return t.Status, true, nil
+		}
+		if strings.Contains(strings.ToLower(t.Status), "fail") {
+			return t.Status, false, &UnexpectedStateError{Kind: "Template", ID: id, State: t.Status}
 		}
+		return t.Status, false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return "", l.Count, fmt.Errorf("Could not find an exact match for %s: %+v", name, l)
+	return t, nil
 }
 
 // List all public, private, and privileged templates.
 func (s *TemplateService) ListTemplates(p *ListTemplatesParams) (*ListTemplatesResponse, error) {
-	resp, err := s.cs.newRequest("listTemplates", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListTemplatesWithContext(context.Background(), p)
+}
 
+// ListTemplatesWithContext is like ListTemplates, but the request is bound to ctx.
+func (s *TemplateService) ListTemplatesWithContext(ctx context.Context, p *ListTemplatesParams) (*ListTemplatesResponse, error) {
 	var r ListTemplatesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListTemplatesPager returns a Pager over the items of all pages of ListTemplates.
+func (s *TemplateService) ListTemplatesPager(p *ListTemplatesParams) *Pager[*Template] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Template, int, error) {
+		r, err := s.ListTemplatesWithContext(ctx, &ListTemplatesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Templates, r.Count, nil
+	})
+}
+
+// ListTemplatesAll is like ListTemplatesWithContext, but requests all pages and returns the items of all of them.
+func (s *TemplateService) ListTemplatesAll(ctx context.Context, p *ListTemplatesParams) ([]*Template, error) {
+	return s.ListTemplatesPager(p).All(ctx)
+}
+
 type ListTemplatesResponse struct {
 	Count     int         `json:"count"`
 	Templates []*Template `json:"template"`
@@ -435,7 +546,7 @@ type Template struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -457,7 +568,7 @@ type Template struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -502,6 +613,22 @@ func (p *RegisterTemplateParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RegisterTemplateParams) APIName() string {
+	return "registerTemplate"
+}
+
+func (p *RegisterTemplateParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RegisterTemplateParams) IsAsync() bool {
+	return false
+}
+
+func (p *RegisterTemplateParams) Response() interface{} {
+	return &RegisterTemplateResponse{}
+}
+
 func (p *RegisterTemplateParams) SetName(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -575,13 +702,13 @@ func (s *TemplateService) NewRegisterTemplateParams(displaytext string, format s
 
 // Registers an existing template into the CloudStack cloud.
 func (s *TemplateService) RegisterTemplate(p *RegisterTemplateParams) (*RegisterTemplateResponse, error) {
-	resp, err := s.cs.newRequest("registerTemplate", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RegisterTemplateWithContext(context.Background(), p)
+}
 
+// RegisterTemplateWithContext is like RegisterTemplate, but the request is bound to ctx.
+func (s *TemplateService) RegisterTemplateWithContext(ctx context.Context, p *RegisterTemplateParams) (*RegisterTemplateResponse, error) {
 	var r RegisterTemplateResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -598,7 +725,7 @@ type RegisterTemplate struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -620,7 +747,7 @@ type RegisterTemplate struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -664,6 +791,22 @@ func (p *UpdateTemplateParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *UpdateTemplateParams) APIName() string {
+	return "updateTemplate"
+}
+
+func (p *UpdateTemplateParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *UpdateTemplateParams) IsAsync() bool {
+	return false
+}
+
+func (p *UpdateTemplateParams) Response() interface{} {
+	return &UpdateTemplateResponse{}
+}
+
 func (p *UpdateTemplateParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -723,13 +866,13 @@ func (s *TemplateService) NewUpdateTemplateParams(id string) *UpdateTemplatePara
 
 // Updates attributes of a template.
 func (s *TemplateService) UpdateTemplate(p *UpdateTemplateParams) (*UpdateTemplateResponse, error) {
-	resp, err := s.cs.newRequest("updateTemplate", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.UpdateTemplateWithContext(context.Background(), p)
+}
 
+// UpdateTemplateWithContext is like UpdateTemplate, but the request is bound to ctx.
+func (s *TemplateService) UpdateTemplateWithContext(ctx context.Context, p *UpdateTemplateParams) (*UpdateTemplateResponse, error) {
 	var r UpdateTemplateResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -741,7 +884,7 @@ type UpdateTemplateResponse struct {
 	Accountid             string            `json:"accountid,omitempty"`
 	Bootable              bool              `json:"bootable,omitempty"`
 	Checksum              string            `json:"checksum,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	CrossZones            bool              `json:"crossZones,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Displaytext           string            `json:"displaytext,omitempty"`
@@ -763,7 +906,7 @@ type UpdateTemplateResponse struct {
 	Passwordenabled       bool              `json:"passwordenabled,omitempty"`
 	Project               string            `json:"project,omitempty"`
 	Projectid             string            `json:"projectid,omitempty"`
-	Removed               string            `json:"removed,omitempty"`
+	Removed               Timestamp         `json:"removed,omitempty"`
 	Size                  int64             `json:"size,omitempty"`
 	Sourcetemplateid      string            `json:"sourcetemplateid,omitempty"`
 	Sshkeyenabled         bool              `json:"sshkeyenabled,omitempty"`
@@ -794,6 +937,22 @@ func (p *UpdateTemplatePermissionsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *UpdateTemplatePermissionsParams) APIName() string {
+	return "updateTemplatePermissions"
+}
+
+func (p *UpdateTemplatePermissionsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *UpdateTemplatePermissionsParams) IsAsync() bool {
+	return false
+}
+
+func (p *UpdateTemplatePermissionsParams) Response() interface{} {
+	return &UpdateTemplatePermissionsResponse{}
+}
+
 func (p *UpdateTemplatePermissionsParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -821,13 +980,13 @@ func (s *TemplateService) NewUpdateTemplatePermissionsParams(id string) *UpdateT
 
 // Updates a template visibility permissions. A public template is visible to all accounts within the same domain. A private template is visible only to the owner of the template. A priviledged template is a private template with account permissions added. Only accounts specified under the template permissions are visible to them.
 func (s *TemplateService) UpdateTemplatePermissions(p *UpdateTemplatePermissionsParams) (*UpdateTemplatePermissionsResponse, error) {
-	resp, err := s.cs.newRequest("updateTemplatePermissions", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.UpdateTemplatePermissionsWithContext(context.Background(), p)
+}
 
+// UpdateTemplatePermissionsWithContext is like UpdateTemplatePermissions, but the request is bound to ctx.
+func (s *TemplateService) UpdateTemplatePermissionsWithContext(ctx context.Context, p *UpdateTemplatePermissionsParams) (*UpdateTemplatePermissionsResponse, error) {
 	var r UpdateTemplatePermissionsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -836,7 +995,7 @@ func (s *TemplateService) UpdateTemplatePermissions(p *UpdateTemplatePermissions
 
 type UpdateTemplatePermissionsResponse struct {
 	//	Displaytext string `json:"displaytext,omitempty"`
-	Success string `json:"success,omitempty"`
+	Success FlexBool `json:"success,omitempty"`
 }
 
 type ListTemplatePermissionsParams struct {
@@ -854,6 +1013,22 @@ func (p *ListTemplatePermissionsParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListTemplatePermissionsParams) APIName() string {
+	return "listTemplatePermissions"
+}
+
+func (p *ListTemplatePermissionsParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListTemplatePermissionsParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListTemplatePermissionsParams) Response() interface{} {
+	return &ListTemplatePermissionsResponse{}
+}
+
 func (p *ListTemplatePermissionsParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -873,19 +1048,36 @@ func (s *TemplateService) NewListTemplatePermissionsParams(id string) *ListTempl
 
 // List template visibility and all accounts that have permissions to view this template.
 func (s *TemplateService) ListTemplatePermissions(p *ListTemplatePermissionsParams) (*ListTemplatePermissionsResponse, error) {
-	resp, err := s.cs.newRequest("listTemplatePermissions", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListTemplatePermissionsWithContext(context.Background(), p)
+}
 
+// ListTemplatePermissionsWithContext is like ListTemplatePermissions, but the request is bound to ctx.
+func (s *TemplateService) ListTemplatePermissionsWithContext(ctx context.Context, p *ListTemplatePermissionsParams) (*ListTemplatePermissionsResponse, error) {
 	var r ListTemplatePermissionsResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListTemplatePermissionsPager returns a Pager over the items of ListTemplatePermissions, which does not support paging and
+// returns all items at once.
+func (s *TemplateService) ListTemplatePermissionsPager(p *ListTemplatePermissionsParams) *Pager[*TemplatePermission] {
+	return newUnpagedPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*TemplatePermission, int, error) {
+		r, err := s.ListTemplatePermissionsWithContext(ctx, &ListTemplatePermissionsParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.TemplatePermissions, r.Count, nil
+	})
+}
+
+// ListTemplatePermissionsAll is like ListTemplatePermissionsWithContext, but returns the items only.
+func (s *TemplateService) ListTemplatePermissionsAll(ctx context.Context, p *ListTemplatePermissionsParams) ([]*TemplatePermission, error) {
+	return s.ListTemplatePermissionsPager(p).All(ctx)
+}
+
 type ListTemplatePermissionsResponse struct {
 	Count               int                   `json:"count"`
 	TemplatePermissions []*TemplatePermission `json:"templatepermission"`
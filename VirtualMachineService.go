@@ -17,11 +17,11 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 type IptoNetworklistParams struct {
@@ -93,6 +93,22 @@ func (p *DeployValueVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeployValueVirtualMachineParams) APIName() string {
+	return "deployValueVirtualMachine"
+}
+
+func (p *DeployValueVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeployValueVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeployValueVirtualMachineParams) Response() interface{} {
+	return &DeployValueVirtualMachineResponse{}
+}
+
 func (p *DeployValueVirtualMachineParams) SetDiskofferingid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -172,54 +188,31 @@ func (s *VirtualMachineService) NewDeployValueVirtualMachineParams(serviceofferi
 
 // Creates and automatically starts a virtual machine based on a service offering, disk offering, and template.
 func (s *VirtualMachineService) DeployValueVirtualMachine(p *DeployValueVirtualMachineParams) (*DeployValueVirtualMachineResponse, error) {
+	return s.DeployValueVirtualMachineWithContext(context.Background(), p)
+}
 
-	if p.p["networkid"] == nil {
-		netid, err := getNetworkIdByName(s.cs, "PublicFrontSegment")
-		if err != nil {
-			return nil, err
+// DeployValueVirtualMachineWithContext is like DeployValueVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) DeployValueVirtualMachineWithContext(ctx context.Context, p *DeployValueVirtualMachineParams) (*DeployValueVirtualMachineResponse, error) {
+	var r DeployValueVirtualMachineResponse
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
 		}
-		nparams := s.cs.VirtualMachine.NewIptoNetworklistParams(netid)
-		p.SetIptoNetworklist([]IptoNetworklistParams{nparams})
-	}
-
-	resp, err := s.cs.newRequest("deployValueVirtualMachine", p.toURLValues())
-	if err != nil {
 		return nil, err
 	}
 
+	return &r, nil
+}
+
+// DeployValueVirtualMachineJob is like DeployValueVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) DeployValueVirtualMachineJob(ctx context.Context, p *DeployValueVirtualMachineParams) (*Job[*DeployValueVirtualMachineResponse], error) {
 	var r DeployValueVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetExAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		// for kcps api response
-		b, err = cnvCorrectVirtualMachineJson(b)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := json.Unmarshal(b, &r); err != nil {
-			fmt.Println(err)
-			return nil, err
-		}
-	}
-
-	return &r, nil
+	return newJob(s.cs, r.JobID, true, &r, decodeJobResult), nil
 }
 
 type DeployValueVirtualMachineResponse struct {
@@ -237,10 +230,10 @@ type DeployValueVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -267,7 +260,7 @@ type DeployValueVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -279,7 +272,7 @@ type DeployValueVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -316,6 +309,22 @@ func (p *DestroyVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DestroyVirtualMachineParams) APIName() string {
+	return "destroyVirtualMachine"
+}
+
+func (p *DestroyVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DestroyVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *DestroyVirtualMachineParams) Response() interface{} {
+	return &DestroyVirtualMachineResponse{}
+}
+
 // You should always use this function to get a new DestroyVirtualMachineParams instance,
 // as then you are sure you have configured all required params
 func (s *VirtualMachineService) NewDestroyVirtualMachineParams(id string) *DestroyVirtualMachineParams {
@@ -327,42 +336,31 @@ func (s *VirtualMachineService) NewDestroyVirtualMachineParams(id string) *Destr
 
 // Destroys a virtual machine.
 func (s *VirtualMachineService) DestroyVirtualMachine(p *DestroyVirtualMachineParams) (*DestroyVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("destroyVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DestroyVirtualMachineWithContext(context.Background(), p)
+}
 
+// DestroyVirtualMachineWithContext is like DestroyVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) DestroyVirtualMachineWithContext(ctx context.Context, p *DestroyVirtualMachineParams) (*DestroyVirtualMachineResponse, error) {
 	var r DestroyVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		var r DeployValueVirtualMachineResponse
-		if err := json.Unmarshal(resp, &r); err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DestroyVirtualMachineJob is like DestroyVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) DestroyVirtualMachineJob(ctx context.Context, p *DestroyVirtualMachineParams) (*Job[*DestroyVirtualMachineResponse], error) {
+	var r DestroyVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type DestroyVirtualMachineResponse struct {
@@ -380,10 +378,10 @@ type DestroyVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -410,7 +408,7 @@ type DestroyVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -422,7 +420,7 @@ type DestroyVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -455,6 +453,22 @@ func (p *RebootVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *RebootVirtualMachineParams) APIName() string {
+	return "rebootVirtualMachine"
+}
+
+func (p *RebootVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *RebootVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *RebootVirtualMachineParams) Response() interface{} {
+	return &RebootVirtualMachineResponse{}
+}
+
 func (p *RebootVirtualMachineParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -474,43 +488,31 @@ func (s *VirtualMachineService) NewRebootVirtualMachineParams(id string) *Reboot
 
 // Reboots a virtual machine.
 func (s *VirtualMachineService) RebootVirtualMachine(p *RebootVirtualMachineParams) (*RebootVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("rebootVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.RebootVirtualMachineWithContext(context.Background(), p)
+}
 
+// RebootVirtualMachineWithContext is like RebootVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) RebootVirtualMachineWithContext(ctx context.Context, p *RebootVirtualMachineParams) (*RebootVirtualMachineResponse, error) {
 	var r RebootVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		// for kcps api response
-		b, err = cnvCorrectVirtualMachineJson(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// RebootVirtualMachineJob is like RebootVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) RebootVirtualMachineJob(ctx context.Context, p *RebootVirtualMachineParams) (*Job[*RebootVirtualMachineResponse], error) {
+	var r RebootVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type RebootVirtualMachineResponse struct {
@@ -528,10 +530,10 @@ type RebootVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -558,7 +560,7 @@ type RebootVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -570,7 +572,7 @@ type RebootVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -603,6 +605,22 @@ func (p *StartVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *StartVirtualMachineParams) APIName() string {
+	return "startVirtualMachine"
+}
+
+func (p *StartVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *StartVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *StartVirtualMachineParams) Response() interface{} {
+	return &StartVirtualMachineResponse{}
+}
+
 func (p *StartVirtualMachineParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -622,43 +640,31 @@ func (s *VirtualMachineService) NewStartVirtualMachineParams(id string) *StartVi
 
 // Starts a virtual machine.
 func (s *VirtualMachineService) StartVirtualMachine(p *StartVirtualMachineParams) (*StartVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("startVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.StartVirtualMachineWithContext(context.Background(), p)
+}
 
+// StartVirtualMachineWithContext is like StartVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) StartVirtualMachineWithContext(ctx context.Context, p *StartVirtualMachineParams) (*StartVirtualMachineResponse, error) {
 	var r StartVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetExAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		// for kcps api response
-		b, err = cnvCorrectVirtualMachineJson(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// StartVirtualMachineJob is like StartVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) StartVirtualMachineJob(ctx context.Context, p *StartVirtualMachineParams) (*Job[*StartVirtualMachineResponse], error) {
+	var r StartVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, true, &r, decodeJobResult), nil
 }
 
 type StartVirtualMachineResponse struct {
@@ -676,10 +682,10 @@ type StartVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -706,7 +712,7 @@ type StartVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -718,7 +724,7 @@ type StartVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -755,6 +761,22 @@ func (p *StopVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *StopVirtualMachineParams) APIName() string {
+	return "stopVirtualMachine"
+}
+
+func (p *StopVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *StopVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *StopVirtualMachineParams) Response() interface{} {
+	return &StopVirtualMachineResponse{}
+}
+
 func (p *StopVirtualMachineParams) SetForced(v bool) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -782,48 +804,31 @@ func (s *VirtualMachineService) NewStopVirtualMachineParams(id string) *StopVirt
 
 // Stops a virtual machine.
 func (s *VirtualMachineService) StopVirtualMachine(p *StopVirtualMachineParams) (*StopVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("stopVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = cnvCorrectVirtualMachineJson(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.StopVirtualMachineWithContext(context.Background(), p)
+}
 
+// StopVirtualMachineWithContext is like StopVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) StopVirtualMachineWithContext(ctx context.Context, p *StopVirtualMachineParams) (*StopVirtualMachineResponse, error) {
 	var r StopVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		// for kcps api response
-		b, err = cnvCorrectVirtualMachineJson(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// StopVirtualMachineJob is like StopVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) StopVirtualMachineJob(ctx context.Context, p *StopVirtualMachineParams) (*Job[*StopVirtualMachineResponse], error) {
+	var r StopVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type StopVirtualMachineResponse struct {
@@ -841,10 +846,10 @@ type StopVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -871,7 +876,7 @@ type StopVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -883,7 +888,7 @@ type StopVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -916,6 +921,22 @@ func (p *ResetPasswordForVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ResetPasswordForVirtualMachineParams) APIName() string {
+	return "resetPasswordForVirtualMachine"
+}
+
+func (p *ResetPasswordForVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ResetPasswordForVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *ResetPasswordForVirtualMachineParams) Response() interface{} {
+	return &ResetPasswordForVirtualMachineResponse{}
+}
+
 func (p *ResetPasswordForVirtualMachineParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -935,48 +956,31 @@ func (s *VirtualMachineService) NewResetPasswordForVirtualMachineParams(id strin
 
 // Resets the password for virtual machine. The virtual machine must be in a "Stopped" state and the template must already support this feature for this command to take effect. [async]
 func (s *VirtualMachineService) ResetPasswordForVirtualMachine(p *ResetPasswordForVirtualMachineParams) (*ResetPasswordForVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("resetPasswordForVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = cnvCorrectVirtualMachineJson(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.ResetPasswordForVirtualMachineWithContext(context.Background(), p)
+}
 
+// ResetPasswordForVirtualMachineWithContext is like ResetPasswordForVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) ResetPasswordForVirtualMachineWithContext(ctx context.Context, p *ResetPasswordForVirtualMachineParams) (*ResetPasswordForVirtualMachineResponse, error) {
 	var r ResetPasswordForVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		// for kcps api response
-		b, err = cnvCorrectVirtualMachineJson(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// ResetPasswordForVirtualMachineJob is like ResetPasswordForVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) ResetPasswordForVirtualMachineJob(ctx context.Context, p *ResetPasswordForVirtualMachineParams) (*Job[*ResetPasswordForVirtualMachineResponse], error) {
+	var r ResetPasswordForVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type ResetPasswordForVirtualMachineResponse struct {
@@ -994,10 +998,10 @@ type ResetPasswordForVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -1024,7 +1028,7 @@ type ResetPasswordForVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -1036,7 +1040,7 @@ type ResetPasswordForVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -1075,6 +1079,14 @@ func (p *ListVirtualMachinesParams) toURLValues() url.Values {
 	if v, found := p.p["networkid"]; found {
 		u.Set("networkid", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["state"]; found {
 		u.Set("state", v.(string))
 	}
@@ -1095,6 +1107,22 @@ func (p *ListVirtualMachinesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListVirtualMachinesParams) APIName() string {
+	return "listVirtualMachines"
+}
+
+func (p *ListVirtualMachinesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListVirtualMachinesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListVirtualMachinesParams) Response() interface{} {
+	return &ListVirtualMachinesResponse{}
+}
+
 func (p *ListVirtualMachinesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -1125,6 +1153,23 @@ func (p *ListVirtualMachinesParams) SetNetworkid(v string) {
 	p.p["networkid"] = v
 	return
 }
+
+func (p *ListVirtualMachinesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListVirtualMachinesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListVirtualMachinesParams) SetState(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -1165,21 +1210,140 @@ func (s *VirtualMachineService) NewListVirtualMachinesParams() *ListVirtualMachi
 	return p
 }
 
-// List the virtual machines owned by the account.
-func (s *VirtualMachineService) ListVirtualMachines(p *ListVirtualMachinesParams) (*ListVirtualMachinesResponse, error) {
-	resp, err := s.cs.newRequest("listVirtualMachines", p.toURLValues())
+// GetVirtualMachineID returns the ID of the virtual machine with the given name. The error is a
+// *NotFoundError if there is no such virtual machine and an *AmbiguousError if there are several.
+func (s *VirtualMachineService) GetVirtualMachineID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetVirtualMachineIDWithContext(context.Background(), name, opts...)
+}
+
+// GetVirtualMachineIDWithContext is like GetVirtualMachineID, but the requests are bound to ctx.
+func (s *VirtualMachineService) GetVirtualMachineIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetVirtualMachineByNameWithContext(ctx, name, opts...)
+	if err != nil {
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetVirtualMachineByName returns the virtual machine with the given name, like GetVirtualMachineID.
+func (s *VirtualMachineService) GetVirtualMachineByName(name string, opts ...OptionFunc) (*VirtualMachine, int, error) {
+	return s.GetVirtualMachineByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetVirtualMachineByNameWithContext is like GetVirtualMachineByName, but the requests are bound to ctx.
+func (s *VirtualMachineService) GetVirtualMachineByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*VirtualMachine, int, error) {
+	p := &ListVirtualMachinesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["name"] = name
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListVirtualMachinesAll(ctx, p)
+	if err != nil {
+		return nil, -1, err
+	}
+	return pickByName("VirtualMachine", name, l, func(v *VirtualMachine) string { return v.Name }, func(v *VirtualMachine) string { return v.Id })
+}
+
+// GetVirtualMachineByID returns the virtual machine with the given ID. The error is a *NotFoundError if there
+// is no such virtual machine.
+func (s *VirtualMachineService) GetVirtualMachineByID(id string, opts ...OptionFunc) (*VirtualMachine, int, error) {
+	return s.GetVirtualMachineByIDWithContext(context.Background(), id, opts...)
+}
+
+// GetVirtualMachineByIDWithContext is like GetVirtualMachineByID, but the requests are bound to ctx.
+func (s *VirtualMachineService) GetVirtualMachineByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*VirtualMachine, int, error) {
+	p := &ListVirtualMachinesParams{}
+	p.p = make(map[string]interface{})
+
+	p.p["id"] = id
+
+	for _, fn := range opts {
+		if err := fn(s.cs, p); err != nil {
+			return nil, -1, err
+		}
+	}
+
+	l, err := s.ListVirtualMachinesAll(ctx, p)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "VirtualMachine", Key: id}
+		}
+		return nil, -1, err
+	}
+	return pickByID("VirtualMachine", id, l, func(v *VirtualMachine) string { return v.Id })
+}
+
+// WaitUntilVirtualMachineRunning waits until the virtual machine with the given ID is Running and
+// returns it. The wait is spaced and limited like KCPSClient.WaitFor, using w or the JobWaiter
+// of the client if w is nil.
+func (s *VirtualMachineService) WaitUntilVirtualMachineRunning(ctx context.Context, id string, w *JobWaiter) (*VirtualMachine, error) {
+	return s.waitForState(ctx, id, "Running", w)
+}
+
+// WaitUntilVirtualMachineStopped is like WaitUntilVirtualMachineRunning, but waits until the
+// virtual machine is Stopped.
+func (s *VirtualMachineService) WaitUntilVirtualMachineStopped(ctx context.Context, id string, w *JobWaiter) (*VirtualMachine, error) {
+	return s.waitForState(ctx, id, "Stopped", w)
+}
+
+func (s *VirtualMachineService) waitForState(ctx context.Context, id string, want string, w *JobWaiter) (*VirtualMachine, error) {
+	var vm *VirtualMachine
+	_, err := s.cs.waitFor(ctx, w, "VirtualMachine", id, func(ctx context.Context) (string, bool, error) {
+		var err error
+		if vm, _, err = s.GetVirtualMachineByIDWithContext(ctx, id); err != nil {
+			return "", false, err
+		}
+		switch vm.State {
+		case want:
+			return vm.State, true, nil
+		case "Error", "Destroyed", "Expunging":
+			return vm.State, false, &UnexpectedStateError{Kind: "VirtualMachine", ID: id, State: vm.State}
+		}
+		return vm.State, false, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return vm, nil
+}
+
+// List the virtual machines owned by the account.
+func (s *VirtualMachineService) ListVirtualMachines(p *ListVirtualMachinesParams) (*ListVirtualMachinesResponse, error) {
+	return s.ListVirtualMachinesWithContext(context.Background(), p)
+}
 
+// ListVirtualMachinesWithContext is like ListVirtualMachines, but the request is bound to ctx.
+func (s *VirtualMachineService) ListVirtualMachinesWithContext(ctx context.Context, p *ListVirtualMachinesParams) (*ListVirtualMachinesResponse, error) {
 	var r ListVirtualMachinesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListVirtualMachinesPager returns a Pager over the items of all pages of ListVirtualMachines.
+func (s *VirtualMachineService) ListVirtualMachinesPager(p *ListVirtualMachinesParams) *Pager[*VirtualMachine] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*VirtualMachine, int, error) {
+		r, err := s.ListVirtualMachinesWithContext(ctx, &ListVirtualMachinesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.VirtualMachines, r.Count, nil
+	})
+}
+
+// ListVirtualMachinesAll is like ListVirtualMachinesWithContext, but requests all pages and returns the items of all of them.
+func (s *VirtualMachineService) ListVirtualMachinesAll(ctx context.Context, p *ListVirtualMachinesParams) ([]*VirtualMachine, error) {
+	return s.ListVirtualMachinesPager(p).All(ctx)
+}
+
 type ListVirtualMachinesResponse struct {
 	Count           int               `json:"count"`
 	VirtualMachines []*VirtualMachine `json:"virtualmachine"`
@@ -1199,10 +1363,10 @@ type VirtualMachine struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -1229,7 +1393,7 @@ type VirtualMachine struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -1241,7 +1405,7 @@ type VirtualMachine struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -1277,6 +1441,22 @@ func (p *ChangeServiceForVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ChangeServiceForVirtualMachineParams) APIName() string {
+	return "changeServiceForVirtualMachine"
+}
+
+func (p *ChangeServiceForVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ChangeServiceForVirtualMachineParams) IsAsync() bool {
+	return false
+}
+
+func (p *ChangeServiceForVirtualMachineParams) Response() interface{} {
+	return &ChangeServiceForVirtualMachineResponse{}
+}
+
 func (p *ChangeServiceForVirtualMachineParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -1305,18 +1485,13 @@ func (s *VirtualMachineService) NewChangeServiceForVirtualMachineParams(id strin
 
 // Changes the service offering for a virtual machine. The virtual machine must be in a "Stopped" state for this command to take effect.
 func (s *VirtualMachineService) ChangeServiceForVirtualMachine(p *ChangeServiceForVirtualMachineParams) (*ChangeServiceForVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("changeServiceForVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = cnvCorrectVirtualMachineJson(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.ChangeServiceForVirtualMachineWithContext(context.Background(), p)
+}
 
+// ChangeServiceForVirtualMachineWithContext is like ChangeServiceForVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) ChangeServiceForVirtualMachineWithContext(ctx context.Context, p *ChangeServiceForVirtualMachineParams) (*ChangeServiceForVirtualMachineResponse, error) {
 	var r ChangeServiceForVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -1337,10 +1512,10 @@ type ChangeServiceForVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               int               `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -1367,7 +1542,7 @@ type ChangeServiceForVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -1379,7 +1554,7 @@ type ChangeServiceForVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -1415,6 +1590,22 @@ func (p *ScaleVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ScaleVirtualMachineParams) APIName() string {
+	return "scaleVirtualMachine"
+}
+
+func (p *ScaleVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ScaleVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *ScaleVirtualMachineParams) Response() interface{} {
+	return &ScaleVirtualMachineResponse{}
+}
+
 func (p *ScaleVirtualMachineParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -1443,89 +1634,37 @@ func (s *VirtualMachineService) NewScaleVirtualMachineParams(id string, serviceo
 
 // Scales the virtual machine to a new service offering.
 func (s *VirtualMachineService) ScaleVirtualMachine(p *ScaleVirtualMachineParams) (*ScaleVirtualMachineResponse, error) {
-	resp, err := s.cs.newRequest("scaleVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err = cnvCorrectVirtualMachineJson(resp)
-	if err != nil {
-		return nil, err
-	}
+	return s.ScaleVirtualMachineWithContext(context.Background(), p)
+}
 
+// ScaleVirtualMachineWithContext is like ScaleVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) ScaleVirtualMachineWithContext(ctx context.Context, p *ScaleVirtualMachineParams) (*ScaleVirtualMachineResponse, error) {
 	var r ScaleVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
-		return nil, err
-	}
-
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
 		}
+		return nil, err
 	}
 
 	return &r, nil
 }
 
-type ScaleVirtualMachineResponse struct {
-	JobID       string `json:"jobid,omitempty"`
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     bool   `json:"success,omitempty"`
-}
-
-func cnvCorrectVirtualMachineJson(message json.RawMessage) (json.RawMessage, error) {
-	var m interface{}
-	if err := json.Unmarshal(message, &m); err != nil {
+// ScaleVirtualMachineJob is like ScaleVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) ScaleVirtualMachineJob(ctx context.Context, p *ScaleVirtualMachineParams) (*Job[*ScaleVirtualMachineResponse], error) {
+	var r ScaleVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
 		return nil, err
 	}
 
-	vm := m.(map[string]interface{})
-
-	if cpunum, ok := vm["cpunumber"].(string); ok {
-		cpunumstr, err := strconv.Atoi(cpunum)
-		if err != nil {
-			return nil, err
-		}
-		vm["cpunumber"] = int(cpunumstr)
-	}
-
-	if _, ok := vm["cpuspeed"].(string); ok {
-		cpuspeed, err := strconv.Atoi(vm["cpuspeed"].(string))
-		if err != nil {
-			return nil, err
-		}
-		vm["cpuspeed"] = int(cpuspeed)
-	}
-
-	if _, ok := vm["created"].(float64); ok {
-		utime := int64(vm["created"].(float64)) / 1000
-		t := time.Unix(utime, 0)
-		vm["created"] = t.Format("2006-01-02T15:04:05+09:00")
-	}
-
-	if _, ok := vm["rootdeviceid"].(string); ok {
-		rootdeviceid, err := strconv.ParseInt(vm["rootdeviceid"].(string), 10, 64)
-		if err != nil {
-			return nil, err
-		}
-		vm["rootdeviceid"] = int64(rootdeviceid)
-	}
-
-	b, err := json.Marshal(vm)
-	if err != nil {
-		return nil, err
-	}
+	return newJob(s.cs, r.JobID, false, &r, decodePlainJobResult), nil
+}
 
-	return json.RawMessage(b), nil
+type ScaleVirtualMachineResponse struct {
+	JobID       string   `json:"jobid,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 // For KCPS premium host
@@ -1577,6 +1716,22 @@ func (p *DeployPremiumVirtualMachineParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeployPremiumVirtualMachineParams) APIName() string {
+	return "deployPremiumVirtualMachine"
+}
+
+func (p *DeployPremiumVirtualMachineParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeployPremiumVirtualMachineParams) IsAsync() bool {
+	return true
+}
+
+func (p *DeployPremiumVirtualMachineParams) Response() interface{} {
+	return &DeployPremiumVirtualMachineResponse{}
+}
+
 func (p *DeployPremiumVirtualMachineParams) SetDiskofferingid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -1609,11 +1764,16 @@ func (p *DeployPremiumVirtualMachineParams) SetName(v string) {
 	return
 }
 
+// SetNetworkids is a shorthand for SetIptoNetworklist without IP addresses.
 func (p *DeployPremiumVirtualMachineParams) SetNetworkids(v []string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
 	}
-	p.p["networkids"] = v
+	l := make([]IptoNetworklistParams, len(v))
+	for i, id := range v {
+		l[i].Networkid = id
+	}
+	p.p["networkids"] = l
 	return
 }
 
@@ -1673,52 +1833,31 @@ func (s *VirtualMachineService) NewDeployPremiumVirtualMachineParams(serviceoffe
 
 // Creates and automatically starts a virtual machine based on a service offering, disk offering, and template.
 func (s *VirtualMachineService) DeployPremiumVirtualMachine(p *DeployPremiumVirtualMachineParams) (*DeployPremiumVirtualMachineResponse, error) {
-	if p.p["networkid"] == nil {
-		netid, err := getNetworkIdByName(s.cs, "PublicFrontSegment")
-		if err != nil {
-			return nil, err
-		}
-		nparams := s.cs.VirtualMachine.NewIptoNetworklistParams(netid)
-		p.SetIptoNetworklist([]IptoNetworklistParams{nparams})
-	}
-	resp, err := s.cs.newRequest("deployPremiumVirtualMachine", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeployPremiumVirtualMachineWithContext(context.Background(), p)
+}
 
+// DeployPremiumVirtualMachineWithContext is like DeployPremiumVirtualMachine, but the request is bound to ctx.
+func (s *VirtualMachineService) DeployPremiumVirtualMachineWithContext(ctx context.Context, p *DeployPremiumVirtualMachineParams) (*DeployPremiumVirtualMachineResponse, error) {
 	var r DeployPremiumVirtualMachineResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetExAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
-
-		// for kcps api response
-		b, err = cnvCorrectVirtualMachineJson(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			fmt.Println(err)
-			return nil, err
-		}
+// DeployPremiumVirtualMachineJob is like DeployPremiumVirtualMachineWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VirtualMachineService) DeployPremiumVirtualMachineJob(ctx context.Context, p *DeployPremiumVirtualMachineParams) (*Job[*DeployPremiumVirtualMachineResponse], error) {
+	var r DeployPremiumVirtualMachineResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, true, &r, decodeJobResult), nil
 }
 
 type DeployPremiumVirtualMachineResponse struct {
@@ -1736,10 +1875,10 @@ type DeployPremiumVirtualMachineResponse struct {
 		Type              string   `json:"type,omitempty"`
 		VirtualmachineIds []string `json:"virtualmachineIds,omitempty"`
 	} `json:"affinitygroup,omitempty"`
-	Cpunumber             int               `json:"cpunumber,omitempty"`
-	Cpuspeed              int               `json:"cpuspeed,omitempty"`
+	Cpunumber             FlexInt           `json:"cpunumber,omitempty"`
+	Cpuspeed              FlexInt           `json:"cpuspeed,omitempty"`
 	Cpuused               string            `json:"cpuused,omitempty"`
-	Created               string            `json:"created,omitempty"`
+	Created               Timestamp         `json:"created,omitempty"`
 	Details               map[string]string `json:"details,omitempty"`
 	Diskioread            int64             `json:"diskioread,omitempty"`
 	Diskiowrite           int64             `json:"diskiowrite,omitempty"`
@@ -1766,7 +1905,7 @@ type DeployPremiumVirtualMachineResponse struct {
 	Isoid                 string            `json:"isoid,omitempty"`
 	Isoname               string            `json:"isoname,omitempty"`
 	Keypair               string            `json:"keypair,omitempty"`
-	Memory                int               `json:"memory,omitempty"`
+	Memory                FlexInt           `json:"memory,omitempty"`
 	Name                  string            `json:"name,omitempty"`
 	Networkkbsread        int64             `json:"networkkbsread,omitempty"`
 	Networkkbswrite       int64             `json:"networkkbswrite,omitempty"`
@@ -1778,7 +1917,7 @@ type DeployPremiumVirtualMachineResponse struct {
 	Projectid             string            `json:"projectid,omitempty"`
 	Publicip              string            `json:"publicip,omitempty"`
 	Publicipid            string            `json:"publicipid,omitempty"`
-	Rootdeviceid          int64             `json:"rootdeviceid,omitempty"`
+	Rootdeviceid          FlexInt64         `json:"rootdeviceid,omitempty"`
 	Rootdevicetype        string            `json:"rootdevicetype,omitempty"`
 	Securitygroups        []Securitygroup   `json:"securitygroup,omitempty"`
 	Serviceofferingid     string            `json:"serviceofferingid,omitempty"`
@@ -1796,20 +1935,34 @@ type DeployPremiumVirtualMachineResponse struct {
 	Zonename              string            `json:"zonename,omitempty"`
 }
 
-func getNetworkIdByName(cs *KCPSClient, networkname string) (string, error) {
-	params := cs.AccountDomain.NewListNetworksParams()
-	params.SetKeyword(networkname)
-	resp, err := cs.AccountDomain.ListNetworks(params)
-	if err != nil {
-		return "", err
+// KDDI deploy commands which get the default network of the client when their params contain
+// no network, by lower case name
+var defaultNetworkCommands = map[string]bool{
+	"deployvaluevirtualmachine":   true,
+	"deploypremiumvirtualmachine": true,
+}
+
+// Returns the params of a deploy command with the default network of the client added, when
+// they contain no network and a default network is configured. The network is resolved for
+// every request, in the zone of the params, and the params passed in are never modified.
+func (cs *KCPSClient) withDefaultNetwork(ctx context.Context, params url.Values) (url.Values, error) {
+	if cs.defaultNetwork == "" || params.Get("iptonetworklist[0].networkid") != "" || params.Get("networkids") != "" {
+		return params, nil
 	}
 
-	if resp.Count > 1 {
-		return "", fmt.Errorf("Multiple network found. Network Name:", networkname)
+	netid := cs.defaultNetwork
+	if !IsID(netid) {
+		var err error
+		netid, _, err = cs.AccountDomain.GetNetworkIDWithContext(ctx, netid, WithZoneID(params.Get("zoneid")))
+		if err != nil {
+			return nil, fmt.Errorf("Unable to find the default network: %w", err)
+		}
 	}
 
-	if resp.Count == 0 {
-		return "", fmt.Errorf("Network not found. Network Name:", networkname)
+	p := make(url.Values, len(params)+1)
+	for k, v := range params {
+		p[k] = v
 	}
-	return resp.Networks[0].Id, nil
+	p.Set("iptonetworklist[0].networkid", netid)
+	return p, nil
 }
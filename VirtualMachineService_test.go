@@ -0,0 +1,192 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeployValueVirtualMachineEmptyParams(t *testing.T) {
+	srv, cs := newTestClient(t)
+
+	// The params have no map yet, which must not keep the default network from being set
+	_, err := cs.VirtualMachine.DeployValueVirtualMachine(&DeployValueVirtualMachineParams{})
+	if err == nil {
+		t.Fatal("expected an error for the missing parameters")
+	}
+
+	reqs := srv.Requests()
+	last := reqs[len(reqs)-1]
+	if last.Command != "deployValueVirtualMachine" {
+		t.Fatalf("last command = %s, want deployValueVirtualMachine", last.Command)
+	}
+	if got := last.Params.Get("iptonetworklist[0].networkid"); got != srv.NetworkID {
+		t.Errorf("networkid = %q, want the default network %q", got, srv.NetworkID)
+	}
+}
+
+func TestDeployValueVirtualMachineDefaultNetwork(t *testing.T) {
+	tests := []struct {
+		name     string
+		network  string
+		explicit bool
+		want     string
+	}{
+		{name: "builtin default", want: "PublicFrontSegment"},
+		{name: "default by name", network: "PrivateBackSegment", want: "PrivateBackSegment"},
+		{name: "explicit network", network: "PrivateBackSegment", explicit: true, want: "PublicFrontSegment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithAsync(true)}
+			if tt.network != "" {
+				opts = append(opts, WithDefaultNetwork(tt.network))
+			}
+			srv, cs := newTestClient(t, opts...)
+
+			p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+			if tt.explicit {
+				p.SetIptoNetworklist([]IptoNetworklistParams{{Networkid: srv.NetworkID}})
+			}
+			vm, err := cs.VirtualMachine.DeployValueVirtualMachine(p)
+			if err != nil {
+				t.Fatalf("DeployValueVirtualMachine: %v", err)
+			}
+			want, _, err := cs.AccountDomain.GetNetworkID(tt.want)
+			if err != nil {
+				t.Fatalf("GetNetworkID: %v", err)
+			}
+			if len(vm.Nics) != 1 || vm.Nics[0].Networkid != want {
+				t.Errorf("nics = %+v, want a single nic in %s", vm.Nics, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployValueVirtualMachineJobResult(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true))
+
+	// The job result has cpunumber as a string and created as epoch millis
+	before := time.Now().Truncate(time.Millisecond)
+	vm, err := cs.VirtualMachine.DeployValueVirtualMachine(
+		cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1"))
+	if err != nil {
+		t.Fatalf("DeployValueVirtualMachine: %v", err)
+	}
+
+	if vm.Cpunumber != 2 || vm.Cpuspeed != 2000 {
+		t.Errorf("cpunumber, cpuspeed = %d, %d, want 2, 2000", vm.Cpunumber, vm.Cpuspeed)
+	}
+	if vm.Created.Before(before) || vm.Created.After(time.Now()) {
+		t.Errorf("created = %s, want between %s and now", vm.Created, before)
+	}
+	if vm.Id == "" || vm.Name != "vm1" || vm.State != "Running" {
+		t.Errorf("vm = %s %q %s, want a running vm1", vm.Id, vm.Name, vm.State)
+	}
+	if n := srv.Count("queryExAsyncJobResult"); n == 0 {
+		t.Error("the job was not polled with queryExAsyncJobResult")
+	}
+}
+
+func TestDeployValueVirtualMachineJobDelay(t *testing.T) {
+	srv, cs := newTestClient(t, WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1}))
+	srv.SetJobDelay(50 * time.Millisecond)
+
+	j, err := cs.VirtualMachine.DeployValueVirtualMachineJob(context.Background(),
+		cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1"))
+	if err != nil {
+		t.Fatalf("DeployValueVirtualMachineJob: %v", err)
+	}
+	if s := j.Status(); s != JobStatusPending {
+		t.Errorf("status = %d right after the deploy, want pending", s)
+	}
+
+	vm, err := j.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if vm.State != "Running" || vm.Created.IsZero() {
+		t.Errorf("vm = %s created %s, want a running vm with a creation time", vm.State, vm.Created)
+	}
+}
+
+func TestDeployValueVirtualMachineParamsReusedAcrossZones(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true))
+	zone2, network2 := srv.AddZone("jp2-east04")
+
+	p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+	vm, err := cs.VirtualMachine.DeployValueVirtualMachine(p)
+	if err != nil {
+		t.Fatalf("DeployValueVirtualMachine: %v", err)
+	}
+	if len(vm.Nics) != 1 || vm.Nics[0].Networkid != srv.NetworkID {
+		t.Errorf("nics = %+v, want a single nic in %s", vm.Nics, srv.NetworkID)
+	}
+	if _, ok := p.toURLValues()["iptonetworklist[0].networkid"]; ok {
+		t.Error("the default network was written into the params")
+	}
+
+	p.SetZoneid(zone2)
+	p.SetName("vm2")
+	vm, err = cs.VirtualMachine.DeployValueVirtualMachine(p)
+	if err != nil {
+		t.Fatalf("DeployValueVirtualMachine: %v", err)
+	}
+	if len(vm.Nics) != 1 || vm.Nics[0].Networkid != network2 {
+		t.Errorf("nics = %+v, want a single nic in %s", vm.Nics, network2)
+	}
+}
+
+func TestDeployValueVirtualMachineNoNetworkLookup(t *testing.T) {
+	t.Run("networkids set", func(t *testing.T) {
+		srv, cs := newTestClient(t)
+
+		p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+		p.SetIptoNetworklist([]IptoNetworklistParams{{Networkid: srv.NetworkID}})
+		if _, err := cs.VirtualMachine.DeployValueVirtualMachine(p); err != nil {
+			t.Fatalf("DeployValueVirtualMachine: %v", err)
+		}
+		if n := srv.Count("listNetworks"); n != 0 {
+			t.Errorf("listNetworks was called %d times, want 0", n)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		var seen Command
+		dryRun := func(next Handler) Handler {
+			return func(ctx context.Context, cmd Command, out interface{}) error {
+				seen = cmd
+				return nil
+			}
+		}
+		srv, cs := newTestClient(t, WithMiddleware(dryRun))
+
+		p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+		if _, err := cs.VirtualMachine.DeployValueVirtualMachine(p); err != nil {
+			t.Fatalf("DeployValueVirtualMachine: %v", err)
+		}
+		if n := srv.Count("listNetworks"); n != 0 {
+			t.Errorf("listNetworks was called %d times, want 0", n)
+		}
+		if seen == nil || seen.Values().Get("iptonetworklist[0].networkid") != "" {
+			t.Errorf("the middleware saw %v, want the params without a network", seen)
+		}
+	})
+}
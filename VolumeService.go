@@ -17,11 +17,11 @@
 package gokcps
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
 )
 
 type AttachVolumeParams struct {
@@ -42,6 +42,22 @@ func (p *AttachVolumeParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *AttachVolumeParams) APIName() string {
+	return "attachVolume"
+}
+
+func (p *AttachVolumeParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *AttachVolumeParams) IsAsync() bool {
+	return true
+}
+
+func (p *AttachVolumeParams) Response() interface{} {
+	return &AttachVolumeResponse{}
+}
+
 func (p *AttachVolumeParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -70,92 +86,86 @@ func (s *VolumeService) NewAttachVolumeParams(id string, virtualmachineid string
 
 // Attaches a disk volume to a virtual machine.
 func (s *VolumeService) AttachVolume(p *AttachVolumeParams) (*AttachVolumeResponse, error) {
-	resp, err := s.cs.newRequest("attachVolume", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.AttachVolumeWithContext(context.Background(), p)
+}
 
+// AttachVolumeWithContext is like AttachVolume, but the request is bound to ctx.
+func (s *VolumeService) AttachVolumeWithContext(ctx context.Context, p *AttachVolumeParams) (*AttachVolumeResponse, error) {
 	var r AttachVolumeResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// AttachVolumeJob is like AttachVolumeWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VolumeService) AttachVolumeJob(ctx context.Context, p *AttachVolumeParams) (*Job[*AttachVolumeResponse], error) {
+	var r AttachVolumeResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type AttachVolumeResponse struct {
-	JobID                      string `json:"jobid,omitempty"`
-	Account                    string `json:"account,omitempty"`
-	Attached                   string `json:"attached,omitempty"`
-	Chaininfo                  string `json:"chaininfo,omitempty"`
-	Created                    string `json:"created,omitempty"`
-	Destroyed                  bool   `json:"destroyed,omitempty"`
-	Deviceid                   int64  `json:"deviceid,omitempty"`
-	DiskBytesReadRate          int64  `json:"diskBytesReadRate,omitempty"`
-	DiskBytesWriteRate         int64  `json:"diskBytesWriteRate,omitempty"`
-	DiskIopsReadRate           int64  `json:"diskIopsReadRate,omitempty"`
-	DiskIopsWriteRate          int64  `json:"diskIopsWriteRate,omitempty"`
-	Diskofferingdisplaytext    string `json:"diskofferingdisplaytext,omitempty"`
-	Diskofferingid             string `json:"diskofferingid,omitempty"`
-	Diskofferingname           string `json:"diskofferingname,omitempty"`
-	Displayvolume              bool   `json:"displayvolume,omitempty"`
-	Domain                     string `json:"domain,omitempty"`
-	Domainid                   string `json:"domainid,omitempty"`
-	Hypervisor                 string `json:"hypervisor,omitempty"`
-	Id                         string `json:"id,omitempty"`
-	Isextractable              bool   `json:"isextractable,omitempty"`
-	Isodisplaytext             string `json:"isodisplaytext,omitempty"`
-	Isoid                      string `json:"isoid,omitempty"`
-	Isoname                    string `json:"isoname,omitempty"`
-	Maxiops                    int64  `json:"maxiops,omitempty"`
-	Miniops                    int64  `json:"miniops,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	Path                       string `json:"path,omitempty"`
-	Project                    string `json:"project,omitempty"`
-	Projectid                  string `json:"projectid,omitempty"`
-	Provisioningtype           string `json:"provisioningtype,omitempty"`
-	Quiescevm                  bool   `json:"quiescevm,omitempty"`
-	Serviceofferingdisplaytext string `json:"serviceofferingdisplaytext,omitempty"`
-	Serviceofferingid          string `json:"serviceofferingid,omitempty"`
-	Serviceofferingname        string `json:"serviceofferingname,omitempty"`
-	Size                       int64  `json:"size,omitempty"`
-	Snapshotid                 string `json:"snapshotid,omitempty"`
-	State                      string `json:"state,omitempty"`
-	Status                     string `json:"status,omitempty"`
-	Storage                    string `json:"storage,omitempty"`
-	Storageid                  string `json:"storageid,omitempty"`
-	Storagetype                string `json:"storagetype,omitempty"`
-	Tags                       []Tag  `json:"tags,omitempty"`
-	Templatedisplaytext        string `json:"templatedisplaytext,omitempty"`
-	Templateid                 string `json:"templateid,omitempty"`
-	Templatename               string `json:"templatename,omitempty"`
-	Type                       string `json:"type,omitempty"`
-	Virtualmachineid           string `json:"virtualmachineid,omitempty"`
-	Vmdisplayname              string `json:"vmdisplayname,omitempty"`
-	Vmname                     string `json:"vmname,omitempty"`
-	Vmstate                    string `json:"vmstate,omitempty"`
-	Zoneid                     string `json:"zoneid,omitempty"`
-	Zonename                   string `json:"zonename,omitempty"`
+	JobID                      string    `json:"jobid,omitempty"`
+	Account                    string    `json:"account,omitempty"`
+	Attached                   Timestamp `json:"attached,omitempty"`
+	Chaininfo                  string    `json:"chaininfo,omitempty"`
+	Created                    Timestamp `json:"created,omitempty"`
+	Destroyed                  bool      `json:"destroyed,omitempty"`
+	Deviceid                   int64     `json:"deviceid,omitempty"`
+	DiskBytesReadRate          int64     `json:"diskBytesReadRate,omitempty"`
+	DiskBytesWriteRate         int64     `json:"diskBytesWriteRate,omitempty"`
+	DiskIopsReadRate           int64     `json:"diskIopsReadRate,omitempty"`
+	DiskIopsWriteRate          int64     `json:"diskIopsWriteRate,omitempty"`
+	Diskofferingdisplaytext    string    `json:"diskofferingdisplaytext,omitempty"`
+	Diskofferingid             string    `json:"diskofferingid,omitempty"`
+	Diskofferingname           string    `json:"diskofferingname,omitempty"`
+	Displayvolume              bool      `json:"displayvolume,omitempty"`
+	Domain                     string    `json:"domain,omitempty"`
+	Domainid                   string    `json:"domainid,omitempty"`
+	Hypervisor                 string    `json:"hypervisor,omitempty"`
+	Id                         string    `json:"id,omitempty"`
+	Isextractable              bool      `json:"isextractable,omitempty"`
+	Isodisplaytext             string    `json:"isodisplaytext,omitempty"`
+	Isoid                      string    `json:"isoid,omitempty"`
+	Isoname                    string    `json:"isoname,omitempty"`
+	Maxiops                    int64     `json:"maxiops,omitempty"`
+	Miniops                    int64     `json:"miniops,omitempty"`
+	Name                       string    `json:"name,omitempty"`
+	Path                       string    `json:"path,omitempty"`
+	Project                    string    `json:"project,omitempty"`
+	Projectid                  string    `json:"projectid,omitempty"`
+	Provisioningtype           string    `json:"provisioningtype,omitempty"`
+	Quiescevm                  bool      `json:"quiescevm,omitempty"`
+	Serviceofferingdisplaytext string    `json:"serviceofferingdisplaytext,omitempty"`
+	Serviceofferingid          string    `json:"serviceofferingid,omitempty"`
+	Serviceofferingname        string    `json:"serviceofferingname,omitempty"`
+	Size                       int64     `json:"size,omitempty"`
+	Snapshotid                 string    `json:"snapshotid,omitempty"`
+	State                      string    `json:"state,omitempty"`
+	Status                     string    `json:"status,omitempty"`
+	Storage                    string    `json:"storage,omitempty"`
+	Storageid                  string    `json:"storageid,omitempty"`
+	Storagetype                string    `json:"storagetype,omitempty"`
+	Tags                       []Tag     `json:"tags,omitempty"`
+	Templatedisplaytext        string    `json:"templatedisplaytext,omitempty"`
+	Templateid                 string    `json:"templateid,omitempty"`
+	Templatename               string    `json:"templatename,omitempty"`
+	Type                       string    `json:"type,omitempty"`
+	Virtualmachineid           string    `json:"virtualmachineid,omitempty"`
+	Vmdisplayname              string    `json:"vmdisplayname,omitempty"`
+	Vmname                     string    `json:"vmname,omitempty"`
+	Vmstate                    string    `json:"vmstate,omitempty"`
+	Zoneid                     string    `json:"zoneid,omitempty"`
+	Zonename                   string    `json:"zonename,omitempty"`
 }
 
 type DetachVolumeParams struct {
@@ -173,6 +183,22 @@ func (p *DetachVolumeParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DetachVolumeParams) APIName() string {
+	return "detachVolume"
+}
+
+func (p *DetachVolumeParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DetachVolumeParams) IsAsync() bool {
+	return true
+}
+
+func (p *DetachVolumeParams) Response() interface{} {
+	return &DetachVolumeResponse{}
+}
+
 func (p *DetachVolumeParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -191,92 +217,86 @@ func (s *VolumeService) NewDetachVolumeParams() *DetachVolumeParams {
 
 // Detaches a disk volume from a virtual machine.
 func (s *VolumeService) DetachVolume(p *DetachVolumeParams) (*DetachVolumeResponse, error) {
-	resp, err := s.cs.newRequest("detachVolume", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DetachVolumeWithContext(context.Background(), p)
+}
 
+// DetachVolumeWithContext is like DetachVolume, but the request is bound to ctx.
+func (s *VolumeService) DetachVolumeWithContext(ctx context.Context, p *DetachVolumeParams) (*DetachVolumeResponse, error) {
 	var r DetachVolumeResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// DetachVolumeJob is like DetachVolumeWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VolumeService) DetachVolumeJob(ctx context.Context, p *DetachVolumeParams) (*Job[*DetachVolumeResponse], error) {
+	var r DetachVolumeResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type DetachVolumeResponse struct {
-	JobID                      string `json:"jobid,omitempty"`
-	Account                    string `json:"account,omitempty"`
-	Attached                   string `json:"attached,omitempty"`
-	Chaininfo                  string `json:"chaininfo,omitempty"`
-	Created                    string `json:"created,omitempty"`
-	Destroyed                  bool   `json:"destroyed,omitempty"`
-	Deviceid                   int64  `json:"deviceid,omitempty"`
-	DiskBytesReadRate          int64  `json:"diskBytesReadRate,omitempty"`
-	DiskBytesWriteRate         int64  `json:"diskBytesWriteRate,omitempty"`
-	DiskIopsReadRate           int64  `json:"diskIopsReadRate,omitempty"`
-	DiskIopsWriteRate          int64  `json:"diskIopsWriteRate,omitempty"`
-	Diskofferingdisplaytext    string `json:"diskofferingdisplaytext,omitempty"`
-	Diskofferingid             string `json:"diskofferingid,omitempty"`
-	Diskofferingname           string `json:"diskofferingname,omitempty"`
-	Displayvolume              bool   `json:"displayvolume,omitempty"`
-	Domain                     string `json:"domain,omitempty"`
-	Domainid                   string `json:"domainid,omitempty"`
-	Hypervisor                 string `json:"hypervisor,omitempty"`
-	Id                         string `json:"id,omitempty"`
-	Isextractable              bool   `json:"isextractable,omitempty"`
-	Isodisplaytext             string `json:"isodisplaytext,omitempty"`
-	Isoid                      string `json:"isoid,omitempty"`
-	Isoname                    string `json:"isoname,omitempty"`
-	Maxiops                    int64  `json:"maxiops,omitempty"`
-	Miniops                    int64  `json:"miniops,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	Path                       string `json:"path,omitempty"`
-	Project                    string `json:"project,omitempty"`
-	Projectid                  string `json:"projectid,omitempty"`
-	Provisioningtype           string `json:"provisioningtype,omitempty"`
-	Quiescevm                  bool   `json:"quiescevm,omitempty"`
-	Serviceofferingdisplaytext string `json:"serviceofferingdisplaytext,omitempty"`
-	Serviceofferingid          string `json:"serviceofferingid,omitempty"`
-	Serviceofferingname        string `json:"serviceofferingname,omitempty"`
-	Size                       int64  `json:"size,omitempty"`
-	Snapshotid                 string `json:"snapshotid,omitempty"`
-	State                      string `json:"state,omitempty"`
-	Status                     string `json:"status,omitempty"`
-	Storage                    string `json:"storage,omitempty"`
-	Storageid                  string `json:"storageid,omitempty"`
-	Storagetype                string `json:"storagetype,omitempty"`
-	Tags                       []Tag  `json:"tags,omitempty"`
-	Templatedisplaytext        string `json:"templatedisplaytext,omitempty"`
-	Templateid                 string `json:"templateid,omitempty"`
-	Templatename               string `json:"templatename,omitempty"`
-	Type                       string `json:"type,omitempty"`
-	Virtualmachineid           string `json:"virtualmachineid,omitempty"`
-	Vmdisplayname              string `json:"vmdisplayname,omitempty"`
-	Vmname                     string `json:"vmname,omitempty"`
-	Vmstate                    string `json:"vmstate,omitempty"`
-	Zoneid                     string `json:"zoneid,omitempty"`
-	Zonename                   string `json:"zonename,omitempty"`
+	JobID                      string    `json:"jobid,omitempty"`
+	Account                    string    `json:"account,omitempty"`
+	Attached                   Timestamp `json:"attached,omitempty"`
+	Chaininfo                  string    `json:"chaininfo,omitempty"`
+	Created                    Timestamp `json:"created,omitempty"`
+	Destroyed                  bool      `json:"destroyed,omitempty"`
+	Deviceid                   int64     `json:"deviceid,omitempty"`
+	DiskBytesReadRate          int64     `json:"diskBytesReadRate,omitempty"`
+	DiskBytesWriteRate         int64     `json:"diskBytesWriteRate,omitempty"`
+	DiskIopsReadRate           int64     `json:"diskIopsReadRate,omitempty"`
+	DiskIopsWriteRate          int64     `json:"diskIopsWriteRate,omitempty"`
+	Diskofferingdisplaytext    string    `json:"diskofferingdisplaytext,omitempty"`
+	Diskofferingid             string    `json:"diskofferingid,omitempty"`
+	Diskofferingname           string    `json:"diskofferingname,omitempty"`
+	Displayvolume              bool      `json:"displayvolume,omitempty"`
+	Domain                     string    `json:"domain,omitempty"`
+	Domainid                   string    `json:"domainid,omitempty"`
+	Hypervisor                 string    `json:"hypervisor,omitempty"`
+	Id                         string    `json:"id,omitempty"`
+	Isextractable              bool      `json:"isextractable,omitempty"`
+	Isodisplaytext             string    `json:"isodisplaytext,omitempty"`
+	Isoid                      string    `json:"isoid,omitempty"`
+	Isoname                    string    `json:"isoname,omitempty"`
+	Maxiops                    int64     `json:"maxiops,omitempty"`
+	Miniops                    int64     `json:"miniops,omitempty"`
+	Name                       string    `json:"name,omitempty"`
+	Path                       string    `json:"path,omitempty"`
+	Project                    string    `json:"project,omitempty"`
+	Projectid                  string    `json:"projectid,omitempty"`
+	Provisioningtype           string    `json:"provisioningtype,omitempty"`
+	Quiescevm                  bool      `json:"quiescevm,omitempty"`
+	Serviceofferingdisplaytext string    `json:"serviceofferingdisplaytext,omitempty"`
+	Serviceofferingid          string    `json:"serviceofferingid,omitempty"`
+	Serviceofferingname        string    `json:"serviceofferingname,omitempty"`
+	Size                       int64     `json:"size,omitempty"`
+	Snapshotid                 string    `json:"snapshotid,omitempty"`
+	State                      string    `json:"state,omitempty"`
+	Status                     string    `json:"status,omitempty"`
+	Storage                    string    `json:"storage,omitempty"`
+	Storageid                  string    `json:"storageid,omitempty"`
+	Storagetype                string    `json:"storagetype,omitempty"`
+	Tags                       []Tag     `json:"tags,omitempty"`
+	Templatedisplaytext        string    `json:"templatedisplaytext,omitempty"`
+	Templateid                 string    `json:"templateid,omitempty"`
+	Templatename               string    `json:"templatename,omitempty"`
+	Type                       string    `json:"type,omitempty"`
+	Virtualmachineid           string    `json:"virtualmachineid,omitempty"`
+	Vmdisplayname              string    `json:"vmdisplayname,omitempty"`
+	Vmname                     string    `json:"vmname,omitempty"`
+	Vmstate                    string    `json:"vmstate,omitempty"`
+	Zoneid                     string    `json:"zoneid,omitempty"`
+	Zonename                   string    `json:"zonename,omitempty"`
 }
 
 type CreateVolumeParams struct {
@@ -307,6 +327,22 @@ func (p *CreateVolumeParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *CreateVolumeParams) APIName() string {
+	return "createVolume"
+}
+
+func (p *CreateVolumeParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *CreateVolumeParams) IsAsync() bool {
+	return true
+}
+
+func (p *CreateVolumeParams) Response() interface{} {
+	return &CreateVolumeResponse{}
+}
+
 func (p *CreateVolumeParams) SetDiskofferingid(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -357,92 +393,86 @@ func (s *VolumeService) NewCreateVolumeParams() *CreateVolumeParams {
 
 // Creates a disk volume from a disk offering. This disk volume must still be attached to a virtual machine to make use of it.
 func (s *VolumeService) CreateVolume(p *CreateVolumeParams) (*CreateVolumeResponse, error) {
-	resp, err := s.cs.newRequest("createVolume", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.CreateVolumeWithContext(context.Background(), p)
+}
 
+// CreateVolumeWithContext is like CreateVolume, but the request is bound to ctx.
+func (s *VolumeService) CreateVolumeWithContext(ctx context.Context, p *CreateVolumeParams) (*CreateVolumeResponse, error) {
 	var r CreateVolumeResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// CreateVolumeJob is like CreateVolumeWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VolumeService) CreateVolumeJob(ctx context.Context, p *CreateVolumeParams) (*Job[*CreateVolumeResponse], error) {
+	var r CreateVolumeResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type CreateVolumeResponse struct {
-	JobID                      string `json:"jobid,omitempty"`
-	Account                    string `json:"account,omitempty"`
-	Attached                   string `json:"attached,omitempty"`
-	Chaininfo                  string `json:"chaininfo,omitempty"`
-	Created                    string `json:"created,omitempty"`
-	Destroyed                  bool   `json:"destroyed,omitempty"`
-	Deviceid                   int64  `json:"deviceid,omitempty"`
-	DiskBytesReadRate          int64  `json:"diskBytesReadRate,omitempty"`
-	DiskBytesWriteRate         int64  `json:"diskBytesWriteRate,omitempty"`
-	DiskIopsReadRate           int64  `json:"diskIopsReadRate,omitempty"`
-	DiskIopsWriteRate          int64  `json:"diskIopsWriteRate,omitempty"`
-	Diskofferingdisplaytext    string `json:"diskofferingdisplaytext,omitempty"`
-	Diskofferingid             string `json:"diskofferingid,omitempty"`
-	Diskofferingname           string `json:"diskofferingname,omitempty"`
-	Displayvolume              bool   `json:"displayvolume,omitempty"`
-	Domain                     string `json:"domain,omitempty"`
-	Domainid                   string `json:"domainid,omitempty"`
-	Hypervisor                 string `json:"hypervisor,omitempty"`
-	Id                         string `json:"id,omitempty"`
-	Isextractable              bool   `json:"isextractable,omitempty"`
-	Isodisplaytext             string `json:"isodisplaytext,omitempty"`
-	Isoid                      string `json:"isoid,omitempty"`
-	Isoname                    string `json:"isoname,omitempty"`
-	Maxiops                    int64  `json:"maxiops,omitempty"`
-	Miniops                    int64  `json:"miniops,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	Path                       string `json:"path,omitempty"`
-	Project                    string `json:"project,omitempty"`
-	Projectid                  string `json:"projectid,omitempty"`
-	Provisioningtype           string `json:"provisioningtype,omitempty"`
-	Quiescevm                  bool   `json:"quiescevm,omitempty"`
-	Serviceofferingdisplaytext string `json:"serviceofferingdisplaytext,omitempty"`
-	Serviceofferingid          string `json:"serviceofferingid,omitempty"`
-	Serviceofferingname        string `json:"serviceofferingname,omitempty"`
-	Size                       int64  `json:"size,omitempty"`
-	Snapshotid                 string `json:"snapshotid,omitempty"`
-	State                      string `json:"state,omitempty"`
-	Status                     string `json:"status,omitempty"`
-	Storage                    string `json:"storage,omitempty"`
-	Storageid                  string `json:"storageid,omitempty"`
-	Storagetype                string `json:"storagetype,omitempty"`
-	Tags                       []Tag  `json:"tags,omitempty"`
-	Templatedisplaytext        string `json:"templatedisplaytext,omitempty"`
-	Templateid                 string `json:"templateid,omitempty"`
-	Templatename               string `json:"templatename,omitempty"`
-	Type                       string `json:"type,omitempty"`
-	Virtualmachineid           string `json:"virtualmachineid,omitempty"`
-	Vmdisplayname              string `json:"vmdisplayname,omitempty"`
-	Vmname                     string `json:"vmname,omitempty"`
-	Vmstate                    string `json:"vmstate,omitempty"`
-	Zoneid                     string `json:"zoneid,omitempty"`
-	Zonename                   string `json:"zonename,omitempty"`
+	JobID                      string    `json:"jobid,omitempty"`
+	Account                    string    `json:"account,omitempty"`
+	Attached                   Timestamp `json:"attached,omitempty"`
+	Chaininfo                  string    `json:"chaininfo,omitempty"`
+	Created                    Timestamp `json:"created,omitempty"`
+	Destroyed                  bool      `json:"destroyed,omitempty"`
+	Deviceid                   int64     `json:"deviceid,omitempty"`
+	DiskBytesReadRate          int64     `json:"diskBytesReadRate,omitempty"`
+	DiskBytesWriteRate         int64     `json:"diskBytesWriteRate,omitempty"`
+	DiskIopsReadRate           int64     `json:"diskIopsReadRate,omitempty"`
+	DiskIopsWriteRate          int64     `json:"diskIopsWriteRate,omitempty"`
+	Diskofferingdisplaytext    string    `json:"diskofferingdisplaytext,omitempty"`
+	Diskofferingid             string    `json:"diskofferingid,omitempty"`
+	Diskofferingname           string    `json:"diskofferingname,omitempty"`
+	Displayvolume              bool      `json:"displayvolume,omitempty"`
+	Domain                     string    `json:"domain,omitempty"`
+	Domainid                   string    `json:"domainid,omitempty"`
+	Hypervisor                 string    `json:"hypervisor,omitempty"`
+	Id                         string    `json:"id,omitempty"`
+	Isextractable              bool      `json:"isextractable,omitempty"`
+	Isodisplaytext             string    `json:"isodisplaytext,omitempty"`
+	Isoid                      string    `json:"isoid,omitempty"`
+	Isoname                    string    `json:"isoname,omitempty"`
+	Maxiops                    int64     `json:"maxiops,omitempty"`
+	Miniops                    int64     `json:"miniops,omitempty"`
+	Name                       string    `json:"name,omitempty"`
+	Path                       string    `json:"path,omitempty"`
+	Project                    string    `json:"project,omitempty"`
+	Projectid                  string    `json:"projectid,omitempty"`
+	Provisioningtype           string    `json:"provisioningtype,omitempty"`
+	Quiescevm                  bool      `json:"quiescevm,omitempty"`
+	Serviceofferingdisplaytext string    `json:"serviceofferingdisplaytext,omitempty"`
+	Serviceofferingid          string    `json:"serviceofferingid,omitempty"`
+	Serviceofferingname        string    `json:"serviceofferingname,omitempty"`
+	Size                       int64     `json:"size,omitempty"`
+	Snapshotid                 string    `json:"snapshotid,omitempty"`
+	State                      string    `json:"state,omitempty"`
+	Status                     string    `json:"status,omitempty"`
+	Storage                    string    `json:"storage,omitempty"`
+	Storageid                  string    `json:"storageid,omitempty"`
+	Storagetype                string    `json:"storagetype,omitempty"`
+	Tags                       []Tag     `json:"tags,omitempty"`
+	Templatedisplaytext        string    `json:"templatedisplaytext,omitempty"`
+	Templateid                 string    `json:"templateid,omitempty"`
+	Templatename               string    `json:"templatename,omitempty"`
+	Type                       string    `json:"type,omitempty"`
+	Virtualmachineid           string    `json:"virtualmachineid,omitempty"`
+	Vmdisplayname              string    `json:"vmdisplayname,omitempty"`
+	Vmname                     string    `json:"vmname,omitempty"`
+	Vmstate                    string    `json:"vmstate,omitempty"`
+	Zoneid                     string    `json:"zoneid,omitempty"`
+	Zonename                   string    `json:"zonename,omitempty"`
 }
 
 type DeleteVolumeParams struct {
@@ -460,6 +490,22 @@ func (p *DeleteVolumeParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *DeleteVolumeParams) APIName() string {
+	return "deleteVolume"
+}
+
+func (p *DeleteVolumeParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *DeleteVolumeParams) IsAsync() bool {
+	return false
+}
+
+func (p *DeleteVolumeParams) Response() interface{} {
+	return &DeleteVolumeResponse{}
+}
+
 func (p *DeleteVolumeParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -479,13 +525,13 @@ func (s *VolumeService) NewDeleteVolumeParams(id string) *DeleteVolumeParams {
 
 // Deletes a detached disk volume.
 func (s *VolumeService) DeleteVolume(p *DeleteVolumeParams) (*DeleteVolumeResponse, error) {
-	resp, err := s.cs.newRequest("deleteVolume", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.DeleteVolumeWithContext(context.Background(), p)
+}
 
+// DeleteVolumeWithContext is like DeleteVolume, but the request is bound to ctx.
+func (s *VolumeService) DeleteVolumeWithContext(ctx context.Context, p *DeleteVolumeParams) (*DeleteVolumeResponse, error) {
 	var r DeleteVolumeResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
@@ -493,8 +539,8 @@ func (s *VolumeService) DeleteVolume(p *DeleteVolumeParams) (*DeleteVolumeRespon
 }
 
 type DeleteVolumeResponse struct {
-	Displaytext string `json:"displaytext,omitempty"`
-	Success     string `json:"success,omitempty"`
+	Displaytext string   `json:"displaytext,omitempty"`
+	Success     FlexBool `json:"success,omitempty"`
 }
 
 type ListVolumesParams struct {
@@ -515,6 +561,14 @@ func (p *ListVolumesParams) toURLValues() url.Values {
 	if v, found := p.p["name"]; found {
 		u.Set("name", v.(string))
 	}
+	if v, found := p.p["page"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("page", vv)
+	}
+	if v, found := p.p["pagesize"]; found {
+		vv := strconv.Itoa(v.(int))
+		u.Set("pagesize", vv)
+	}
 	if v, found := p.p["tags"]; found {
 		i := 0
 		for k, vv := range v.(map[string]string) {
@@ -535,6 +589,22 @@ func (p *ListVolumesParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ListVolumesParams) APIName() string {
+	return "listVolumes"
+}
+
+func (p *ListVolumesParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ListVolumesParams) IsAsync() bool {
+	return false
+}
+
+func (p *ListVolumesParams) Response() interface{} {
+	return &ListVolumesResponse{}
+}
+
 func (p *ListVolumesParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -558,6 +628,22 @@ func (p *ListVolumesParams) SetName(v string) {
 	return
 }
 
+func (p *ListVolumesParams) SetPage(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["page"] = v
+	return
+}
+
+func (p *ListVolumesParams) SetPagesize(v int) {
+	if p.p == nil {
+		p.p = make(map[string]interface{})
+	}
+	p.p["pagesize"] = v
+	return
+}
+
 func (p *ListVolumesParams) SetTags(v map[string]string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -598,8 +684,28 @@ func (s *VolumeService) NewListVolumesParams() *ListVolumesParams {
 	return p
 }
 
-// This is a courtesy helper function, which in some cases may not work as expected!
+// GetVolumeID returns the ID of the volume with the given name. The error is a
+// *NotFoundError if there is no such volume and an *AmbiguousError if there are several.
 func (s *VolumeService) GetVolumeID(name string, opts ...OptionFunc) (string, int, error) {
+	return s.GetVolumeIDWithContext(context.Background(), name, opts...)
+}
+
+// GetVolumeIDWithContext is like GetVolumeID, but the requests are bound to ctx.
+func (s *VolumeService) GetVolumeIDWithContext(ctx context.Context, name string, opts ...OptionFunc) (string, int, error) {
+	r, count, err := s.GetVolumeByNameWithContext(ctx, name, opts...)
+	if err != nil {
+		return "", count, err
+	}
+	return r.Id, count, nil
+}
+
+// GetVolumeByName returns the volume with the given name, like GetVolumeID.
+func (s *VolumeService) GetVolumeByName(name string, opts ...OptionFunc) (*Volume, int, error) {
+	return s.GetVolumeByNameWithContext(context.Background(), name, opts...)
+}
+
+// GetVolumeByNameWithContext is like GetVolumeByName, but the requests are bound to ctx.
+func (s *VolumeService) GetVolumeByNameWithContext(ctx context.Context, name string, opts ...OptionFunc) (*Volume, int, error) {
 	p := &ListVolumesParams{}
 	p.p = make(map[string]interface{})
 
@@ -607,49 +713,25 @@ func (s *VolumeService) GetVolumeID(name string, opts ...OptionFunc) (string, in
 
 	for _, fn := range opts {
 		if err := fn(s.cs, p); err != nil {
-			return "", -1, err
+			return nil, -1, err
 		}
 	}
 
-	l, err := s.ListVolumes(p)
+	l, err := s.ListVolumesAll(ctx, p)
 	if err != nil {
-		return "", -1, err
-	}
-
-	if l.Count == 0 {
-		return "", l.Count, fmt.Errorf("No match found for %s: %+v", name, l)
-	}
-
-	if l.Count == 1 {
-		return l.Volumes[0].Id, l.Count, nil
-	}
-
-	if l.Count > 1 {
-		for _, v := range l.Volumes {
-			if v.Name == name {
-				return v.Id, l.Count, nil
-			}
-		}
+		return nil, -1, err
 	}
-	return "", l.Count, fmt.Errorf("Could not find an exact match for %s: %+v", name, l)
+	return pickByName("Volume", name, l, func(v *Volume) string { return v.Name }, func(v *Volume) string { return v.Id })
 }
 
-// This is a courtesy helper function, which in some cases may not work as expected!
-func (s *VolumeService) GetVolumeByName(name string, opts ...OptionFunc) (*Volume, int, error) {
-	id, count, err := s.GetVolumeID(name, opts...)
-	if err != nil {
-		return nil, count, err
-	}
-
-	r, count, err := s.GetVolumeByID(id, opts...)
-	if err != nil {
-		return nil, count, err
-	}
-	return r, count, nil
+// GetVolumeByID returns the volume with the given ID. The error is a *NotFoundError if there
+// is no such volume.
+func (s *VolumeService) GetVolumeByID(id string, opts ...OptionFunc) (*Volume, int, error) {
+	return s.GetVolumeByIDWithContext(context.Background(), id, opts...)
 }
 
-// This is a courtesy helper function, which in some cases may not work as expected!
-func (s *VolumeService) GetVolumeByID(id string, opts ...OptionFunc) (*Volume, int, error) {
+// GetVolumeByIDWithContext is like GetVolumeByID, but the requests are bound to ctx.
+func (s *VolumeService) GetVolumeByIDWithContext(ctx context.Context, id string, opts ...OptionFunc) (*Volume, int, error) {
 	p := &ListVolumesParams{}
 	p.p = make(map[string]interface{})
 
@@ -661,98 +743,128 @@ func (s *VolumeService) GetVolumeByID(id string, opts ...OptionFunc) (*Volume, i
 		}
 	}
 
-	l, err := s.ListVolumes(p)
+	l, err := s.ListVolumesAll(ctx, p)
 	if err != nil {
-		if strings.Contains(err.Error(), fmt.Sprintf(
-			"Invalid parameter id value=%s due to incorrect long value format, "+
-				"or entity does not exist", id)) {
-			return nil, 0, fmt.Errorf("No match found for %s: %+v", id, l)
+		if IsNotFound(err) {
+			return nil, 0, &NotFoundError{Kind: "Volume", Key: id}
 		}
 		return nil, -1, err
 	}
+	return pickByID("Volume", id, l, func(v *Volume) string { return v.Id })
+}
 
-	if l.Count == 0 {
-		return nil, l.Count, fmt.Errorf("No match found for %s: %+v", id, l)
-	}
-
-	if l.Count == 1 {
-		return l.Volumes[0], l.Count, nil
+// WaitUntilVolumeReady waits until the volume with the given ID is Ready and returns it. The
+// wait is spaced and limited like KCPSClient.WaitFor, using w or the JobWaiter of the client
+// if w is nil.
+func (s *VolumeService) WaitUntilVolumeReady(ctx context.Context, id string, w *JobWaiter) (*Volume, error) {
+	var v *Volume
+	_, err := s.cs.waitFor(ctx, w, "Volume", id, func(ctx context.Context) (string, bool, error) {
+		var err error
+		if v, _, err = s.GetVolumeByIDWithContext(ctx, id); err != nil {
+			return "", false, err
+		}
+		switch v.State {
+		case "Ready":
+			return v.State, true, nil
+		case "Destroy", "Expunging", "Expunged", "UploadError":
+			return v.State, false, &UnexpectedStateError{Kind: "Volume", ID: id, State: v.State}
+		}
+		return v.State, false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, l.Count, fmt.Errorf("There is more then one result for Volume UUID: %s!", id)
+	return v, nil
 }
 
 // Lists all volumes.
 func (s *VolumeService) ListVolumes(p *ListVolumesParams) (*ListVolumesResponse, error) {
-	resp, err := s.cs.newRequest("listVolumes", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ListVolumesWithContext(context.Background(), p)
+}
 
+// ListVolumesWithContext is like ListVolumes, but the request is bound to ctx.
+func (s *VolumeService) ListVolumesWithContext(ctx context.Context, p *ListVolumesParams) (*ListVolumesResponse, error) {
 	var r ListVolumesResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
 		return nil, err
 	}
 
 	return &r, nil
 }
 
+// ListVolumesPager returns a Pager over the items of all pages of ListVolumes.
+func (s *VolumeService) ListVolumesPager(p *ListVolumesParams) *Pager[*Volume] {
+	return newPager(p.p, func(ctx context.Context, params map[string]interface{}) ([]*Volume, int, error) {
+		r, err := s.ListVolumesWithContext(ctx, &ListVolumesParams{p: params})
+		if err != nil {
+			return nil, 0, err
+		}
+		return r.Volumes, r.Count, nil
+	})
+}
+
+// ListVolumesAll is like ListVolumesWithContext, but requests all pages and returns the items of all of them.
+func (s *VolumeService) ListVolumesAll(ctx context.Context, p *ListVolumesParams) ([]*Volume, error) {
+	return s.ListVolumesPager(p).All(ctx)
+}
+
 type ListVolumesResponse struct {
 	Count   int       `json:"count"`
 	Volumes []*Volume `json:"volume"`
 }
 
 type Volume struct {
-	Account                    string `json:"account,omitempty"`
-	Attached                   string `json:"attached,omitempty"`
-	Chaininfo                  string `json:"chaininfo,omitempty"`
-	Created                    string `json:"created,omitempty"`
-	Destroyed                  bool   `json:"destroyed,omitempty"`
-	Deviceid                   int64  `json:"deviceid,omitempty"`
-	DiskBytesReadRate          int64  `json:"diskBytesReadRate,omitempty"`
-	DiskBytesWriteRate         int64  `json:"diskBytesWriteRate,omitempty"`
-	DiskIopsReadRate           int64  `json:"diskIopsReadRate,omitempty"`
-	DiskIopsWriteRate          int64  `json:"diskIopsWriteRate,omitempty"`
-	Diskofferingdisplaytext    string `json:"diskofferingdisplaytext,omitempty"`
-	Diskofferingid             string `json:"diskofferingid,omitempty"`
-	Diskofferingname           string `json:"diskofferingname,omitempty"`
-	Displayvolume              bool   `json:"displayvolume,omitempty"`
-	Domain                     string `json:"domain,omitempty"`
-	Domainid                   string `json:"domainid,omitempty"`
-	Hypervisor                 string `json:"hypervisor,omitempty"`
-	Id                         string `json:"id,omitempty"`
-	Isextractable              bool   `json:"isextractable,omitempty"`
-	Isodisplaytext             string `json:"isodisplaytext,omitempty"`
-	Isoid                      string `json:"isoid,omitempty"`
-	Isoname                    string `json:"isoname,omitempty"`
-	Maxiops                    int64  `json:"maxiops,omitempty"`
-	Miniops                    int64  `json:"miniops,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	Path                       string `json:"path,omitempty"`
-	Project                    string `json:"project,omitempty"`
-	Projectid                  string `json:"projectid,omitempty"`
-	Provisioningtype           string `json:"provisioningtype,omitempty"`
-	Quiescevm                  bool   `json:"quiescevm,omitempty"`
-	Serviceofferingdisplaytext string `json:"serviceofferingdisplaytext,omitempty"`
-	Serviceofferingid          string `json:"serviceofferingid,omitempty"`
-	Serviceofferingname        string `json:"serviceofferingname,omitempty"`
-	Size                       int64  `json:"size,omitempty"`
-	Snapshotid                 string `json:"snapshotid,omitempty"`
-	State                      string `json:"state,omitempty"`
-	Status                     string `json:"status,omitempty"`
-	Storage                    string `json:"storage,omitempty"`
-	Storageid                  string `json:"storageid,omitempty"`
-	Storagetype                string `json:"storagetype,omitempty"`
-	Tags                       []Tag  `json:"tags,omitempty"`
-	Templatedisplaytext        string `json:"templatedisplaytext,omitempty"`
-	Templateid                 string `json:"templateid,omitempty"`
-	Templatename               string `json:"templatename,omitempty"`
-	Type                       string `json:"type,omitempty"`
-	Virtualmachineid           string `json:"virtualmachineid,omitempty"`
-	Vmdisplayname              string `json:"vmdisplayname,omitempty"`
-	Vmname                     string `json:"vmname,omitempty"`
-	Vmstate                    string `json:"vmstate,omitempty"`
-	Zoneid                     string `json:"zoneid,omitempty"`
-	Zonename                   string `json:"zonename,omitempty"`
+	Account                    string    `json:"account,omitempty"`
+	Attached                   Timestamp `json:"attached,omitempty"`
+	Chaininfo                  string    `json:"chaininfo,omitempty"`
+	Created                    Timestamp `json:"created,omitempty"`
+	Destroyed                  bool      `json:"destroyed,omitempty"`
+	Deviceid                   int64     `json:"deviceid,omitempty"`
+	DiskBytesReadRate          int64     `json:"diskBytesReadRate,omitempty"`
+	DiskBytesWriteRate         int64     `json:"diskBytesWriteRate,omitempty"`
+	DiskIopsReadRate           int64     `json:"diskIopsReadRate,omitempty"`
+	DiskIopsWriteRate          int64     `json:"diskIopsWriteRate,omitempty"`
+	Diskofferingdisplaytext    string    `json:"diskofferingdisplaytext,omitempty"`
+	Diskofferingid             string    `json:"diskofferingid,omitempty"`
+	Diskofferingname           string    `json:"diskofferingname,omitempty"`
+	Displayvolume              bool      `json:"displayvolume,omitempty"`
+	Domain                     string    `json:"domain,omitempty"`
+	Domainid                   string    `json:"domainid,omitempty"`
+	Hypervisor                 string    `json:"hypervisor,omitempty"`
+	Id                         string    `json:"id,omitempty"`
+	Isextractable              bool      `json:"isextractable,omitempty"`
+	Isodisplaytext             string    `json:"isodisplaytext,omitempty"`
+	Isoid                      string    `json:"isoid,omitempty"`
+	Isoname                    string    `json:"isoname,omitempty"`
+	Maxiops                    int64     `json:"maxiops,omitempty"`
+	Miniops                    int64     `json:"miniops,omitempty"`
+	Name                       string    `json:"name,omitempty"`
+	Path                       string    `json:"path,omitempty"`
+	Project                    string    `json:"project,omitempty"`
+	Projectid                  string    `json:"projectid,omitempty"`
+	Provisioningtype           string    `json:"provisioningtype,omitempty"`
+	Quiescevm                  bool      `json:"quiescevm,omitempty"`
+	Serviceofferingdisplaytext string    `json:"serviceofferingdisplaytext,omitempty"`
+	Serviceofferingid          string    `json:"serviceofferingid,omitempty"`
+	Serviceofferingname        string    `json:"serviceofferingname,omitempty"`
+	Size                       int64     `json:"size,omitempty"`
+	Snapshotid                 string    `json:"snapshotid,omitempty"`
+	State                      string    `json:"state,omitempty"`
+	Status                     string    `json:"status,omitempty"`
+	Storage                    string    `json:"storage,omitempty"`
+	Storageid                  string    `json:"storageid,omitempty"`
+	Storagetype                string    `json:"storagetype,omitempty"`
+	Tags                       []Tag     `json:"tags,omitempty"`
+	Templatedisplaytext        string    `json:"templatedisplaytext,omitempty"`
+	Templateid                 string    `json:"templateid,omitempty"`
+	Templatename               string    `json:"templatename,omitempty"`
+	Type                       string    `json:"type,omitempty"`
+	Virtualmachineid           string    `json:"virtualmachineid,omitempty"`
+	Vmdisplayname              string    `json:"vmdisplayname,omitempty"`
+	Vmname                     string    `json:"vmname,omitempty"`
+	Vmstate                    string    `json:"vmstate,omitempty"`
+	Zoneid                     string    `json:"zoneid,omitempty"`
+	Zonename                   string    `json:"zonename,omitempty"`
 }
 
 type ResizeVolumeParams struct {
@@ -774,6 +886,22 @@ func (p *ResizeVolumeParams) toURLValues() url.Values {
 	return u
 }
 
+func (p *ResizeVolumeParams) APIName() string {
+	return "resizeVolume"
+}
+
+func (p *ResizeVolumeParams) Values() url.Values {
+	return p.toURLValues()
+}
+
+func (p *ResizeVolumeParams) IsAsync() bool {
+	return true
+}
+
+func (p *ResizeVolumeParams) Response() interface{} {
+	return &ResizeVolumeResponse{}
+}
+
 func (p *ResizeVolumeParams) SetId(v string) {
 	if p.p == nil {
 		p.p = make(map[string]interface{})
@@ -802,90 +930,84 @@ func (s *VolumeService) NewResizeVolumeParams(id string, size int64) *ResizeVolu
 
 // Resizes a volume
 func (s *VolumeService) ResizeVolume(p *ResizeVolumeParams) (*ResizeVolumeResponse, error) {
-	resp, err := s.cs.newRequest("resizeVolume", p.toURLValues())
-	if err != nil {
-		return nil, err
-	}
+	return s.ResizeVolumeWithContext(context.Background(), p)
+}
 
+// ResizeVolumeWithContext is like ResizeVolume, but the request is bound to ctx.
+func (s *VolumeService) ResizeVolumeWithContext(ctx context.Context, p *ResizeVolumeParams) (*ResizeVolumeResponse, error) {
 	var r ResizeVolumeResponse
-	if err := json.Unmarshal(resp, &r); err != nil {
+	if err := s.cs.execute(ctx, p, &r); err != nil {
+		if errors.Is(err, AsyncTimeoutErr) {
+			return &r, err
+		}
 		return nil, err
 	}
 
-	// If we have a async client, we need to wait for the async result
-	if s.cs.async {
-		b, err := s.cs.GetAsyncJobResult(r.JobID, s.cs.timeout)
-		if err != nil {
-			if err == AsyncTimeoutErr {
-				return &r, err
-			}
-			return nil, err
-		}
-
-		b, err = getRawValue(b)
-		if err != nil {
-			return nil, err
-		}
+	return &r, nil
+}
 
-		if err := json.Unmarshal(b, &r); err != nil {
-			return nil, err
-		}
+// ResizeVolumeJob is like ResizeVolumeWithContext, but returns as soon as the async job is started,
+// even when using an async client. Use the returned Job to wait for the result.
+func (s *VolumeService) ResizeVolumeJob(ctx context.Context, p *ResizeVolumeParams) (*Job[*ResizeVolumeResponse], error) {
+	var r ResizeVolumeResponse
+	if err := s.cs.execute(withoutJobWait(ctx), p, &r); err != nil {
+		return nil, err
 	}
 
-	return &r, nil
+	return newJob(s.cs, r.JobID, false, &r, decodeJobResult), nil
 }
 
 type ResizeVolumeResponse struct {
-	JobID                      string `json:"jobid,omitempty"`
-	Account                    string `json:"account,omitempty"`
-	Attached                   string `json:"attached,omitempty"`
-	Chaininfo                  string `json:"chaininfo,omitempty"`
-	Created                    string `json:"created,omitempty"`
-	Destroyed                  bool   `json:"destroyed,omitempty"`
-	Deviceid                   int64  `json:"deviceid,omitempty"`
-	DiskBytesReadRate          int64  `json:"diskBytesReadRate,omitempty"`
-	DiskBytesWriteRate         int64  `json:"diskBytesWriteRate,omitempty"`
-	DiskIopsReadRate           int64  `json:"diskIopsReadRate,omitempty"`
-	DiskIopsWriteRate          int64  `json:"diskIopsWriteRate,omitempty"`
-	Diskofferingdisplaytext    string `json:"diskofferingdisplaytext,omitempty"`
-	Diskofferingid             string `json:"diskofferingid,omitempty"`
-	Diskofferingname           string `json:"diskofferingname,omitempty"`
-	Displayvolume              bool   `json:"displayvolume,omitempty"`
-	Domain                     string `json:"domain,omitempty"`
-	Domainid                   string `json:"domainid,omitempty"`
-	Hypervisor                 string `json:"hypervisor,omitempty"`
-	Id                         string `json:"id,omitempty"`
-	Isextractable              bool   `json:"isextractable,omitempty"`
-	Isodisplaytext             string `json:"isodisplaytext,omitempty"`
-	Isoid                      string `json:"isoid,omitempty"`
-	Isoname                    string `json:"isoname,omitempty"`
-	Maxiops                    int64  `json:"maxiops,omitempty"`
-	Miniops                    int64  `json:"miniops,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	Path                       string `json:"path,omitempty"`
-	Project                    string `json:"project,omitempty"`
-	Projectid                  string `json:"projectid,omitempty"`
-	Provisioningtype           string `json:"provisioningtype,omitempty"`
-	Quiescevm                  bool   `json:"quiescevm,omitempty"`
-	Serviceofferingdisplaytext string `json:"serviceofferingdisplaytext,omitempty"`
-	Serviceofferingid          string `json:"serviceofferingid,omitempty"`
-	Serviceofferingname        string `json:"serviceofferingname,omitempty"`
-	Size                       int64  `json:"size,omitempty"`
-	Snapshotid                 string `json:"snapshotid,omitempty"`
-	State                      string `json:"state,omitempty"`
-	Status                     string `json:"status,omitempty"`
-	Storage                    string `json:"storage,omitempty"`
-	Storageid                  string `json:"storageid,omitempty"`
-	Storagetype                string `json:"storagetype,omitempty"`
-	Tags                       []Tag  `json:"tags,omitempty"`
-	Templatedisplaytext        string `json:"templatedisplaytext,omitempty"`
-	Templateid                 string `json:"templateid,omitempty"`
-	Templatename               string `json:"templatename,omitempty"`
-	Type                       string `json:"type,omitempty"`
-	Virtualmachineid           string `json:"virtualmachineid,omitempty"`
-	Vmdisplayname              string `json:"vmdisplayname,omitempty"`
-	Vmname                     string `json:"vmname,omitempty"`
-	Vmstate                    string `json:"vmstate,omitempty"`
-	Zoneid                     string `json:"zoneid,omitempty"`
-	Zonename                   string `json:"zonename,omitempty"`
+	JobID                      string    `json:"jobid,omitempty"`
+	Account                    string    `json:"account,omitempty"`
+	Attached                   Timestamp `json:"attached,omitempty"`
+	Chaininfo                  string    `json:"chaininfo,omitempty"`
+	Created                    Timestamp `json:"created,omitempty"`
+	Destroyed                  bool      `json:"destroyed,omitempty"`
+	Deviceid                   int64     `json:"deviceid,omitempty"`
+	DiskBytesReadRate          int64     `json:"diskBytesReadRate,omitempty"`
+	DiskBytesWriteRate         int64     `json:"diskBytesWriteRate,omitempty"`
+	DiskIopsReadRate           int64     `json:"diskIopsReadRate,omitempty"`
+	DiskIopsWriteRate          int64     `json:"diskIopsWriteRate,omitempty"`
+	Diskofferingdisplaytext    string    `json:"diskofferingdisplaytext,omitempty"`
+	Diskofferingid             string    `json:"diskofferingid,omitempty"`
+	Diskofferingname           string    `json:"diskofferingname,omitempty"`
+	Displayvolume              bool      `json:"displayvolume,omitempty"`
+	Domain                     string    `json:"domain,omitempty"`
+	Domainid                   string    `json:"domainid,omitempty"`
+	Hypervisor                 string    `json:"hypervisor,omitempty"`
+	Id                         string    `json:"id,omitempty"`
+	Isextractable              bool      `json:"isextractable,omitempty"`
+	Isodisplaytext             string    `json:"isodisplaytext,omitempty"`
+	Isoid                      string    `json:"isoid,omitempty"`
+	Isoname                    string    `json:"isoname,omitempty"`
+	Maxiops                    int64     `json:"maxiops,omitempty"`
+	Miniops                    int64     `json:"miniops,omitempty"`
+	Name                       string    `json:"name,omitempty"`
+	Path                       string    `json:"path,omitempty"`
+	Project                    string    `json:"project,omitempty"`
+	Projectid                  string    `json:"projectid,omitempty"`
+	Provisioningtype           string    `json:"provisioningtype,omitempty"`
+	Quiescevm                  bool      `json:"quiescevm,omitempty"`
+	Serviceofferingdisplaytext string    `json:"serviceofferingdisplaytext,omitempty"`
+	Serviceofferingid          string    `json:"serviceofferingid,omitempty"`
+	Serviceofferingname        string    `json:"serviceofferingname,omitempty"`
+	Size                       int64     `json:"size,omitempty"`
+	Snapshotid                 string    `json:"snapshotid,omitempty"`
+	State                      string    `json:"state,omitempty"`
+	Status                     string    `json:"status,omitempty"`
+	Storage                    string    `json:"storage,omitempty"`
+	Storageid                  string    `json:"storageid,omitempty"`
+	Storagetype                string    `json:"storagetype,omitempty"`
+	Tags                       []Tag     `json:"tags,omitempty"`
+	Templatedisplaytext        string    `json:"templatedisplaytext,omitempty"`
+	Templateid                 string    `json:"templateid,omitempty"`
+	Templatename               string    `json:"templatename,omitempty"`
+	Type                       string    `json:"type,omitempty"`
+	Virtualmachineid           string    `json:"virtualmachineid,omitempty"`
+	Vmdisplayname              string    `json:"vmdisplayname,omitempty"`
+	Vmname                     string    `json:"vmname,omitempty"`
+	Vmstate                    string    `json:"vmstate,omitempty"`
+	Zoneid                     string    `json:"zoneid,omitempty"`
+	Zonename                   string    `json:"zonename,omitempty"`
 }
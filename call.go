@@ -0,0 +1,70 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Call executes any API command, including the ones not covered by the services, and decodes
+// the response into out, e.g. a pointer to a struct or a *json.RawMessage. The response is
+// unwrapped from its envelope, so {"listcapabilitiesresponse":{"capability":{...}}} decodes
+// the object with the capability field. out may be nil to ignore the response. The request is
+// signed, retried and its errors are typed like the requests of the services, and it goes
+// through the middleware of the client.
+//
+//	var r struct {
+//		Capability struct {
+//			Cloudstackversion string `json:"cloudstackversion"`
+//		} `json:"capability"`
+//	}
+//	err := cs.Call(ctx, "listCapabilities", nil, &r)
+func (cs *KCPSClient) Call(ctx context.Context, command string, params url.Values, out interface{}) error {
+	if out == nil {
+		out = new(json.RawMessage)
+	}
+	return cs.execute(ctx, &rawCommand{name: command, params: params}, out)
+}
+
+// CallAsync is like Call, for commands that start an async job. It waits for the job to finish
+// using the job tracker of the client, and decodes the unwrapped job result into out. If the
+// job failed, the error is an *AsyncJobError.
+func (cs *KCPSClient) CallAsync(ctx context.Context, command string, params url.Values, out interface{}) error {
+	var r struct {
+		JobID string `json:"jobid"`
+	}
+	if err := cs.execute(withoutJobWait(ctx), &rawCommand{name: command, params: params, async: true}, &r); err != nil {
+		return err
+	}
+	if r.JobID == "" {
+		return fmt.Errorf("Command %s did not start an async job", command)
+	}
+
+	if out == nil {
+		out = new(json.RawMessage)
+	}
+	decode := decodeJobResult
+	if plainJobResultCommands[strings.ToLower(command)] {
+		decode = decodePlainJobResult
+	}
+	_, err := newJob(cs, r.JobID, exAsyncCommands[strings.ToLower(command)], out, decode).Wait(ctx)
+	return err
+}
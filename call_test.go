@@ -0,0 +1,107 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCall(t *testing.T) {
+	srv, cs := newTestClient(t)
+	ctx := context.Background()
+
+	var r struct {
+		Count int `json:"count"`
+		Zone  []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"zone"`
+	}
+	if err := cs.Call(ctx, "listZones", url.Values{"name": {"jp2-east03"}}, &r); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if r.Count != 1 || len(r.Zone) != 1 || r.Zone[0].ID != srv.ZoneID {
+		t.Errorf("decoded %+v, want the zone %s", r, srv.ZoneID)
+	}
+
+	if err := cs.Call(ctx, "listZones", nil, nil); err != nil {
+		t.Errorf("Call without params and output: %v", err)
+	}
+
+	err := cs.Call(ctx, "listCapabilities", nil, nil)
+	var e *CSError
+	if !errors.As(err, &e) || e.ErrorCode != 432 || e.Command != "listCapabilities" {
+		t.Errorf("err = %v, want a *CSError for the unknown command", err)
+	}
+}
+
+func TestCallAsync(t *testing.T) {
+	srv, cs := newTestClient(t)
+	ctx := context.Background()
+
+	params := url.Values{
+		"name":           {"vol1"},
+		"zoneid":         {srv.ZoneID},
+		"diskofferingid": {srv.DiskOfferingID},
+		"size":           {"10"},
+	}
+	var vol struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := cs.CallAsync(ctx, "createVolume", params, &vol); err != nil {
+		t.Fatalf("CallAsync: %v", err)
+	}
+	if vol.ID == "" || vol.Name != "vol1" {
+		t.Errorf("decoded %+v, want the created volume", vol)
+	}
+
+	// The KDDI specific commands are polled with queryExAsyncJobResult
+	var vm json.RawMessage
+	deploy := url.Values{
+		"serviceofferingid": {srv.ServiceOfferingID},
+		"templateid":        {srv.TemplateID},
+		"zoneid":            {srv.ZoneID},
+		"name":              {"vm1"},
+	}
+	if err := cs.CallAsync(ctx, "deployValueVirtualMachine", deploy, &vm); err != nil {
+		t.Fatalf("CallAsync: %v", err)
+	}
+	if !strings.Contains(string(vm), `"vm1"`) {
+		t.Errorf("decoded %s, want the deployed virtual machine", vm)
+	}
+	if n := srv.Count("queryExAsyncJobResult"); n == 0 {
+		t.Error("the deploy job was not polled with queryExAsyncJobResult")
+	}
+
+	srv.FailNextJob("createVolume", "Insufficient capacity")
+	params.Set("name", "vol2")
+	err := cs.CallAsync(ctx, "createVolume", params, nil)
+	var je *AsyncJobError
+	if !errors.As(err, &je) || je.ErrorText != "Insufficient capacity" {
+		t.Errorf("err = %v, want an *AsyncJobError", err)
+	}
+
+	if err := cs.CallAsync(ctx, "listZones", nil, nil); err == nil || !strings.Contains(err.Error(), "async job") {
+		t.Errorf("err = %v, want an error for a command without a job", err)
+	}
+}
@@ -0,0 +1,186 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Command is a single API call. The params of all API calls of the services implement it, so
+// they can be passed to Execute and inspected by a Middleware.
+type Command interface {
+	APIName() string       // Name of the API command, e.g. "listVirtualMachines"
+	Values() url.Values    // The request parameters
+	IsAsync() bool         // Whether the command starts an async job
+	Response() interface{} // Returns a pointer to a new, empty value of the response type
+}
+
+// Handler executes a command and decodes its response into out, which is a pointer as returned
+// by Command.Response. For async commands executed by an async client, the response is the
+// result of the finished job.
+type Handler func(ctx context.Context, cmd Command, out interface{}) error
+
+// Middleware wraps the Handler executing the commands of a client, e.g. to audit, check,
+// cache or measure all API calls in one place. A Middleware can return without calling next,
+// e.g. to do a dry run. The first Middleware added is the outermost one.
+//
+//	func audit(next gokcps.Handler) gokcps.Handler {
+//		return func(ctx context.Context, cmd gokcps.Command, out interface{}) error {
+//			log.Printf("%s %v", cmd.APIName(), cmd.Values())
+//			return next(ctx, cmd, out)
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+// KDDI specific commands whose async jobs are polled with queryExAsyncJobResult, by lower case name
+var exAsyncCommands = map[string]bool{
+	"deployvaluevirtualmachine":   true,
+	"deploypremiumvirtualmachine": true,
+	"startvirtualmachine":         true,
+}
+
+// Async commands whose job result is not wrapped in an object, by lower case name
+var plainJobResultCommands = map[string]bool{
+	"deletefirewallrule":         true,
+	"disablestaticnat":           true,
+	"deleteiso":                  true,
+	"deleteloadbalancerrule":     true,
+	"removefromloadbalancerrule": true,
+	"assigntoloadbalancerrule":   true,
+	"deletelbstickinesspolicy":   true,
+	"deleteportforwardingrule":   true,
+	"removeipfromnic":            true,
+	"disassociateipaddress":      true,
+	"deletesnapshot":             true,
+	"deletevmsnapshot":           true,
+	"createtags":                 true,
+	"deletetags":                 true,
+	"deletetemplate":             true,
+	"scalevirtualmachine":        true,
+}
+
+// Use adds middleware to the chain every command of the client goes through. It must not be
+// called while the client is in use.
+func (cs *KCPSClient) Use(m ...Middleware) {
+	cs.middleware = append(cs.middleware, m...)
+}
+
+// Execute executes cmd through the middleware of the client and returns its response, which
+// is the value returned by cmd.Response. If waiting for an async job timed out, the response
+// holds the ID of the job along with the *AsyncTimeoutError.
+func (cs *KCPSClient) Execute(ctx context.Context, cmd Command) (interface{}, error) {
+	out := cmd.Response()
+	err := cs.execute(ctx, cmd, out)
+	return out, err
+}
+
+// Executes cmd through the middleware, decoding the response into out.
+func (cs *KCPSClient) execute(ctx context.Context, cmd Command, out interface{}) error {
+	h := Handler(cs.handle)
+	for i := len(cs.middleware) - 1; i >= 0; i-- {
+		h = cs.middleware[i](h)
+	}
+	return h(ctx, cmd, out)
+}
+
+type noJobWaitKey struct{}
+
+// Makes the commands executed with the returned context return as soon as their async job is
+// started, even when using an async client, for the XxxJob calls which wait on their own.
+func withoutJobWait(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noJobWaitKey{}, true)
+}
+
+// The innermost Handler: sends the request and waits for the async job of async commands when
+// using an async client.
+func (cs *KCPSClient) handle(ctx context.Context, cmd Command, out interface{}) error {
+	api := cmd.APIName()
+	params := cmd.Values()
+	if defaultNetworkCommands[strings.ToLower(api)] {
+		var err error
+		if params, err = cs.withDefaultNetwork(ctx, params); err != nil {
+			return err
+		}
+	}
+
+	if err := cs.request(ctx, api, params, out); err != nil {
+		return err
+	}
+	if !cmd.IsAsync() || !cs.async || ctx.Value(noJobWaitKey{}) != nil {
+		return nil
+	}
+
+	jobID := jobIDOf(out)
+	if jobID == "" {
+		return fmt.Errorf("Command %s did not start an async job", api)
+	}
+
+	var b json.RawMessage
+	var err error
+	if exAsyncCommands[strings.ToLower(api)] {
+		b, err = cs.waitForExAsyncJob(ctx, jobID)
+	} else {
+		b, err = cs.waitForAsyncJob(ctx, jobID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !plainJobResultCommands[strings.ToLower(api)] {
+		if b, err = getRawValue(b); err != nil {
+			return err
+		}
+	}
+	if err := cs.unmarshal(b, out); err != nil {
+		if cs.logger != nil {
+			cs.logger.LogAttrs(ctx, slog.LevelDebug, "Unable to decode the async job result",
+				slog.String("command", api), slog.String("jobid", jobID), slog.Any("error", err))
+		}
+		return err
+	}
+	return nil
+}
+
+// Returns the JobID field of the response v points to, if any.
+func jobIDOf(v interface{}) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := rv.FieldByName("JobID"); f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// A Command for the commands executed with Call and CallAsync
+type rawCommand struct {
+	name   string
+	params url.Values
+	async  bool
+}
+
+func (c *rawCommand) APIName() string       { return c.name }
+func (c *rawCommand) Values() url.Values    { return c.params }
+func (c *rawCommand) IsAsync() bool         { return c.async }
+func (c *rawCommand) Response() interface{} { return new(json.RawMessage) }
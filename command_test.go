@@ -0,0 +1,94 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, cmd Command, out interface{}) error {
+				calls = append(calls, name+" "+cmd.APIName())
+				err := next(ctx, cmd, out)
+				calls = append(calls, name+" done")
+				return err
+			}
+		}
+	}
+	last := func(ctx context.Context, r *RequestInfo) {
+		calls = append(calls, "request "+r.Command)
+	}
+
+	srv, cs := newTestClient(t, WithMiddleware(record("a"), record("b")), WithBeforeRequest(last))
+	cs.Use(record("c"))
+
+	out, err := cs.Execute(context.Background(), cs.AccountDomain.NewListZonesParams())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	want := []string{
+		"a listZones", "b listZones", "c listZones",
+		"request listZones",
+		"c done", "b done", "a done",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+
+	r, ok := out.(*ListZonesResponse)
+	if !ok || len(r.Zones) != 1 || r.Zones[0].Id != srv.ZoneID {
+		t.Errorf("Execute = %#v, want a *ListZonesResponse with the zone", out)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	cached := &ListZonesResponse{Count: 1, Zones: []*Zone{{Id: "cached", Name: "cached"}}}
+	cache := func(next Handler) Handler {
+		return func(ctx context.Context, cmd Command, out interface{}) error {
+			if r, ok := out.(*ListZonesResponse); ok {
+				*r = *cached
+				return nil
+			}
+			return next(ctx, cmd, out)
+		}
+	}
+	srv, cs := newTestClient(t, WithMiddleware(cache))
+
+	// The services go through the middleware as well
+	r, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	if err != nil {
+		t.Fatalf("ListZones: %v", err)
+	}
+	if len(r.Zones) != 1 || r.Zones[0].Id != "cached" {
+		t.Errorf("zones = %+v, want the cached zone", r.Zones)
+	}
+	if n := srv.Count("listZones"); n != 0 {
+		t.Errorf("listZones was sent %d times, want 0", n)
+	}
+
+	if _, err := cs.AccountDomain.ListNetworks(cs.AccountDomain.NewListNetworksParams()); err != nil {
+		t.Fatalf("ListNetworks: %v", err)
+	}
+	if n := srv.Count("listNetworks"); n != 1 {
+		t.Errorf("listNetworks was sent %d times, want 1", n)
+	}
+}
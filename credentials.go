@@ -0,0 +1,272 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variables read by EnvProvider and FileProvider
+const (
+	EnvAPIURL     = "KCPS_API_URL"
+	EnvAPIKey     = "KCPS_API_KEY"
+	EnvSecretKey  = "KCPS_SECRET_KEY"
+	EnvConfigFile = "KCPS_CONFIG_FILE"
+	EnvProfile    = "KCPS_PROFILE"
+)
+
+// ErrNoCredentials is returned, possibly wrapped, by a CredentialProvider that has no
+// credentials to offer.
+var ErrNoCredentials = errors.New("No KCPS credentials found")
+
+// Credentials are the API URL and the keys the client signs its requests with.
+type Credentials struct {
+	APIURL    string // Optional; the URL the client was created with is used when empty
+	APIKey    string
+	SecretKey string
+}
+
+// CredentialProvider supplies the credentials of a client. The client asks for them before
+// every request, so a provider can hand out new keys after they were rotated without the
+// client being rebuilt. Providers must be safe for concurrent use.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticProvider always returns the same credentials.
+type StaticProvider Credentials
+
+// Credentials implements CredentialProvider.
+func (p StaticProvider) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials(p), nil
+}
+
+// EnvProvider reads the credentials from the KCPS_API_URL, KCPS_API_KEY and KCPS_SECRET_KEY
+// environment variables on every request. KCPS_API_URL is optional, except for clients created
+// with NewFromCredentials.
+type EnvProvider struct{}
+
+// Credentials implements CredentialProvider.
+func (EnvProvider) Credentials(ctx context.Context) (Credentials, error) {
+	c := Credentials{
+		APIURL:    os.Getenv(EnvAPIURL),
+		APIKey:    os.Getenv(EnvAPIKey),
+		SecretKey: os.Getenv(EnvSecretKey),
+	}
+	if c.APIKey == "" || c.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("%w: %s and %s must be set", ErrNoCredentials, EnvAPIKey, EnvSecretKey)
+	}
+	return c, nil
+}
+
+// ChainProvider returns the credentials of the first provider in the chain that has any.
+type ChainProvider []CredentialProvider
+
+// Credentials implements CredentialProvider. When no provider has credentials, the errors of
+// all providers are returned.
+func (p ChainProvider) Credentials(ctx context.Context) (Credentials, error) {
+	var errs []error
+	for _, cp := range p {
+		c, err := cp.Credentials(ctx)
+		if err == nil {
+			return c, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return Credentials{}, ErrNoCredentials
+	}
+	return Credentials{}, errors.Join(errs...)
+}
+
+// DefaultConfigFile returns the path of the config file read by a FileProvider without a
+// path: the KCPS_CONFIG_FILE environment variable, or ~/.kcps/config.
+func DefaultConfigFile() string {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kcps", "config")
+}
+
+// FileProvider reads the credentials from a profile in a cloudmonkey style config file:
+//
+//	[core]
+//	profile = jp2
+//
+//	[jp2]
+//	url = https://api.example.com/client/api
+//	apikey = ...
+//	secretkey = ...
+//	verifycert = true
+//
+// The file is read again when it changed since the last request, so rotated keys are picked
+// up by running clients.
+type FileProvider struct {
+	Path    string // Path of the config file; DefaultConfigFile() when empty
+	Profile string // Name of the profile; KCPS_PROFILE or the profile of the [core] section when empty
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	profile map[string]string // Settings of the profile read last
+}
+
+// Credentials implements CredentialProvider.
+func (p *FileProvider) Credentials(ctx context.Context) (Credentials, error) {
+	settings, err := p.settings()
+	if err != nil {
+		return Credentials{}, err
+	}
+	c := Credentials{
+		APIURL:    settings["url"],
+		APIKey:    settings["apikey"],
+		SecretKey: settings["secretkey"],
+	}
+	if c.APIKey == "" || c.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("%w: profile %s of %s has no apikey or secretkey", ErrNoCredentials, p.profileName(nil), p.path())
+	}
+	return c, nil
+}
+
+func (p *FileProvider) path() string {
+	if p.Path != "" {
+		return p.Path
+	}
+	return DefaultConfigFile()
+}
+
+func (p *FileProvider) profileName(sections map[string]map[string]string) string {
+	if p.Profile != "" {
+		return p.Profile
+	}
+	if name := os.Getenv(EnvProfile); name != "" {
+		return name
+	}
+	return sections["core"]["profile"]
+}
+
+// Returns the settings of the profile, reading the file again when it changed.
+func (p *FileProvider) settings() (map[string]string, error) {
+	path := p.path()
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.profile != nil && fi.ModTime().Equal(p.modTime) && fi.Size() == p.size {
+		return p.profile, nil
+	}
+
+	sections, err := readINIFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := p.profileName(sections)
+	if name == "" {
+		return nil, fmt.Errorf("%w: no profile given and none set in the [core] section of %s", ErrNoCredentials, path)
+	}
+	profile, ok := sections[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: profile %s not found in %s", ErrNoCredentials, name, path)
+	}
+
+	p.modTime = fi.ModTime()
+	p.size = fi.Size()
+	p.profile = profile
+	return profile, nil
+}
+
+// Reads an INI file into its sections. Keys are lower case, lines starting with # or ; are
+// comments.
+func readINIFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sections := make(map[string]map[string]string)
+	var section map[string]string
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case line[0] == '[' && line[len(line)-1] == ']':
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			section = sections[name]
+		default:
+			k, v, ok := strings.Cut(line, "=")
+			if !ok || section == nil {
+				return nil, fmt.Errorf("Unable to parse line %d of %s", n, path)
+			}
+			section[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// NewFromCredentials creates a new client which asks p for its credentials before every
+// request, configured by the given options. The credentials must include the API URL.
+func NewFromCredentials(p CredentialProvider, opts ...Option) (*KCPSClient, error) {
+	c, err := p.Credentials(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if c.APIURL == "" {
+		return nil, fmt.Errorf("%w: the credentials have no API URL, e.g. set %s", ErrNoCredentials, EnvAPIURL)
+	}
+	return New("", "", "", append([]Option{WithCredentials(p)}, opts...)...)
+}
+
+// NewClientFromProfile creates a new client using the profile with the given name in the
+// config file returned by DefaultConfigFile, see FileProvider. An empty name selects the
+// profile like FileProvider does. When the profile sets verifycert to false, the certificate
+// of the API is not verified.
+func NewClientFromProfile(name string, opts ...Option) (*KCPSClient, error) {
+	p := &FileProvider{Profile: name}
+	settings, err := p.settings()
+	if err != nil {
+		return nil, err
+	}
+	if v := strings.ToLower(settings["verifycert"]); v == "false" || v == "no" {
+		opts = append([]Option{WithInsecureSkipVerify(true)}, opts...)
+	}
+	return NewFromCredentials(p, opts...)
+}
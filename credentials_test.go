@@ -0,0 +1,85 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uesyn/gokcps/gokcpstest"
+)
+
+func TestNewFromCredentials(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		p       CredentialProvider
+		wantErr error
+	}{
+		{
+			name: "static",
+			p:    StaticProvider{APIURL: srv.URL, APIKey: srv.APIKey, SecretKey: srv.SecretKey},
+		},
+		{
+			name: "env",
+			env:  map[string]string{EnvAPIURL: srv.URL, EnvAPIKey: srv.APIKey, EnvSecretKey: srv.SecretKey},
+			p:    EnvProvider{},
+		},
+		{
+			name:    "env without url",
+			env:     map[string]string{EnvAPIURL: "", EnvAPIKey: srv.APIKey, EnvSecretKey: srv.SecretKey},
+			p:       EnvProvider{},
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:    "static without url",
+			p:       StaticProvider{APIKey: srv.APIKey, SecretKey: srv.SecretKey},
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:    "env without keys",
+			env:     map[string]string{EnvAPIURL: srv.URL, EnvAPIKey: "", EnvSecretKey: ""},
+			p:       EnvProvider{},
+			wantErr: ErrNoCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cs, err := NewFromCredentials(tt.p)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFromCredentials: %v", err)
+			}
+			if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+				t.Errorf("ListZones: %v", err)
+			}
+		})
+	}
+}
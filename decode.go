@@ -0,0 +1,104 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// Decodes the value wrapped in the response envelope, e.g. {"listvirtualmachinesresponse":{...}},
+// straight from r into v, without buffering the whole response first.
+func decodeEnvelope(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("Unexpected response, expected an object but got %v", t)
+	}
+	if !dec.More() {
+		return errors.New("Unable to extract the raw value from an empty response")
+	}
+
+	// Skip the name of the envelope
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return dec.Decode(v)
+}
+
+// Resets the value v points to, so a retried request does not decode into a partially
+// decoded value.
+func resetValue(v interface{}) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+}
+
+// Like json.Unmarshal, but also converts the timestamps in v to the location of the client.
+func (cs *KCPSClient) unmarshal(b []byte, v interface{}) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	cs.localize(v)
+	return nil
+}
+
+// Converts all timestamps in the value v points to, to the location set with WithLocation.
+func (cs *KCPSClient) localize(v interface{}) {
+	if cs.location != nil {
+		localizeValue(reflect.ValueOf(v), cs.location)
+	}
+}
+
+var timestampType = reflect.TypeOf(Timestamp{})
+
+func localizeValue(v reflect.Value, loc *time.Location) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			localizeValue(v.Elem(), loc)
+		}
+	case reflect.Struct:
+		if v.Type() == timestampType {
+			// Values that are not addressable, like the ones stored in an interface, cannot be set
+			if !v.CanAddr() {
+				return
+			}
+			if t := v.Addr().Interface().(*Timestamp); !t.Time.IsZero() {
+				t.Time = t.Time.In(loc)
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				localizeValue(f, loc)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			localizeValue(v.Index(i), loc)
+		}
+	}
+}
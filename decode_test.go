@@ -0,0 +1,77 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithLocation(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	srv, cs := newTestClient(t, WithLocation(jst), WithAsync(true))
+
+	v, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol"))
+	if err != nil {
+		t.Fatalf("CreateVolume: %v", err)
+	}
+	if v.Created.IsZero() || v.Created.Location() != jst {
+		t.Errorf("created = %v in the job result, want a time in JST", v.Created)
+	}
+
+	j, err := cs.Volume.CreateVolumeJob(context.Background(), newTestVolumeParams(cs, srv, "vol2"))
+	if err != nil {
+		t.Fatalf("CreateVolumeJob: %v", err)
+	}
+	v, err = j.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if v.Created.IsZero() || v.Created.Location() != jst {
+		t.Errorf("created = %v in the job, want a time in JST", v.Created)
+	}
+
+	l, err := cs.Volume.ListVolumes(cs.Volume.NewListVolumesParams())
+	if err != nil {
+		t.Fatalf("ListVolumes: %v", err)
+	}
+	if len(l.Volumes) != 2 {
+		t.Fatalf("listed %d volumes, want 2", len(l.Volumes))
+	}
+	for _, v := range l.Volumes {
+		if v.Created.IsZero() || v.Created.Location() != jst {
+			t.Errorf("created = %v in the list, want a time in JST", v.Created)
+		}
+	}
+}
+
+func TestLocalizeNotAddressable(t *testing.T) {
+	cs := &KCPSClient{location: time.FixedZone("JST", 9*60*60)}
+	created := time.Date(2016, 1, 2, 6, 4, 5, 0, time.UTC)
+
+	// Values stored in an interface cannot be set, and must be skipped instead of panicking
+	var v interface{} = Timestamp{Time: created}
+	cs.localize(&v)
+	var s interface{} = struct{ Created Timestamp }{Created: Timestamp{Time: created}}
+	cs.localize(&s)
+	cs.localize([1]Timestamp{{Time: created}})
+
+	if got := v.(Timestamp).Time; got.Location() != time.UTC {
+		t.Errorf("time = %v, want it unchanged", got)
+	}
+}
@@ -0,0 +1,349 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Error codes returned by the API in the `errorcode` field of an error response
+const (
+	ErrCodeUnauthorized         = 401
+	ErrCodeAPILimitExceeded     = 429
+	ErrCodeMalformedParameter   = 430
+	ErrCodeParamError           = 431
+	ErrCodeUnsupportedAction    = 432
+	ErrCodeInternalError        = 530
+	ErrCodeAccountError         = 531
+	ErrCodeAccountResourceLimit = 532
+	ErrCodeInsufficientCapacity = 533
+	ErrCodeResourceUnavailable  = 534
+	ErrCodeResourceAllocation   = 535
+	ErrCodeResourceInUse        = 536
+	ErrCodeNetworkRuleConflict  = 537
+)
+
+// Exception codes returned by the API in the `cserrorcode` field of an error response
+const (
+	CSErrCodeCloudRuntime          = 4250
+	CSErrCodeCloudAuthentication   = 4290
+	CSErrCodeInsufficientAddress   = 4320
+	CSErrCodeInsufficientCapacity  = 4325
+	CSErrCodeInsufficientNetwork   = 4330
+	CSErrCodeInsufficientServer    = 4335
+	CSErrCodeInsufficientStorage   = 4340
+	CSErrCodeInvalidParameterValue = 4350
+	CSErrCodePermissionDenied      = 4365
+	CSErrCodeResourceUnavailable   = 4380
+	CSErrCodeRequestLimit          = 4545
+	CSErrCodeServerAPI             = 9999
+)
+
+// Parameters that are never exposed through errors, as they are either part of the
+// authentication or added to every request anyway
+var omittedParams = map[string]bool{
+	"apikey":    true,
+	"signature": true,
+	"command":   true,
+	"response":  true,
+}
+
+// Parameters whose values are replaced by redactedValue before they are exposed, in addition
+// to all parameters with "password" in their name
+var sensitiveParams = map[string]bool{
+	"password":    true,
+	"newpassword": true,
+	"userdata":    true,
+	"sessionkey":  true,
+}
+
+const redactedValue = "[REDACTED]"
+
+// Returns a copy of the request parameters which is safe to log or to return to callers.
+func sanitizeParams(params url.Values) url.Values {
+	u := make(url.Values, len(params))
+	for k, v := range params {
+		lk := strings.ToLower(k)
+		if omittedParams[lk] {
+			continue
+		}
+		if sensitiveParams[lk] || strings.Contains(lk, "password") {
+			u[k] = []string{redactedValue}
+			continue
+		}
+		u[k] = append([]string(nil), v...)
+	}
+	return u
+}
+
+// CSError is the error returned when the API responds with an error. Use errors.As to
+// get hold of it, or one of the IsXxx helpers to check for common error conditions.
+type CSError struct {
+	HTTPStatus  int        `json:"-"`           // HTTP status code of the response
+	ErrorCode   int        `json:"errorcode"`   // API error code, see the ErrCodeXxx constants
+	CSErrorCode int        `json:"cserrorcode"` // CloudStack exception code, see the CSErrCodeXxx constants
+	ErrorText   string     `json:"errortext"`
+	Command     string     `json:"-"` // The API command that failed
+	Params      url.Values `json:"-"` // The request parameters, without credentials and sensitive values
+}
+
+func (e *CSError) Error() string {
+	msg := fmt.Sprintf("CloudStack API error %d (CSExceptionErrorCode: %d): %s", e.ErrorCode, e.CSErrorCode, e.ErrorText)
+	if e.Command != "" {
+		msg = e.Command + ": " + msg
+	}
+	return msg
+}
+
+// Returns the CSError wrapped in err, if any.
+func asCSError(err error) (*CSError, bool) {
+	var e *CSError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// NotFoundError is returned by the GetXxx helpers when no resource matches the name or ID.
+type NotFoundError struct {
+	Kind string // Type of the resource, e.g. VirtualMachine
+	Key  string // The name or ID that was looked up
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("No match found for %s %s", e.Kind, e.Key)
+}
+
+// AmbiguousError is returned by the GetXxx helpers when more than one resource matches the
+// name or ID.
+type AmbiguousError struct {
+	Kind string
+	Key  string
+	IDs  []string // IDs of all matching resources
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("There is more than one result for %s %s: %s", e.Kind, e.Key, strings.Join(e.IDs, ", "))
+}
+
+// IsNotFound returns true if err is a *NotFoundError, or an API error reporting that the
+// requested entity does not exist.
+func IsNotFound(err error) bool {
+	var nf *NotFoundError
+	if errors.As(err, &nf) {
+		return true
+	}
+	e, ok := asCSError(err)
+	if !ok || (e.ErrorCode != ErrCodeParamError && e.HTTPStatus != ErrCodeParamError) {
+		return false
+	}
+	text := strings.ToLower(e.ErrorText)
+	return strings.Contains(text, "does not exist") ||
+		strings.Contains(text, "unable to find") ||
+		strings.Contains(text, "not found")
+}
+
+// IsAmbiguous returns true if err is an *AmbiguousError.
+func IsAmbiguous(err error) bool {
+	var e *AmbiguousError
+	return errors.As(err, &e)
+}
+
+// IsAuthError returns true if err is an API error caused by invalid credentials or an
+// invalid request signature.
+func IsAuthError(err error) bool {
+	e, ok := asCSError(err)
+	if !ok {
+		return false
+	}
+	return e.ErrorCode == ErrCodeUnauthorized ||
+		e.HTTPStatus == http.StatusUnauthorized ||
+		e.CSErrorCode == CSErrCodeCloudAuthentication
+}
+
+// IsThrottled returns true if err is an API error returned because the API request
+// limit was exceeded.
+func IsThrottled(err error) bool {
+	e, ok := asCSError(err)
+	if !ok {
+		return false
+	}
+	return e.ErrorCode == ErrCodeAPILimitExceeded ||
+		e.HTTPStatus == http.StatusTooManyRequests ||
+		e.CSErrorCode == CSErrCodeRequestLimit ||
+		strings.Contains(strings.ToLower(e.ErrorText), "too many requests")
+}
+
+// IsInsufficientCapacity returns true if err is an API error or a failed async job reporting
+// that there is not enough capacity to fulfill the request.
+func IsInsufficientCapacity(err error) bool {
+	e, ok := asCSError(err)
+	if !ok {
+		return false
+	}
+	switch e.CSErrorCode {
+	case CSErrCodeInsufficientAddress, CSErrCodeInsufficientCapacity, CSErrCodeInsufficientNetwork,
+		CSErrCodeInsufficientServer, CSErrCodeInsufficientStorage:
+		return true
+	}
+	return e.ErrorCode == ErrCodeInsufficientCapacity
+}
+
+// AsyncJobError is returned when an async job finished with a failure. When the job
+// result contains an error code, errors.As can also be used to get a *CSError for it,
+// so the IsXxx helpers work for failed jobs as well.
+type AsyncJobError struct {
+	JobID           string
+	Cmd             string
+	Jobresultcode   int
+	Jobinstancetype string
+	Jobinstanceid   string
+	ErrorCode       int
+	CSErrorCode     int
+	ErrorText       string
+	Jobresult       json.RawMessage // The undecoded job result
+}
+
+func newAsyncJobError(jobid string, r *QueryAsyncJobResultResponse) *AsyncJobError {
+	e := &AsyncJobError{
+		JobID:           jobid,
+		Cmd:             r.Cmd,
+		Jobresultcode:   r.Jobresultcode,
+		Jobinstancetype: r.Jobinstancetype,
+		Jobinstanceid:   r.Jobinstanceid,
+		Jobresult:       r.Jobresult,
+	}
+
+	var body struct {
+		ErrorCode   int    `json:"errorcode"`
+		CSErrorCode int    `json:"cserrorcode"`
+		ErrorText   string `json:"errortext"`
+	}
+	var text string
+	switch {
+	case json.Unmarshal(r.Jobresult, &body) == nil:
+		e.ErrorCode = body.ErrorCode
+		e.CSErrorCode = body.CSErrorCode
+		e.ErrorText = body.ErrorText
+	case json.Unmarshal(r.Jobresult, &text) == nil:
+		e.ErrorText = text
+	default:
+		e.ErrorText = string(r.Jobresult)
+	}
+	return e
+}
+
+func (e *AsyncJobError) Error() string {
+	if e.ErrorCode == 0 && e.CSErrorCode == 0 {
+		return fmt.Sprintf("Async job %s (%s) failed: %s", e.JobID, e.Cmd, e.ErrorText)
+	}
+	return fmt.Sprintf("Async job %s (%s) failed with error %d (CSExceptionErrorCode: %d): %s",
+		e.JobID, e.Cmd, e.ErrorCode, e.CSErrorCode, e.ErrorText)
+}
+
+func (e *AsyncJobError) Unwrap() error {
+	if e.ErrorCode == 0 && e.CSErrorCode == 0 {
+		return nil
+	}
+	return &CSError{
+		ErrorCode:   e.ErrorCode,
+		CSErrorCode: e.CSErrorCode,
+		ErrorText:   e.ErrorText,
+		Command:     e.Cmd,
+	}
+}
+
+// AsyncTimeoutErr is returned, possibly wrapped, when an async job does not finish in time.
+// Use errors.Is(err, AsyncTimeoutErr) to check for it.
+var AsyncTimeoutErr = errors.New("Timeout while waiting for async job to finish")
+
+// AsyncTimeoutError is the error returned when waiting for an async job timed out. It carries
+// the ID of the job, so the caller can resume waiting for the job later.
+type AsyncTimeoutError struct {
+	JobID   string
+	Timeout time.Duration
+	Err     error // The context error, if the deadline of the context was exceeded
+}
+
+func (e *AsyncTimeoutError) Error() string {
+	return fmt.Sprintf("Timeout after %s while waiting for async job %s to finish", e.Timeout, e.JobID)
+}
+
+// Is makes errors.Is(err, AsyncTimeoutErr) report true for an *AsyncTimeoutError.
+func (e *AsyncTimeoutError) Is(target error) bool {
+	return target == AsyncTimeoutErr
+}
+
+func (e *AsyncTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// WaitTimeoutError is returned when a resource did not reach the state waited for in time.
+// It carries the last state that was observed.
+type WaitTimeoutError struct {
+	Kind    string // Kind of the resource; empty when using WaitFor
+	ID      string
+	State   string // Last observed state
+	Timeout time.Duration
+	Err     error // The context error, if the deadline of the context was exceeded
+}
+
+func (e *WaitTimeoutError) Error() string {
+	if e.Kind == "" {
+		return fmt.Sprintf("Timeout after %s while waiting, last state: %q", e.Timeout, e.State)
+	}
+	return fmt.Sprintf("Timeout after %s while waiting for %s %s, last state: %q", e.Timeout, e.Kind, e.ID, e.State)
+}
+
+func (e *WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// UnexpectedStateError is returned when a resource that is waited for reaches a state from
+// which it cannot reach the wanted state anymore, e.g. a virtual machine in state Error.
+type UnexpectedStateError struct {
+	Kind  string
+	ID    string
+	State string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("%s %s is in unexpected state %q", e.Kind, e.ID, e.State)
+}
+
+// ClockSkewError is returned when the API refused a request with an expiring signature, and
+// the clock of the API server differs too much from the local clock. The client corrects the
+// expiry of the following requests, so the request can be retried. errors.As can also be used
+// to get the *CSError of the refused request.
+type ClockSkewError struct {
+	Skew time.Duration // How far the clock of the API server is ahead of the local clock
+	Err  error
+}
+
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("Request refused, the clock of the API server is off by %s: %v", e.Skew, e.Err)
+}
+
+func (e *ClockSkewError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,148 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/uesyn/gokcps/gokcpstest"
+)
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		fail      func(srv *gokcpstest.Server)
+		notFound  bool
+		auth      bool
+		throttled bool
+	}{
+		{name: "not found", notFound: true},
+		{name: "throttled", throttled: true, fail: func(srv *gokcpstest.Server) {
+			srv.FailNext("deleteVolume", http.StatusTooManyRequests, ErrCodeAPILimitExceeded, "Too many requests")
+		}},
+		{name: "unauthorized", auth: true, fail: func(srv *gokcpstest.Server) {
+			srv.FailNext("deleteVolume", http.StatusUnauthorized, ErrCodeUnauthorized, "unable to verify user credentials")
+		}},
+		{name: "other", fail: func(srv *gokcpstest.Server) {
+			srv.FailNext("deleteVolume", ErrCodeInternalError, ErrCodeInternalError, "Internal error")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, cs := newTestClient(t, WithRetryPolicy(NoRetry))
+			if tt.fail != nil {
+				tt.fail(srv)
+			}
+
+			_, err := cs.Volume.DeleteVolume(cs.Volume.NewDeleteVolumeParams("missing"))
+			var e *CSError
+			if !errors.As(err, &e) {
+				t.Fatalf("err = %v, want a *CSError", err)
+			}
+			if e.Command != "deleteVolume" || e.Params.Get("id") != "missing" {
+				t.Errorf("command = %s, params = %v, want deleteVolume with the id", e.Command, e.Params)
+			}
+			if _, ok := e.Params["apiKey"]; ok {
+				t.Errorf("params = %v, want them without the API key", e.Params)
+			}
+			if got := IsNotFound(err); got != tt.notFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.notFound)
+			}
+			if got := IsAuthError(err); got != tt.auth {
+				t.Errorf("IsAuthError = %v, want %v", got, tt.auth)
+			}
+			if got := IsThrottled(err); got != tt.throttled {
+				t.Errorf("IsThrottled = %v, want %v", got, tt.throttled)
+			}
+		})
+	}
+}
+
+func TestIsNotFoundLookup(t *testing.T) {
+	_, cs := newTestClient(t)
+
+	_, _, err := cs.AccountDomain.GetNetworkID("NoSuchNetwork")
+	if !IsNotFound(err) {
+		t.Errorf("err = %v, want a not found error", err)
+	}
+	if IsNotFound(errors.New("does not exist")) {
+		t.Error("IsNotFound is true for an error that is not from the API")
+	}
+}
+
+func TestNewCSError(t *testing.T) {
+	long := strings.Repeat("x", 600)
+	params := url.Values{"apiKey": {"key"}, "signature": {"sig"}, "id": {"1"}, "password": {"pw"}}
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   CSError
+	}{
+		{
+			name:   "envelope",
+			status: 431,
+			body:   `{"deletevolumeresponse":{"uuidList":[],"errorcode":431,"cserrorcode":4350,"errortext":"Volume does not exist"}}`,
+			want:   CSError{HTTPStatus: 431, ErrorCode: 431, CSErrorCode: 4350, ErrorText: "Volume does not exist"},
+		},
+		{
+			name:   "envelope without text",
+			status: 530,
+			body:   `{"deletevolumeresponse":{"errorcode":530}}`,
+			want:   CSError{HTTPStatus: 530, ErrorCode: 530, ErrorText: `{"deletevolumeresponse":{"errorcode":530}}`},
+		},
+		{
+			name:   "html",
+			status: http.StatusBadGateway,
+			body:   "<html><body>502 Bad Gateway</body></html>\n",
+			want:   CSError{HTTPStatus: http.StatusBadGateway, ErrorText: "<html><body>502 Bad Gateway</body></html>"},
+		},
+		{
+			name:   "empty",
+			status: http.StatusServiceUnavailable,
+			want:   CSError{HTTPStatus: http.StatusServiceUnavailable, ErrorText: "Service Unavailable"},
+		},
+		{
+			name:   "long",
+			status: http.StatusInternalServerError,
+			body:   long,
+			want:   CSError{HTTPStatus: http.StatusInternalServerError, ErrorText: long[:512] + "..."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newCSError(tt.status, "deleteVolume", params, []byte(tt.body))
+			if e.HTTPStatus != tt.want.HTTPStatus || e.ErrorCode != tt.want.ErrorCode ||
+				e.CSErrorCode != tt.want.CSErrorCode || e.ErrorText != tt.want.ErrorText {
+				t.Errorf("newCSError = %+v, want %+v", *e, tt.want)
+			}
+			if e.Command != "deleteVolume" {
+				t.Errorf("command = %q, want deleteVolume", e.Command)
+			}
+			if e.Params.Encode() != "id=1&password=%5BREDACTED%5D" {
+				t.Errorf("params = %v, want them sanitized", e.Params)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types in this file accept the different forms KCPS uses for the same value, so
+// responses decode without rewriting them first.
+
+// FlexInt is an int which is decoded from either a JSON number or a string holding one, as
+// KCPS returns some numbers as strings.
+type FlexInt int
+
+func (i *FlexInt) UnmarshalJSON(b []byte) error {
+	n, err := parseFlexInt(b, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*i = FlexInt(n)
+	return nil
+}
+
+// FlexInt64 is like FlexInt, for int64 values.
+type FlexInt64 int64
+
+func (i *FlexInt64) UnmarshalJSON(b []byte) error {
+	n, err := parseFlexInt(b, 64)
+	if err != nil {
+		return err
+	}
+	*i = FlexInt64(n)
+	return nil
+}
+
+func parseFlexInt(b []byte, bitSize int) (int64, error) {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return 0, nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return 0, err
+		}
+		if s == "" {
+			return 0, nil
+		}
+		b = []byte(s)
+	}
+	n, err := strconv.ParseInt(string(b), 10, bitSize)
+	if err != nil {
+		// Also accept numbers like 2.0 or 1e3, as long as they are whole
+		f, ferr := strconv.ParseFloat(string(b), 64)
+		if ferr != nil || f != float64(int64(f)) {
+			return 0, fmt.Errorf("Unable to decode %s as an integer", b)
+		}
+		n = int64(f)
+	}
+	return n, nil
+}
+
+// FlexBool is a bool which is decoded from either a JSON bool, a string holding one or the
+// numbers 0 and 1. KCPS reports the success of synchronous commands as the string "true".
+type FlexBool bool
+
+func (v *FlexBool) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+
+	switch strings.ToLower(s) {
+	case "null":
+		return nil
+	case "true", "1":
+		*v = true
+	case "false", "0", "":
+		*v = false
+	default:
+		return fmt.Errorf("Unable to decode %s as a bool", b)
+	}
+	return nil
+}
+
+// Layouts of the date strings returned by the API, tried in order. Dates without a time zone
+// are taken to be in UTC.
+var timestampLayouts = []string{
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Timestamp is a point in time as returned by the API, either as a date string or, like the
+// creation time of virtual machines in async job results, as epoch milliseconds. Raw keeps the
+// original value. Values that cannot be parsed decode without an error, leaving Time zero.
+type Timestamp struct {
+	time.Time
+	Raw string
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		return nil
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*t = parseTimestamp(s)
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Unable to decode %s as a timestamp", b)
+	}
+	*t = Timestamp{Time: time.UnixMilli(ms), Raw: string(b)}
+	return nil
+}
+
+// MarshalJSON encodes the timestamp in the layout used by the API, in the location of Time.
+// Values that could not be parsed are encoded as their original value.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// MarshalText is like MarshalJSON, so encodings that use text, like YAML or TOML, agree with
+// JSON instead of using the method promoted from Time.
+func (t Timestamp) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses the text like a JSON string, keeping the original value in Raw.
+func (t *Timestamp) UnmarshalText(b []byte) error {
+	*t = parseTimestamp(string(b))
+	return nil
+}
+
+// String formats the timestamp in the layout used by the API, so it reflects the location set
+// with WithLocation. Values that could not be parsed are returned as their original value.
+func (t Timestamp) String() string {
+	if t.Time.IsZero() {
+		return t.Raw
+	}
+	return t.Time.Format(timestampLayouts[0])
+}
+
+func parseTimestamp(s string) Timestamp {
+	ts := Timestamp{Raw: s}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		ts.Time = time.UnixMilli(ms)
+		return ts
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			ts.Time = t
+			break
+		}
+	}
+	return ts
+}
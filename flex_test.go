@@ -0,0 +1,172 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name string
+		in   string
+		want time.Time
+		raw  string
+	}{
+		{name: "epoch millis", in: `1451746800123`, want: time.UnixMilli(1451746800123), raw: "1451746800123"},
+		{name: "epoch millis string", in: `"1451746800123"`, want: time.UnixMilli(1451746800123), raw: "1451746800123"},
+		{name: "offset", in: `"2016-01-02T15:04:05+0900"`, want: time.Date(2016, 1, 2, 15, 4, 5, 0, jst), raw: "2016-01-02T15:04:05+0900"},
+		{name: "RFC 3339", in: `"2016-01-02T15:04:05+09:00"`, want: time.Date(2016, 1, 2, 15, 4, 5, 0, jst), raw: "2016-01-02T15:04:05+09:00"},
+		{name: "no zone", in: `"2016-01-02T15:04:05"`, want: time.Date(2016, 1, 2, 15, 4, 5, 0, time.UTC), raw: "2016-01-02T15:04:05"},
+		{name: "no zone with space", in: `"2016-01-02 15:04:05"`, want: time.Date(2016, 1, 2, 15, 4, 5, 0, time.UTC), raw: "2016-01-02 15:04:05"},
+		{name: "date", in: `"2016-01-02"`, want: time.Date(2016, 1, 2, 0, 0, 0, 0, time.UTC), raw: "2016-01-02"},
+		{name: "unparsable", in: `"yesterday"`, raw: "yesterday"},
+		{name: "null", in: `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !ts.Time.Equal(tt.want) {
+				t.Errorf("Time = %v, want %v", ts.Time, tt.want)
+			}
+			if ts.Raw != tt.raw {
+				t.Errorf("Raw = %q, want %q", ts.Raw, tt.raw)
+			}
+		})
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`true`), &ts); err == nil {
+		t.Error("expected an error for a boolean")
+	}
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	ts := Timestamp{Time: time.Date(2016, 1, 2, 15, 4, 5, 0, jst), Raw: "1451714645000"}
+
+	b, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	if want := `"2016-01-02T15:04:05+0900"`; string(b) != want {
+		t.Errorf("MarshalJSON = %s, want %s", b, want)
+	}
+
+	text, err := ts.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+	if `"`+string(text)+`"` != string(b) {
+		t.Errorf("MarshalText = %s, want it to agree with MarshalJSON %s", text, b)
+	}
+
+	var back Timestamp
+	if err := back.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if !back.Time.Equal(ts.Time) || back.Raw != string(text) {
+		t.Errorf("UnmarshalText = %+v, want %v", back, ts.Time)
+	}
+
+	unparsed := Timestamp{Raw: "yesterday"}
+	if text, _ := unparsed.MarshalText(); string(text) != "yesterday" {
+		t.Errorf("MarshalText of an unparsed timestamp = %q, want the raw value", text)
+	}
+}
+
+func TestFlexTypes(t *testing.T) {
+	tests := []struct {
+		in      string
+		int     FlexInt
+		int64   FlexInt64
+		intErr  bool
+		bool    FlexBool
+		boolErr bool
+	}{
+		{in: `1`, int: 1, int64: 1, bool: true},
+		{in: `0`, bool: false},
+		{in: `-42`, int: -42, int64: -42, boolErr: true},
+		{in: `2.0`, int: 2, int64: 2, boolErr: true},
+		{in: `"7"`, int: 7, int64: 7, boolErr: true},
+		{in: `"1"`, int: 1, int64: 1, bool: true},
+		{in: `""`},
+		{in: `null`},
+		{in: `"9007199254740993"`, int: 9007199254740993, int64: 9007199254740993, boolErr: true},
+		{in: `true`, intErr: true, bool: true},
+		{in: `"true"`, intErr: true, bool: true},
+		{in: `"False"`, intErr: true},
+		{in: `1.5`, intErr: true, boolErr: true},
+		{in: `"abc"`, intErr: true, boolErr: true},
+		{in: `{}`, intErr: true, boolErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var i FlexInt
+			err := json.Unmarshal([]byte(tt.in), &i)
+			if (err != nil) != tt.intErr {
+				t.Errorf("FlexInt error = %v, want an error: %v", err, tt.intErr)
+			} else if err == nil && i != tt.int {
+				t.Errorf("FlexInt = %d, want %d", i, tt.int)
+			}
+
+			var i64 FlexInt64
+			err = json.Unmarshal([]byte(tt.in), &i64)
+			if (err != nil) != tt.intErr {
+				t.Errorf("FlexInt64 error = %v, want an error: %v", err, tt.intErr)
+			} else if err == nil && i64 != tt.int64 {
+				t.Errorf("FlexInt64 = %d, want %d", i64, tt.int64)
+			}
+
+			var b FlexBool
+			err = json.Unmarshal([]byte(tt.in), &b)
+			if (err != nil) != tt.boolErr {
+				t.Errorf("FlexBool error = %v, want an error: %v", err, tt.boolErr)
+			} else if err == nil && b != tt.bool {
+				t.Errorf("FlexBool = %v, want %v", b, tt.bool)
+			}
+		})
+	}
+}
+
+func TestFlexTypesInStruct(t *testing.T) {
+	var v struct {
+		Count   FlexInt   `json:"count"`
+		Size    FlexInt64 `json:"size"`
+		Success FlexBool  `json:"success"`
+	}
+	if err := json.Unmarshal([]byte(`{"count":"3","size":10737418240,"success":"true"}`), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if v.Count != 3 || v.Size != 10737418240 || !v.Success {
+		t.Errorf("decoded %+v", v)
+	}
+
+	err := json.Unmarshal([]byte(`{"count":"three"}`), &v)
+	if err == nil || !strings.Contains(err.Error(), "three") {
+		t.Errorf("err = %v, want an error naming the bad value", err)
+	}
+}
@@ -0,0 +1,989 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcpstest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type handler func(s *Server, p url.Values) (interface{}, error)
+
+// The implemented commands, by their lower case name
+var handlers = map[string]handler{
+	"listzones":            listZones,
+	"listnetworks":         listNetworks,
+	"listserviceofferings": listServiceOfferings,
+	"listdiskofferings":    listDiskOfferings,
+	"listtemplates":        listTemplates,
+
+	"queryasyncjobresult":   queryAsyncJobResult,
+	"queryexasyncjobresult": queryExAsyncJobResult,
+	"listasyncjobs":         listAsyncJobs,
+
+	"deployvaluevirtualmachine":      deployValueVirtualMachine,
+	"listvirtualmachines":            listVirtualMachines,
+	"startvirtualmachine":            startVirtualMachine,
+	"stopvirtualmachine":             stopVirtualMachine,
+	"rebootvirtualmachine":           rebootVirtualMachine,
+	"destroyvirtualmachine":          destroyVirtualMachine,
+	"resetpasswordforvirtualmachine": resetPasswordForVirtualMachine,
+
+	"createvolume": createVolume,
+	"listvolumes":  listVolumes,
+	"attachvolume": attachVolume,
+	"detachvolume": detachVolume,
+	"resizevolume": resizeVolume,
+	"deletevolume": deleteVolume,
+
+	"createsnapshot": createSnapshot,
+	"listsnapshots":  listSnapshots,
+	"deletesnapshot": deleteSnapshot,
+
+	"associateipaddress":    associateIpAddress,
+	"disassociateipaddress": disassociateIpAddress,
+	"listpublicipaddresses": listPublicIpAddresses,
+	"enablestaticnat":       enableStaticNat,
+	"disablestaticnat":      disableStaticNat,
+
+	"createfirewallrule": createFirewallRule,
+	"deletefirewallrule": deleteFirewallRule,
+	"listfirewallrules":  listFirewallRules,
+
+	"createportforwardingrule": createPortForwardingRule,
+	"deleteportforwardingrule": deletePortForwardingRule,
+	"listportforwardingrules":  listPortForwardingRules,
+
+	"createloadbalancerrule":        createLoadBalancerRule,
+	"deleteloadbalancerrule":        deleteLoadBalancerRule,
+	"listloadbalancerrules":         listLoadBalancerRules,
+	"assigntoloadbalancerrule":      assignToLoadBalancerRule,
+	"removefromloadbalancerrule":    removeFromLoadBalancerRule,
+	"listloadbalancerruleinstances": listLoadBalancerRuleInstances,
+
+	"createtags": createTags,
+	"deletetags": deleteTags,
+	"listtags":   listTags,
+}
+
+// Results of the commands and jobs that only report success
+var (
+	syncSuccess  = object{"success": "true"}
+	asyncSuccess = object{"success": true}
+)
+
+func listZones(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("zone", s.find(kindZone, p, "id", "name"), p)
+}
+
+func listNetworks(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("network", s.find(kindNetwork, p, "id", "zoneid"), p)
+}
+
+func listServiceOfferings(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("serviceoffering", s.find(kindServiceOffering, p, "id", "name"), p)
+}
+
+func listDiskOfferings(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("diskoffering", s.find(kindDiskOffering, p, "id", "name"), p)
+}
+
+func listTemplates(s *Server, p url.Values) (interface{}, error) {
+	if p.Get("templatefilter") == "" {
+		return nil, errMissing("templatefilter")
+	}
+	return listResponse("template", s.find(kindTemplate, p, "id", "name", "zoneid"), p)
+}
+
+// Renders a virtual machine the way the KCPS async jobs return it: the numbers are strings
+// and the creation time is in epoch millis.
+func (s *Server) jobVirtualMachine(vm object) object {
+	r := s.render(vm)
+	for _, k := range []string{"cpunumber", "cpuspeed", "rootdeviceid"} {
+		if v, ok := r[k]; ok {
+			r[k] = fmt.Sprint(v)
+		}
+	}
+	if t, ok := vm["_created"].(time.Time); ok {
+		r["created"] = t.UnixNano() / int64(time.Millisecond)
+	}
+	if pw, ok := vm["_password"].(string); ok {
+		r["password"] = pw
+		delete(vm, "_password")
+	}
+	return object{"virtualmachine": r}
+}
+
+func deployValueVirtualMachine(s *Server, p url.Values) (interface{}, error) {
+	offering, err := s.mustGet(kindServiceOffering, p.Get("serviceofferingid"), "serviceofferingid")
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.mustGet(kindTemplate, p.Get("templateid"), "templateid")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := s.mustGet(kindZone, p.Get("zoneid"), "zoneid")
+	if err != nil {
+		return nil, err
+	}
+
+	networkID := p.Get("iptonetworklist[0].networkid")
+	if networkID == "" {
+		networkID = p.Get("networkids")
+	}
+	if networkID == "" {
+		networkID = s.NetworkID
+	}
+	network, err := s.mustGet(kindNetwork, networkID, "networkids")
+	if err != nil {
+		return nil, err
+	}
+
+	name := p.Get("name")
+	if name == "" {
+		name = "VM-" + newID()[:8]
+	}
+	for _, vm := range s.objects[kindVirtualMachine] {
+		if vm.str("name") == name {
+			return nil, errParam("A virtual machine with name %s already exists", name)
+		}
+	}
+
+	s.nextIP++
+	ip := p.Get("iptonetworklist[0].ip")
+	if ip == "" {
+		ip = fmt.Sprintf("10.0.%d.%d", s.nextIP/250, s.nextIP%250+2)
+	}
+
+	vm := s.add(kindVirtualMachine, object{
+		"name":                name,
+		"displayname":         name,
+		"state":               "Starting",
+		"zoneid":              zone.id(),
+		"zonename":            zone["name"],
+		"templateid":          template.id(),
+		"templatename":        template["name"],
+		"templatedisplaytext": template["displaytext"],
+		"serviceofferingid":   offering.id(),
+		"serviceofferingname": offering["name"],
+		"cpunumber":           offering["cpunumber"],
+		"cpuspeed":            offering["cpuspeed"],
+		"memory":              offering["memory"],
+		"hypervisor":          "VMware",
+		"haenable":            false,
+		"passwordenabled":     true,
+		"rootdeviceid":        0,
+		"rootdevicetype":      "ROOT",
+		"guestosid":           newID(),
+		"nic": []object{{
+			"id":           newID(),
+			"networkid":    network.id(),
+			"networkname":  network["name"],
+			"ipaddress":    ip,
+			"gateway":      network["gateway"],
+			"netmask":      network["netmask"],
+			"isdefault":    true,
+			"type":         "Shared",
+			"traffictype":  "Guest",
+			"macaddress":   fmt.Sprintf("06:00:00:00:%02x:%02x", s.nextIP/256, s.nextIP%256),
+			"broadcasturi": "vlan://untagged",
+		}},
+		"_password": "pw-" + newID()[:8],
+	})
+	s.add(kindVolume, object{
+		"name":             "ROOT-" + name,
+		"type":             "ROOT",
+		"state":            "Ready",
+		"size":             template["size"],
+		"zoneid":           zone.id(),
+		"zonename":         zone["name"],
+		"virtualmachineid": vm.id(),
+		"vmname":           name,
+		"vmdisplayname":    name,
+		"vmstate":          "Running",
+		"deviceid":         0,
+		"storagetype":      "shared",
+	})
+
+	return s.startJob("vm.DeployVMCmd", true, "VirtualMachine", vm.id(), func() (interface{}, error) {
+		s.setVMState(vm, "Running")
+		return s.jobVirtualMachine(vm), nil
+	})
+}
+
+// Sets the state of a virtual machine and of the volumes attached to it.
+func (s *Server) setVMState(vm object, state string) {
+	vm["state"] = state
+	for _, v := range s.objects[kindVolume] {
+		if v.str("virtualmachineid") == vm.id() {
+			v["vmstate"] = state
+		}
+	}
+}
+
+func listVirtualMachines(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("virtualmachine", s.find(kindVirtualMachine, p, "id", "name", "state", "zoneid", "templateid"), p)
+}
+
+// Starts a job changing the state of a virtual machine.
+func (s *Server) vmStateJob(p url.Values, cmd string, ex bool, from []string, transient, final string) (interface{}, error) {
+	vm, err := s.mustGet(kindVirtualMachine, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	allowed := false
+	for _, state := range from {
+		allowed = allowed || vm.str("state") == state
+	}
+	if !allowed {
+		return nil, errParam("Unable to execute the command, as the virtual machine %s is in state %s", vm.id(), vm.str("state"))
+	}
+
+	s.setVMState(vm, transient)
+	return s.startJob(cmd, ex, "VirtualMachine", vm.id(), func() (interface{}, error) {
+		s.setVMState(vm, final)
+		return s.jobVirtualMachine(vm), nil
+	})
+}
+
+func startVirtualMachine(s *Server, p url.Values) (interface{}, error) {
+	return s.vmStateJob(p, "vm.StartVMCmd", true, []string{"Stopped", "Running"}, "Starting", "Running")
+}
+
+func stopVirtualMachine(s *Server, p url.Values) (interface{}, error) {
+	return s.vmStateJob(p, "vm.StopVMCmd", false, []string{"Running", "Stopped"}, "Stopping", "Stopped")
+}
+
+func rebootVirtualMachine(s *Server, p url.Values) (interface{}, error) {
+	return s.vmStateJob(p, "vm.RebootVMCmd", false, []string{"Running"}, "Starting", "Running")
+}
+
+func resetPasswordForVirtualMachine(s *Server, p url.Values) (interface{}, error) {
+	vm, err := s.mustGet(kindVirtualMachine, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	if vm.str("state") != "Stopped" {
+		return nil, errParam("Unable to reset the password, as the virtual machine %s is not stopped", vm.id())
+	}
+	return s.startJob("vm.ResetVMPasswordCmd", false, "VirtualMachine", vm.id(), func() (interface{}, error) {
+		vm["_password"] = "pw-" + newID()[:8]
+		return s.jobVirtualMachine(vm), nil
+	})
+}
+
+func destroyVirtualMachine(s *Server, p url.Values) (interface{}, error) {
+	vm, err := s.mustGet(kindVirtualMachine, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	s.setVMState(vm, "Stopping")
+	return s.startJob("vm.DestroyVMCmd", false, "VirtualMachine", vm.id(), func() (interface{}, error) {
+		for _, v := range append([]object(nil), s.objects[kindVolume]...) {
+			if v.str("virtualmachineid") != vm.id() {
+				continue
+			}
+			if v.str("type") == "ROOT" {
+				s.remove(kindVolume, v.id())
+			} else {
+				detach(v)
+			}
+		}
+		for _, lb := range s.objects[kindLoadBalancer] {
+			lb["_vms"] = without(lb["_vms"], vm.id())
+		}
+		s.remove(kindVirtualMachine, vm.id())
+		vm["state"] = "Destroyed"
+		return s.jobVirtualMachine(vm), nil
+	})
+}
+
+func createVolume(s *Server, p url.Values) (interface{}, error) {
+	name := p.Get("name")
+	if name == "" {
+		return nil, errMissing("name")
+	}
+	zoneID := p.Get("zoneid")
+	if zoneID == "" {
+		zoneID = s.ZoneID
+	}
+	zone, err := s.mustGet(kindZone, zoneID, "zoneid")
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	if id := p.Get("diskofferingid"); id != "" {
+		offering, err := s.mustGet(kindDiskOffering, id, "diskofferingid")
+		if err != nil {
+			return nil, err
+		}
+		if gb, ok := offering["disksize"].(int); ok {
+			size = int64(gb) << 30
+		}
+	}
+	if v := p.Get("size"); v != "" {
+		gb, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || gb < 1 {
+			return nil, errParam("Invalid value %s for parameter size", v)
+		}
+		size = gb << 30
+	}
+	if size == 0 {
+		return nil, errMissing("size")
+	}
+
+	v := s.add(kindVolume, object{
+		"name":           name,
+		"type":           "DATADISK",
+		"state":          "Allocated",
+		"size":           size,
+		"zoneid":         zone.id(),
+		"zonename":       zone["name"],
+		"diskofferingid": p.Get("diskofferingid"),
+		"storagetype":    "shared",
+	})
+	return s.startJob("volume.CreateVolumeCmd", false, "Volume", v.id(), func() (interface{}, error) {
+		return object{"volume": s.render(v)}, nil
+	})
+}
+
+func listVolumes(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("volume", s.find(kindVolume, p, "id", "name", "virtualmachineid", "type", "zoneid"), p)
+}
+
+func attachVolume(s *Server, p url.Values) (interface{}, error) {
+	v, err := s.mustGet(kindVolume, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	vm, err := s.mustGet(kindVirtualMachine, p.Get("virtualmachineid"), "virtualmachineid")
+	if err != nil {
+		return nil, err
+	}
+	if v.str("virtualmachineid") != "" {
+		return nil, errParam("Volume %s is already attached to a virtual machine", v.id())
+	}
+
+	return s.startJob("volume.AttachVolumeCmd", false, "Volume", v.id(), func() (interface{}, error) {
+		deviceID := 1
+		for _, other := range s.objects[kindVolume] {
+			if other.str("virtualmachineid") == vm.id() {
+				if id, _ := other["deviceid"].(int); id >= deviceID {
+					deviceID = id + 1
+				}
+			}
+		}
+		v["virtualmachineid"] = vm.id()
+		v["vmname"] = vm["name"]
+		v["vmdisplayname"] = vm["displayname"]
+		v["vmstate"] = vm["state"]
+		v["deviceid"] = deviceID
+		v["state"] = "Ready"
+		v["attached"] = timestamp(time.Now())
+		return object{"volume": s.render(v)}, nil
+	})
+}
+
+// Detaches a volume from its virtual machine.
+func detach(v object) {
+	for _, k := range []string{"virtualmachineid", "vmname", "vmdisplayname", "vmstate", "deviceid", "attached"} {
+		delete(v, k)
+	}
+}
+
+func detachVolume(s *Server, p url.Values) (interface{}, error) {
+	v, err := s.mustGet(kindVolume, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	if v.str("virtualmachineid") == "" {
+		return nil, errParam("Volume %s is not attached to a virtual machine", v.id())
+	}
+	if v.str("type") == "ROOT" {
+		return nil, errParam("Unable to detach the root volume %s", v.id())
+	}
+
+	return s.startJob("volume.DetachVolumeCmd", false, "Volume", v.id(), func() (interface{}, error) {
+		detach(v)
+		return object{"volume": s.render(v)}, nil
+	})
+}
+
+func resizeVolume(s *Server, p url.Values) (interface{}, error) {
+	v, err := s.mustGet(kindVolume, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	gb, err := strconv.ParseInt(p.Get("size"), 10, 64)
+	if err != nil || gb < 1 {
+		return nil, errParam("Invalid value %s for parameter size", p.Get("size"))
+	}
+	if size, _ := v["size"].(int64); gb<<30 < size {
+		return nil, errParam("Shrinking volume %s is not supported", v.id())
+	}
+
+	return s.startJob("volume.ResizeVolumeCmd", false, "Volume", v.id(), func() (interface{}, error) {
+		v["size"] = gb << 30
+		return object{"volume": s.render(v)}, nil
+	})
+}
+
+func deleteVolume(s *Server, p url.Values) (interface{}, error) {
+	v, err := s.mustGet(kindVolume, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	if v.str("virtualmachineid") != "" {
+		return nil, errParam("Unable to delete volume %s, as it is attached to a virtual machine", v.id())
+	}
+	s.remove(kindVolume, v.id())
+	return syncSuccess, nil
+}
+
+func createSnapshot(s *Server, p url.Values) (interface{}, error) {
+	v, err := s.mustGet(kindVolume, p.Get("volumeid"), "volumeid")
+	if err != nil {
+		return nil, err
+	}
+
+	snap := s.add(kindSnapshot, object{
+		"name":         fmt.Sprintf("%s_%s_%s", v.str("vmname"), v.str("name"), time.Now().Format("20060102150405")),
+		"volumeid":     v.id(),
+		"volumename":   v["name"],
+		"volumetype":   v["type"],
+		"zoneid":       v["zoneid"],
+		"state":        "Creating",
+		"snapshottype": "MANUAL",
+		"intervaltype": "MANUAL",
+		"physicalsize": v["size"],
+		"revertable":   false,
+	})
+	return s.startJob("snapshot.CreateSnapshotCmd", false, "Snapshot", snap.id(), func() (interface{}, error) {
+		snap["state"] = "BackedUp"
+		return object{"snapshot": s.render(snap)}, nil
+	})
+}
+
+func listSnapshots(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("snapshot", s.find(kindSnapshot, p, "id", "name", "volumeid", "snapshottype", "zoneid"), p)
+}
+
+func deleteSnapshot(s *Server, p url.Values) (interface{}, error) {
+	snap, err := s.mustGet(kindSnapshot, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	return s.startJob("snapshot.DeleteSnapshotCmd", false, "Snapshot", snap.id(), func() (interface{}, error) {
+		s.remove(kindSnapshot, snap.id())
+		return asyncSuccess, nil
+	})
+}
+
+func associateIpAddress(s *Server, p url.Values) (interface{}, error) {
+	networkID := p.Get("networkid")
+	if networkID == "" {
+		networkID = s.NetworkID
+	}
+	network, err := s.mustGet(kindNetwork, networkID, "networkid")
+	if err != nil {
+		return nil, err
+	}
+
+	s.nextIP++
+	ip := s.add(kindPublicIP, object{
+		"ipaddress":             fmt.Sprintf("203.0.%d.%d", 113+s.nextIP/250, s.nextIP%250+2),
+		"state":                 "Allocating",
+		"associatednetworkid":   network.id(),
+		"associatednetworkname": network["name"],
+		"networkid":             network.id(),
+		"zoneid":                network["zoneid"],
+		"zonename":              network["zonename"],
+		"issourcenat":           false,
+		"isstaticnat":           false,
+		"issystem":              false,
+		"forvirtualnetwork":     true,
+		"allocated":             timestamp(time.Now()),
+	})
+	return s.startJob("address.AssociateIPAddrCmd", false, "IpAddress", ip.id(), func() (interface{}, error) {
+		ip["state"] = "Allocated"
+		return object{"ipaddress": s.render(ip)}, nil
+	})
+}
+
+func disassociateIpAddress(s *Server, p url.Values) (interface{}, error) {
+	ip, err := s.mustGet(kindPublicIP, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	ip["state"] = "Releasing"
+	return s.startJob("address.DisassociateIPAddrCmd", false, "IpAddress", ip.id(), func() (interface{}, error) {
+		for _, kind := range []string{kindFirewallRule, kindPortForwarding} {
+			for _, r := range append([]object(nil), s.objects[kind]...) {
+				if r.str("ipaddressid") == ip.id() {
+					s.remove(kind, r.id())
+				}
+			}
+		}
+		for _, r := range append([]object(nil), s.objects[kindLoadBalancer]...) {
+			if r.str("publicipid") == ip.id() {
+				s.remove(kindLoadBalancer, r.id())
+			}
+		}
+		s.remove(kindPublicIP, ip.id())
+		return asyncSuccess, nil
+	})
+}
+
+func listPublicIpAddresses(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("publicipaddress", s.find(kindPublicIP, p, "id", "ipaddress", "associatednetworkid", "isstaticnat", "zoneid"), p)
+}
+
+func enableStaticNat(s *Server, p url.Values) (interface{}, error) {
+	ip, err := s.mustGet(kindPublicIP, p.Get("ipaddressid"), "ipaddressid")
+	if err != nil {
+		return nil, err
+	}
+	vm, err := s.mustGet(kindVirtualMachine, p.Get("virtualmachineid"), "virtualmachineid")
+	if err != nil {
+		return nil, err
+	}
+	if ip["isstaticnat"] == true {
+		return nil, errParam("Static NAT is already enabled for IP address %s", ip.id())
+	}
+
+	ip["isstaticnat"] = true
+	ip["virtualmachineid"] = vm.id()
+	ip["virtualmachinename"] = vm["name"]
+	ip["virtualmachinedisplayname"] = vm["displayname"]
+	if nics, ok := vm["nic"].([]object); ok && len(nics) > 0 {
+		ip["vmipaddress"] = nics[0]["ipaddress"]
+	}
+	return syncSuccess, nil
+}
+
+func disableStaticNat(s *Server, p url.Values) (interface{}, error) {
+	ip, err := s.mustGet(kindPublicIP, p.Get("ipaddressid"), "ipaddressid")
+	if err != nil {
+		return nil, err
+	}
+	if ip["isstaticnat"] != true {
+		return nil, errParam("Static NAT is not enabled for IP address %s", ip.id())
+	}
+	return s.startJob("nat.DisableStaticNatCmd", false, "IpAddress", ip.id(), func() (interface{}, error) {
+		ip["isstaticnat"] = false
+		for _, k := range []string{"virtualmachineid", "virtualmachinename", "virtualmachinedisplayname", "vmipaddress"} {
+			delete(ip, k)
+		}
+		return asyncSuccess, nil
+	})
+}
+
+// Validates a port parameter, which is optional unless required is set.
+func port(p url.Values, name string, required bool) (string, error) {
+	v := p.Get(name)
+	if v == "" {
+		if required {
+			return "", errMissing(name)
+		}
+		return "", nil
+	}
+	if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+		return "", errParam("Invalid value %s for parameter %s", v, name)
+	}
+	return v, nil
+}
+
+func createFirewallRule(s *Server, p url.Values) (interface{}, error) {
+	ip, err := s.mustGet(kindPublicIP, p.Get("ipaddressid"), "ipaddressid")
+	if err != nil {
+		return nil, err
+	}
+	protocol := strings.ToLower(p.Get("protocol"))
+	if protocol == "" {
+		return nil, errMissing("protocol")
+	}
+
+	rule := object{
+		"ipaddressid": ip.id(),
+		"ipaddress":   ip["ipaddress"],
+		"networkid":   ip["associatednetworkid"],
+		"protocol":    protocol,
+		"cidrlist":    p.Get("cidrlist"),
+		"state":       "Staged",
+	}
+	if rule["cidrlist"] == "" {
+		rule["cidrlist"] = "0.0.0.0/0"
+	}
+	if protocol == "tcp" || protocol == "udp" {
+		start, err := port(p, "startport", true)
+		if err != nil {
+			return nil, err
+		}
+		end, err := port(p, "endport", false)
+		if err != nil {
+			return nil, err
+		}
+		if end == "" {
+			end = start
+		}
+		// KCPS returns the ports as strings
+		rule["startport"] = start
+		rule["endport"] = end
+	}
+	s.add(kindFirewallRule, rule)
+
+	return s.startJob("firewall.CreateFirewallRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		rule["state"] = "Active"
+		return object{"firewallrule": s.render(rule)}, nil
+	})
+}
+
+func deleteFirewallRule(s *Server, p url.Values) (interface{}, error) {
+	rule, err := s.mustGet(kindFirewallRule, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	rule["state"] = "Revoke"
+	return s.startJob("firewall.DeleteFirewallRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		s.remove(kindFirewallRule, rule.id())
+		return asyncSuccess, nil
+	})
+}
+
+func listFirewallRules(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("firewallrule", s.find(kindFirewallRule, p, "id", "ipaddressid", "networkid"), p)
+}
+
+func createPortForwardingRule(s *Server, p url.Values) (interface{}, error) {
+	ip, err := s.mustGet(kindPublicIP, p.Get("ipaddressid"), "ipaddressid")
+	if err != nil {
+		return nil, err
+	}
+	vm, err := s.mustGet(kindVirtualMachine, p.Get("virtualmachineid"), "virtualmachineid")
+	if err != nil {
+		return nil, err
+	}
+	protocol := strings.ToLower(p.Get("protocol"))
+	if protocol != "tcp" && protocol != "udp" {
+		return nil, errParam("Invalid value %s for parameter protocol", p.Get("protocol"))
+	}
+
+	rule := object{
+		"ipaddressid":               ip.id(),
+		"ipaddress":                 ip["ipaddress"],
+		"networkid":                 ip["associatednetworkid"],
+		"protocol":                  protocol,
+		"virtualmachineid":          vm.id(),
+		"virtualmachinename":        vm["name"],
+		"virtualmachinedisplayname": vm["displayname"],
+		"cidrlist":                  "",
+		"state":                     "Staged",
+	}
+	for _, k := range []string{"privateport", "publicport"} {
+		v, err := port(p, k, true)
+		if err != nil {
+			return nil, err
+		}
+		rule[k] = v
+		rule[strings.TrimSuffix(k, "port")+"endport"] = v
+	}
+	for _, k := range []string{"privateendport", "publicendport"} {
+		v, err := port(p, k, false)
+		if err != nil {
+			return nil, err
+		}
+		if v != "" {
+			rule[k] = v
+		}
+	}
+	rule["vmguestip"] = p.Get("vmguestip")
+	if nics, ok := vm["nic"].([]object); ok && len(nics) > 0 && rule["vmguestip"] == "" {
+		rule["vmguestip"] = nics[0]["ipaddress"]
+	}
+	s.add(kindPortForwarding, rule)
+
+	return s.startJob("firewall.CreatePortForwardingRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		rule["state"] = "Active"
+		return object{"portforwardingrule": s.render(rule)}, nil
+	})
+}
+
+func deletePortForwardingRule(s *Server, p url.Values) (interface{}, error) {
+	rule, err := s.mustGet(kindPortForwarding, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	rule["state"] = "Revoke"
+	return s.startJob("firewall.DeletePortForwardingRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		s.remove(kindPortForwarding, rule.id())
+		return asyncSuccess, nil
+	})
+}
+
+func listPortForwardingRules(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("portforwardingrule", s.find(kindPortForwarding, p, "id", "ipaddressid", "networkid"), p)
+}
+
+func createLoadBalancerRule(s *Server, p url.Values) (interface{}, error) {
+	name := p.Get("name")
+	if name == "" {
+		return nil, errMissing("name")
+	}
+	algorithm := p.Get("algorithm")
+	switch algorithm {
+	case "roundrobin", "leastconn", "source":
+	case "":
+		return nil, errMissing("algorithm")
+	default:
+		return nil, errParam("Invalid value %s for parameter algorithm", algorithm)
+	}
+	ip, err := s.mustGet(kindPublicIP, p.Get("publicipid"), "publicipid")
+	if err != nil {
+		return nil, err
+	}
+	private, err := port(p, "privateport", true)
+	if err != nil {
+		return nil, err
+	}
+	public, err := port(p, "publicport", true)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := s.add(kindLoadBalancer, object{
+		"name":        name,
+		"algorithm":   algorithm,
+		"publicipid":  ip.id(),
+		"publicip":    ip["ipaddress"],
+		"networkid":   ip["associatednetworkid"],
+		"zoneid":      ip["zoneid"],
+		"privateport": private,
+		"publicport":  public,
+		"protocol":    "tcp",
+		"cidrlist":    "",
+		"state":       "Add",
+		"_vms":        []string{},
+	})
+	return s.startJob("loadbalancer.CreateLoadBalancerRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		rule["state"] = "Active"
+		return object{"loadbalancer": s.render(rule)}, nil
+	})
+}
+
+func deleteLoadBalancerRule(s *Server, p url.Values) (interface{}, error) {
+	rule, err := s.mustGet(kindLoadBalancer, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	rule["state"] = "Revoke"
+	return s.startJob("loadbalancer.DeleteLoadBalancerRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		s.remove(kindLoadBalancer, rule.id())
+		return asyncSuccess, nil
+	})
+}
+
+func listLoadBalancerRules(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("loadbalancerrule", s.find(kindLoadBalancer, p, "id", "name", "publicipid", "zoneid"), p)
+}
+
+// Returns the virtual machines referred to by the virtualmachineids param.
+func (s *Server) virtualMachines(p url.Values) ([]string, error) {
+	var ids []string
+	for _, id := range strings.Split(p.Get("virtualmachineids"), ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if _, err := s.mustGet(kindVirtualMachine, id, "virtualmachineids"); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errMissing("virtualmachineids")
+	}
+	return ids, nil
+}
+
+// Returns the ids without id.
+func without(ids interface{}, id string) []string {
+	var r []string
+	for _, v := range ids.([]string) {
+		if v != id {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+func assignToLoadBalancerRule(s *Server, p url.Values) (interface{}, error) {
+	rule, err := s.mustGet(kindLoadBalancer, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.virtualMachines(p)
+	if err != nil {
+		return nil, err
+	}
+	return s.startJob("loadbalancer.AssignToLoadBalancerRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		vms := rule["_vms"].([]string)
+		for _, id := range ids {
+			vms = append(without(vms, id), id)
+		}
+		rule["_vms"] = vms
+		return asyncSuccess, nil
+	})
+}
+
+func removeFromLoadBalancerRule(s *Server, p url.Values) (interface{}, error) {
+	rule, err := s.mustGet(kindLoadBalancer, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.virtualMachines(p)
+	if err != nil {
+		return nil, err
+	}
+	return s.startJob("loadbalancer.RemoveFromLoadBalancerRuleCmd", false, "FirewallRule", rule.id(), func() (interface{}, error) {
+		for _, id := range ids {
+			rule["_vms"] = without(rule["_vms"], id)
+		}
+		return asyncSuccess, nil
+	})
+}
+
+func listLoadBalancerRuleInstances(s *Server, p url.Values) (interface{}, error) {
+	rule, err := s.mustGet(kindLoadBalancer, p.Get("id"), "id")
+	if err != nil {
+		return nil, err
+	}
+	var list []interface{}
+	for _, id := range rule["_vms"].([]string) {
+		if vm := s.get(kindVirtualMachine, id); vm != nil {
+			list = append(list, s.render(vm))
+		}
+	}
+	return listResponse("loadbalancerruleinstance", list, p)
+}
+
+// Returns the key/value pairs of the tags[n].key and tags[n].value params.
+func tagParams(p url.Values) [][2]string {
+	var tags [][2]string
+	for i := 0; ; i++ {
+		key, ok := p[fmt.Sprintf("tags[%d].key", i)]
+		if !ok {
+			return tags
+		}
+		tags = append(tags, [2]string{key[0], p.Get(fmt.Sprintf("tags[%d].value", i))})
+	}
+}
+
+// Returns the kind and the IDs of the resources referred to by the resourcetype and
+// resourceids params.
+func (s *Server) tagResources(p url.Values) (string, []string, error) {
+	rt := p.Get("resourcetype")
+	if rt == "" {
+		return "", nil, errMissing("resourcetype")
+	}
+	kind, ok := resourceTypes[strings.ToLower(rt)]
+	if !ok {
+		return "", nil, errParam("Invalid value %s for parameter resourcetype", rt)
+	}
+
+	var ids []string
+	for _, id := range strings.Split(p.Get("resourceids"), ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if _, err := s.mustGet(kind, id, "resourceids"); err != nil {
+			return "", nil, err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return "", nil, errMissing("resourceids")
+	}
+	return rt, ids, nil
+}
+
+func createTags(s *Server, p url.Values) (interface{}, error) {
+	rt, ids, err := s.tagResources(p)
+	if err != nil {
+		return nil, err
+	}
+	tags := tagParams(p)
+	if len(tags) == 0 {
+		return nil, errMissing("tags")
+	}
+	for _, id := range ids {
+		for _, t := range tags {
+			for _, existing := range s.objects[kindTag] {
+				if existing.str("resourceid") == id && existing.str("key") == t[0] {
+					return nil, errParam("Tag %s already exists for resource %s", t[0], id)
+				}
+			}
+		}
+	}
+
+	return s.startJob("tag.CreateTagsCmd", false, "", "", func() (interface{}, error) {
+		for _, id := range ids {
+			for _, t := range tags {
+				s.add(kindTag, object{
+					"key":          t[0],
+					"value":        t[1],
+					"resourceid":   id,
+					"resourcetype": rt,
+				})
+			}
+		}
+		return asyncSuccess, nil
+	})
+}
+
+func deleteTags(s *Server, p url.Values) (interface{}, error) {
+	_, ids, err := s.tagResources(p)
+	if err != nil {
+		return nil, err
+	}
+	tags := tagParams(p)
+
+	return s.startJob("tag.DeleteTagsCmd", false, "", "", func() (interface{}, error) {
+		for _, id := range ids {
+			for _, t := range append([]object(nil), s.objects[kindTag]...) {
+				if t.str("resourceid") != id {
+					continue
+				}
+				match := len(tags) == 0
+				for _, kv := range tags {
+					match = match || (t.str("key") == kv[0] && (kv[1] == "" || t.str("value") == kv[1]))
+				}
+				if match {
+					s.remove(kindTag, t.id())
+				}
+			}
+		}
+		return asyncSuccess, nil
+	})
+}
+
+func listTags(s *Server, p url.Values) (interface{}, error) {
+	return listResponse("tag", s.find(kindTag, p, "resourceid", "resourcetype", "key", "value"), p)
+}
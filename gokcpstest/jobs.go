@@ -0,0 +1,163 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcpstest
+
+import (
+	"net/url"
+	"time"
+)
+
+// Time zone of the dates without one in the parameters, like the startdate of listAsyncJobs
+var jst = time.FixedZone("JST", 9*60*60)
+
+// An async job. The changes made by a job are applied once it finishes.
+type job struct {
+	id           string
+	cmd          string
+	ex           bool // Only visible through queryExAsyncJobResult, like the KDDI specific jobs
+	instanceType string
+	instanceID   string
+	created      time.Time
+	due          time.Time
+	run          func() (interface{}, error)
+
+	status     int // 0 pending, 1 succeeded, 2 failed
+	resultCode int
+	result     interface{}
+}
+
+// Starts an async job which calls run once it is due, and returns the response of the
+// command that started it.
+func (s *Server) startJob(cmd string, ex bool, instanceType, instanceID string, run func() (interface{}, error)) (interface{}, error) {
+	now := time.Now()
+	j := &job{
+		id:           newID(),
+		cmd:          "org.apache.cloudstack.api.command.user." + cmd,
+		ex:           ex,
+		instanceType: instanceType,
+		instanceID:   instanceID,
+		created:      now,
+		due:          now.Add(s.jobDelay),
+		run:          run,
+	}
+
+	if fails := s.jobFails[s.command]; len(fails) > 0 {
+		s.jobFails[s.command] = fails[1:]
+		text := fails[0]
+		j.run = func() (interface{}, error) {
+			return nil, &apiError{status: 530, code: 530, cscode: 4250, text: text}
+		}
+	}
+
+	s.jobs = append(s.jobs, j)
+	res := object{"jobid": j.id}
+	if instanceID != "" {
+		res["id"] = instanceID
+	}
+	return res, nil
+}
+
+// Finishes all jobs that are due.
+func (s *Server) settleJobs() {
+	now := time.Now()
+	for _, j := range s.jobs {
+		if j.status != 0 || now.Before(j.due) {
+			continue
+		}
+		res, err := j.run()
+		if err != nil {
+			e, ok := err.(*apiError)
+			if !ok {
+				e = &apiError{code: 530, cscode: 4250, text: err.Error()}
+			}
+			j.status = 2
+			j.resultCode = 530
+			j.result = errorBody(e)
+			continue
+		}
+		j.status = 1
+		j.result = res
+	}
+}
+
+func (s *Server) findJob(id string, ex bool) *job {
+	for _, j := range s.jobs {
+		if j.id == id && j.ex == ex {
+			return j
+		}
+	}
+	return nil
+}
+
+func (j *job) object() object {
+	o := object{
+		"jobid":           j.id,
+		"cmd":             j.cmd,
+		"jobstatus":       j.status,
+		"jobprocstatus":   0,
+		"jobresultcode":   j.resultCode,
+		"jobinstancetype": j.instanceType,
+		"jobinstanceid":   j.instanceID,
+		"created":         timestamp(j.created),
+	}
+	if j.status != 0 {
+		o["jobresulttype"] = "object"
+		o["jobresult"] = j.result
+	}
+	return o
+}
+
+func queryAsyncJobResult(s *Server, p url.Values) (interface{}, error) {
+	return s.queryJob(p, false)
+}
+
+func queryExAsyncJobResult(s *Server, p url.Values) (interface{}, error) {
+	return s.queryJob(p, true)
+}
+
+func (s *Server) queryJob(p url.Values, ex bool) (interface{}, error) {
+	id := p.Get("jobid")
+	if id == "" {
+		return nil, errMissing("jobid")
+	}
+	j := s.findJob(id, ex)
+	if j == nil {
+		return nil, errNotFound("AsyncJob", id)
+	}
+	return j.object(), nil
+}
+
+func listAsyncJobs(s *Server, p url.Values) (interface{}, error) {
+	var start time.Time
+	if v := p.Get("startdate"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", v, jst)
+		if err != nil {
+			if t, err = time.ParseInLocation("2006-01-02", v, jst); err != nil {
+				return nil, errParam("Unable to parse date %s for parameter startdate", v)
+			}
+		}
+		start = t
+	}
+
+	var list []interface{}
+	for _, j := range s.jobs {
+		if !j.ex && !j.created.Before(start) {
+			list = append(list, j.object())
+		}
+	}
+	return listResponse("asyncjobs", list, p)
+}
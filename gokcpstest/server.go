@@ -0,0 +1,427 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package gokcpstest provides an in-process fake of the KCPS API for tests. The fake verifies
+// the request signatures and keeps virtual machines, volumes, snapshots, public IP addresses,
+// firewall, port forwarding and load balancer rules, tags and async jobs in memory. It mimics
+// the quirks of the KCPS API, like the string typed numbers and the epoch millis timestamps
+// in the virtual machine job results.
+package gokcpstest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The credentials accepted by a server created with NewServer
+const (
+	DefaultAPIKey    = "gokcpstest-api-key"
+	DefaultSecretKey = "gokcpstest-secret-key"
+	DefaultUsername  = "gokcpstest"
+	DefaultPassword  = "gokcpstest-password"
+)
+
+// Server is a fake KCPS API. Point a client at URL, using APIKey and SecretKey as credentials,
+// or log in with Username and Password.
+type Server struct {
+	*httptest.Server
+
+	APIKey    string
+	SecretKey string
+	Username  string
+	Password  string
+
+	// IDs of the zone, network, offerings and template every server starts with
+	ZoneID            string
+	NetworkID         string
+	ServiceOfferingID string
+	DiskOfferingID    string
+	TemplateID        string
+
+	mu       sync.Mutex
+	objects  map[string][]object // All objects by kind, in the order they were created
+	jobs     []*job
+	jobDelay time.Duration
+	failures map[string][]*apiError // Injected failures by command
+	jobFails map[string][]string    // Injected job failures by command
+	requests []Request
+	command  string // Lower case name of the command being handled
+	nextIP   int
+	nextNet  int               // Number of networks added with AddZone
+	offset   time.Duration     // Offset of the clock of the server from the local clock
+	sessions map[string]string // Session keys of the logged in sessions by JSESSIONID
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Command string
+	Method  string
+	Params  url.Values // The request parameters, without the apiKey and the signature parameters
+}
+
+// A single object, encoded the way the API returns it
+type object map[string]interface{}
+
+// NewServer starts a fake KCPS API. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    DefaultAPIKey,
+		SecretKey: DefaultSecretKey,
+		Username:  DefaultUsername,
+		Password:  DefaultPassword,
+		sessions:  make(map[string]string),
+		objects:   make(map[string][]object),
+		failures:  make(map[string][]*apiError),
+		jobFails:  make(map[string][]string),
+	}
+	s.seed()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// SetJobDelay sets how long async jobs are pending before they finish. The default is 0, so
+// jobs finish as soon as anyone looks at them.
+func (s *Server) SetJobDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobDelay = d
+}
+
+// SetClockOffset makes the clock of the server run ahead of the local clock by d, or behind it
+// when d is negative. The clock is used to check the expiry of signatures and for the Date
+// header of the responses.
+func (s *Server) SetClockOffset(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset = d
+}
+
+// Returns the current time of the server.
+func (s *Server) now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Add(s.offset)
+}
+
+// AddZone adds a zone with the given name and a PublicFrontSegment network in it, and returns
+// the IDs of both. The offerings and the template of the server can be used in every zone.
+func (s *Server) AddZone(name string) (zoneID, networkID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone := s.add(kindZone, object{
+		"name":                name,
+		"networktype":         "Advanced",
+		"allocationstate":     "Enabled",
+		"localstorageenabled": false,
+	})
+	s.nextNet++
+	network := s.add(kindNetwork, object{
+		"name":        "PublicFrontSegment",
+		"displaytext": "PublicFrontSegment",
+		"zoneid":      zone.id(),
+		"zonename":    name,
+		"state":       "Implemented",
+		"type":        "Shared",
+		"cidr":        fmt.Sprintf("10.%d.0.0/16", s.nextNet+1),
+		"gateway":     fmt.Sprintf("10.%d.0.1", s.nextNet+1),
+		"netmask":     "255.255.0.0",
+	})
+	return zone.id(), network.id()
+}
+
+// ExpireSessions ends all sessions, as if they timed out.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]string)
+}
+
+// FailNext makes the next call of command fail with the given HTTP status, API error code and
+// error text.
+func (s *Server) FailNext(command string, status int, errorcode int, errortext string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	command = strings.ToLower(command)
+	s.failures[command] = append(s.failures[command], &apiError{status: status, code: errorcode, text: errortext})
+}
+
+// FailNextJob makes the async job started by the next call of command fail with errortext.
+func (s *Server) FailNextJob(command string, errortext string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	command = strings.ToLower(command)
+	s.jobFails[command] = append(s.jobFails[command], errortext)
+}
+
+// Requests returns all requests received so far with a valid signature.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Count returns the number of requests received so far for command.
+func (s *Server) Count(command string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.requests {
+		if strings.EqualFold(r.Command, command) {
+			n++
+		}
+	}
+	return n
+}
+
+// An error response of the API
+type apiError struct {
+	status int
+	code   int
+	cscode int
+	text   string
+}
+
+func (e *apiError) Error() string {
+	return e.text
+}
+
+func errParam(format string, a ...interface{}) *apiError {
+	return &apiError{status: 431, code: 431, cscode: 4350, text: fmt.Sprintf(format, a...)}
+}
+
+func errNotFound(kind, id string) *apiError {
+	return &apiError{status: 431, code: 431, cscode: 4350, text: fmt.Sprintf("Unable to execute API command due to invalid value. Object %s(uuid: %s) does not exist.", kind, id)}
+}
+
+func errMissing(name string) *apiError {
+	return errParam("Unable to execute API command due to missing parameter %s", name)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errorresponse": errorBody(errParam("%s", err.Error()))})
+		return
+	}
+	params := r.Form
+	command := params.Get("command")
+	now := s.now()
+	w.Header().Set("Date", now.UTC().Format(http.TimeFormat))
+	key := strings.ToLower(command) + "response"
+
+	switch strings.ToLower(command) {
+	case "login":
+		s.login(w, r, params)
+		return
+	case "logout":
+		s.logout(w, r, params)
+		return
+	}
+
+	// Requests of logged in users carry a session key instead of a signature
+	if s.verifySession(r, params) != nil {
+		if err := s.verify(params, now); err != nil {
+			writeJSON(w, err.status, map[string]interface{}{key: errorBody(err)})
+			return
+		}
+	}
+
+	p := make(url.Values, len(params))
+	for k, v := range params {
+		switch strings.ToLower(k) {
+		case "apikey", "signature", "command", "response", "signatureversion", "expires", "sessionkey":
+			continue
+		}
+		p[k] = v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Command: command, Method: r.Method, Params: p})
+	s.settleJobs()
+
+	lc := strings.ToLower(command)
+	if fails := s.failures[lc]; len(fails) > 0 {
+		s.failures[lc] = fails[1:]
+		writeJSON(w, fails[0].status, map[string]interface{}{key: errorBody(fails[0])})
+		return
+	}
+
+	h, ok := handlers[lc]
+	if !ok {
+		err := &apiError{status: 432, code: 432, cscode: 9999, text: "The given command does not exist or it is not available for user"}
+		writeJSON(w, err.status, map[string]interface{}{key: errorBody(err)})
+		return
+	}
+
+	s.command = lc
+	res, err := h(s, p)
+	if err != nil {
+		e, ok := err.(*apiError)
+		if !ok {
+			e = &apiError{status: 530, code: 530, cscode: 4250, text: err.Error()}
+		}
+		writeJSON(w, e.status, map[string]interface{}{key: errorBody(e)})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{key: res})
+}
+
+// Verifies the credentials and the signature exactly the way the client computes them, and
+// the expiry of signatures with version 3.
+func (s *Server) verify(params url.Values, now time.Time) *apiError {
+	unauthorized := &apiError{status: 401, code: 401, cscode: 9999, text: "unable to verify user credentials and/or request signature"}
+	if params.Get("apiKey") != s.APIKey {
+		return unauthorized
+	}
+
+	signature := params.Get("signature")
+	if signature == "" {
+		return unauthorized
+	}
+	v := make(url.Values, len(params))
+	for k, vs := range params {
+		if k != "signature" {
+			v[k] = vs
+		}
+	}
+
+	str := strings.Replace(strings.ToLower(encodeValues(v)), "+", "%20", -1)
+	mac := hmac.New(sha1.New, []byte(s.SecretKey))
+	mac.Write([]byte(str))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return unauthorized
+	}
+
+	if params.Get("signatureVersion") == "3" {
+		expires, err := time.Parse("2006-01-02T15:04:05-0700", params.Get("expires"))
+		if err != nil || now.After(expires) {
+			return unauthorized
+		}
+	}
+	return nil
+}
+
+// Starts a session for a user logging in with the username and password of the server.
+func (s *Server) login(w http.ResponseWriter, r *http.Request, params url.Values) {
+	if r.Method != http.MethodPost || params.Get("username") != s.Username || params.Get("password") != s.Password {
+		err := &apiError{status: 401, code: 401, cscode: 9999, text: "unable to verify user credentials"}
+		writeJSON(w, err.status, map[string]interface{}{"loginresponse": errorBody(err)})
+		return
+	}
+
+	id, key := newID(), newID()
+	s.mu.Lock()
+	s.sessions[id] = key
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: id, Path: "/", HttpOnly: true})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"loginresponse": map[string]interface{}{
+		"username":   s.Username,
+		"sessionkey": key,
+		"timeout":    "1800",
+	}})
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request, params url.Values) {
+	if err := s.verifySession(r, params); err != nil {
+		writeJSON(w, err.status, map[string]interface{}{"logoutresponse": errorBody(err)})
+		return
+	}
+	c, _ := r.Cookie("JSESSIONID")
+	s.mu.Lock()
+	delete(s.sessions, c.Value)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"logoutresponse": map[string]interface{}{"description": "success"}})
+}
+
+// Verifies the session cookie and the session key of a logged in user.
+func (s *Server) verifySession(r *http.Request, params url.Values) *apiError {
+	unauthorized := &apiError{status: 401, code: 401, cscode: 9999, text: "unable to verify user credentials"}
+	c, err := r.Cookie("JSESSIONID")
+	if err != nil {
+		return unauthorized
+	}
+	s.mu.Lock()
+	key, ok := s.sessions[c.Value]
+	s.mu.Unlock()
+	if !ok || params.Get("sessionkey") != key {
+		return unauthorized
+	}
+	return nil
+}
+
+// Same as the encoding used by the client to compute the signature
+func encodeValues(v url.Values) string {
+	var buf bytes.Buffer
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, vv := range v[k] {
+			if buf.Len() > 0 {
+				buf.WriteByte('&')
+			}
+			buf.WriteString(k + "=")
+			buf.WriteString(url.QueryEscape(vv))
+		}
+	}
+	return buf.String()
+}
+
+func errorBody(e *apiError) map[string]interface{} {
+	return map[string]interface{}{
+		"uuidList":    []string{},
+		"errorcode":   e.code,
+		"cserrorcode": e.cscode,
+		"errortext":   e.text,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Formats a timestamp the way the API does
+func timestamp(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05-0700")
+}
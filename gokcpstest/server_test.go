@@ -0,0 +1,239 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcpstest
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Sends a GET request for command to s, signed with secret, and returns the status and the
+// value wrapped in the response envelope.
+func call(t *testing.T, s *Server, secret string, command string, params url.Values) (int, map[string]interface{}) {
+	t.Helper()
+
+	v := url.Values{}
+	for k, vs := range params {
+		v[k] = vs
+	}
+	v.Set("command", command)
+	v.Set("response", "json")
+	v.Set("apiKey", s.APIKey)
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(strings.Replace(strings.ToLower(encodeValues(v)), "+", "%20", -1)))
+	v.Set("signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	resp, err := http.Get(s.URL + "?" + v.Encode())
+	if err != nil {
+		t.Fatalf("%s: %v", command, err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("%s: decoding the response: %v", command, err)
+	}
+	return resp.StatusCode, body[strings.ToLower(command)+"response"]
+}
+
+// Deploys a virtual machine and returns the ID of its job.
+func deploy(t *testing.T, s *Server, name string) string {
+	t.Helper()
+
+	status, r := call(t, s, s.SecretKey, "deployValueVirtualMachine", url.Values{
+		"serviceofferingid": {s.ServiceOfferingID},
+		"templateid":        {s.TemplateID},
+		"zoneid":            {s.ZoneID},
+		"name":              {name},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("deployValueVirtualMachine: status %d: %v", status, r)
+	}
+	return r["jobid"].(string)
+}
+
+// Queries the KDDI specific job with the given ID.
+func queryEx(t *testing.T, s *Server, jobID string) map[string]interface{} {
+	t.Helper()
+
+	status, r := call(t, s, s.SecretKey, "queryExAsyncJobResult", url.Values{"jobid": {jobID}})
+	if status != http.StatusOK {
+		t.Fatalf("queryExAsyncJobResult: status %d: %v", status, r)
+	}
+	return r
+}
+
+func TestSignature(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	tests := []struct {
+		name   string
+		secret string
+		want   int
+	}{
+		{name: "valid", secret: s.SecretKey, want: http.StatusOK},
+		{name: "wrong secret", secret: "wrong", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, r := call(t, s, tt.secret, "listZones", nil)
+			if status != tt.want {
+				t.Errorf("status = %d, want %d: %v", status, tt.want, r)
+			}
+		})
+	}
+
+	if n := s.Count("listZones"); n != 1 {
+		t.Errorf("recorded %d requests, want only the one with a valid signature", n)
+	}
+}
+
+func TestVirtualMachineJobResult(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	before := time.Now()
+	r := queryEx(t, s, deploy(t, s, "vm1"))
+	if r["jobstatus"] != float64(1) {
+		t.Fatalf("jobstatus = %v, want 1", r["jobstatus"])
+	}
+
+	vm := r["jobresult"].(map[string]interface{})["virtualmachine"].(map[string]interface{})
+	if v, ok := vm["cpunumber"].(string); !ok || v != "2" {
+		t.Errorf("cpunumber = %#v, want the string \"2\"", vm["cpunumber"])
+	}
+	ms, ok := vm["created"].(float64)
+	if !ok {
+		t.Fatalf("created = %#v, want epoch millis", vm["created"])
+	}
+	if created := time.UnixMilli(int64(ms)); created.Before(before.Truncate(time.Millisecond)) || created.After(time.Now()) {
+		t.Errorf("created = %s, want between %s and now", created, before)
+	}
+
+	// The job is only visible through queryExAsyncJobResult
+	if status, _ := call(t, s, s.SecretKey, "queryAsyncJobResult", url.Values{"jobid": {r["jobid"].(string)}}); status == http.StatusOK {
+		t.Error("queryAsyncJobResult found the KDDI specific job")
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.FailNext("listZones", 431, 431, "Unable to execute API command")
+	status, r := call(t, s, s.SecretKey, "listZones", nil)
+	if status != 431 || r["errortext"] != "Unable to execute API command" {
+		t.Errorf("first call: status %d: %v, want the injected failure", status, r)
+	}
+
+	status, r = call(t, s, s.SecretKey, "listZones", nil)
+	if status != http.StatusOK {
+		t.Errorf("second call: status %d: %v, want the failure to be used up", status, r)
+	}
+}
+
+func TestFailNextJob(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.FailNextJob("deployValueVirtualMachine", "Insufficient capacity")
+	r := queryEx(t, s, deploy(t, s, "vm1"))
+	if r["jobstatus"] != float64(2) {
+		t.Fatalf("jobstatus = %v, want 2", r["jobstatus"])
+	}
+	if text := r["jobresult"].(map[string]interface{})["errortext"]; text != "Insufficient capacity" {
+		t.Errorf("errortext = %v, want the injected one", text)
+	}
+
+	if r := queryEx(t, s, deploy(t, s, "vm2")); r["jobstatus"] != float64(1) {
+		t.Errorf("jobstatus of the next job = %v, want 1", r["jobstatus"])
+	}
+}
+
+func TestSetJobDelay(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.SetJobDelay(50 * time.Millisecond)
+
+	id := deploy(t, s, "vm1")
+	if r := queryEx(t, s, id); r["jobstatus"] != float64(0) {
+		t.Fatalf("jobstatus = %v right after the deploy, want 0", r["jobstatus"])
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if r := queryEx(t, s, id); r["jobstatus"] != float64(1) {
+		t.Errorf("jobstatus = %v after the delay, want 1", r["jobstatus"])
+	}
+}
+
+func TestSession(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, err := http.PostForm(s.URL, url.Values{
+		"command":  {"login"},
+		"response": {"json"},
+		"username": {s.Username},
+		"password": {s.Password},
+	})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Loginresponse struct {
+			Sessionkey string `json:"sessionkey"`
+		} `json:"loginresponse"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	cookies := resp.Cookies()
+
+	get := func(command string) int {
+		v := url.Values{"command": {command}, "response": {"json"}, "sessionkey": {body.Loginresponse.Sessionkey}}
+		req, _ := http.NewRequest(http.MethodGet, s.URL+"?"+v.Encode(), nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := get("listZones"); status != http.StatusOK {
+		t.Errorf("listZones with the session: status %d", status)
+	}
+	s.ExpireSessions()
+	if status := get("listZones"); status != http.StatusUnauthorized {
+		t.Errorf("listZones with an expired session: status %d, want 401", status)
+	}
+}
@@ -0,0 +1,283 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcpstest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of the objects kept by the server
+const (
+	kindZone            = "zone"
+	kindNetwork         = "network"
+	kindServiceOffering = "serviceoffering"
+	kindDiskOffering    = "diskoffering"
+	kindTemplate        = "template"
+	kindVirtualMachine  = "virtualmachine"
+	kindVolume          = "volume"
+	kindSnapshot        = "snapshot"
+	kindPublicIP        = "publicipaddress"
+	kindFirewallRule    = "firewallrule"
+	kindPortForwarding  = "portforwardingrule"
+	kindLoadBalancer    = "loadbalancerrule"
+	kindTag             = "tag"
+)
+
+// The resource types accepted by the tag commands, and the kind of the objects they refer to
+var resourceTypes = map[string]string{
+	"zone":               kindZone,
+	"network":            kindNetwork,
+	"uservm":             kindVirtualMachine,
+	"volume":             kindVolume,
+	"snapshot":           kindSnapshot,
+	"publicipaddress":    kindPublicIP,
+	"firewallrule":       kindFirewallRule,
+	"portforwardingrule": kindPortForwarding,
+	"loadbalancer":       kindLoadBalancer,
+}
+
+// Account the objects belong to
+const (
+	account  = "gokcpstest"
+	domain   = "ROOT"
+	domainID = "6f8b0e2e-0000-4000-8000-000000000001"
+)
+
+func (s *Server) seed() {
+	zone := s.add(kindZone, object{
+		"name":                "jp2-east03",
+		"networktype":         "Advanced",
+		"allocationstate":     "Enabled",
+		"localstorageenabled": false,
+	})
+	s.ZoneID = zone.id()
+
+	network := s.add(kindNetwork, object{
+		"name":        "PublicFrontSegment",
+		"displaytext": "PublicFrontSegment",
+		"zoneid":      s.ZoneID,
+		"zonename":    zone["name"],
+		"state":       "Implemented",
+		"type":        "Shared",
+		"cidr":        "10.0.0.0/16",
+		"gateway":     "10.0.0.1",
+		"netmask":     "255.255.0.0",
+	})
+	s.NetworkID = network.id()
+	s.add(kindNetwork, object{
+		"name":        "PrivateBackSegment",
+		"displaytext": "PrivateBackSegment",
+		"zoneid":      s.ZoneID,
+		"zonename":    zone["name"],
+		"state":       "Implemented",
+		"type":        "Shared",
+		"cidr":        "10.1.0.0/16",
+		"gateway":     "10.1.0.1",
+		"netmask":     "255.255.0.0",
+	})
+
+	offering := s.add(kindServiceOffering, object{
+		"name":        "Medium",
+		"displaytext": "2 vCPU, 4 GB memory",
+		"cpunumber":   2,
+		"cpuspeed":    2000,
+		"memory":      4096,
+		"storagetype": "shared",
+	})
+	s.ServiceOfferingID = offering.id()
+
+	disk := s.add(kindDiskOffering, object{
+		"name":         "Custom",
+		"displaytext":  "Custom disk",
+		"disksize":     0,
+		"iscustomized": true,
+		"storagetype":  "shared",
+	})
+	s.DiskOfferingID = disk.id()
+
+	template := s.add(kindTemplate, object{
+		"name":            "CentOS 7.2 64-bit",
+		"displaytext":     "CentOS 7.2 64-bit",
+		"zoneid":          s.ZoneID,
+		"zonename":        zone["name"],
+		"isready":         true,
+		"ispublic":        true,
+		"isfeatured":      true,
+		"templatetype":    "USER",
+		"format":          "OVA",
+		"hypervisor":      "VMware",
+		"ostypename":      "CentOS 7 (64-bit)",
+		"passwordenabled": true,
+		"size":            int64(15) << 30,
+		"status":          "Download Complete",
+	})
+	s.TemplateID = template.id()
+}
+
+func (o object) id() string {
+	id, _ := o["id"].(string)
+	return id
+}
+
+func (o object) str(key string) string {
+	switch v := o[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Adds an object of the given kind, setting its ID and creation time.
+func (s *Server) add(kind string, o object) object {
+	if _, ok := o["id"]; !ok {
+		o["id"] = newID()
+	}
+	now := time.Now()
+	o["_created"] = now
+	o["created"] = timestamp(now)
+	switch kind {
+	case kindZone, kindServiceOffering, kindDiskOffering:
+	default:
+		o["account"] = account
+		o["domain"] = domain
+		o["domainid"] = domainID
+	}
+	s.objects[kind] = append(s.objects[kind], o)
+	return o
+}
+
+func (s *Server) get(kind, id string) object {
+	for _, o := range s.objects[kind] {
+		if o.id() == id {
+			return o
+		}
+	}
+	return nil
+}
+
+// Like get, but returns an error if there is no such object.
+func (s *Server) mustGet(kind, id string, param string) (object, error) {
+	if id == "" {
+		return nil, errMissing(param)
+	}
+	o := s.get(kind, id)
+	if o == nil {
+		return nil, errNotFound(kind, id)
+	}
+	return o, nil
+}
+
+func (s *Server) remove(kind, id string) {
+	objs := s.objects[kind][:0]
+	for _, o := range s.objects[kind] {
+		if o.id() != id {
+			objs = append(objs, o)
+		}
+	}
+	s.objects[kind] = objs
+
+	// Tags go away together with the object they are attached to
+	if kind != kindTag {
+		tags := s.objects[kindTag][:0]
+		for _, t := range s.objects[kindTag] {
+			if t.str("resourceid") != id {
+				tags = append(tags, t)
+			}
+		}
+		s.objects[kindTag] = tags
+	}
+}
+
+// Returns a copy of o the way the API returns it, without the internal fields and with
+// the tags attached to it.
+func (s *Server) render(o object) object {
+	r := make(object, len(o))
+	for k, v := range o {
+		if !strings.HasPrefix(k, "_") {
+			r[k] = v
+		}
+	}
+
+	var tags []object
+	for _, t := range s.objects[kindTag] {
+		if t.str("resourceid") == o.id() {
+			tags = append(tags, s.render(t))
+		}
+	}
+	if tags != nil {
+		r["tags"] = tags
+	}
+	return r
+}
+
+// Returns the objects of the given kind matching the params. Every param in fields has to
+// match the field with the same name, and the keyword has to be part of the name.
+func (s *Server) find(kind string, p url.Values, fields ...string) []interface{} {
+	var list []interface{}
+outer:
+	for _, o := range s.objects[kind] {
+		for _, f := range fields {
+			if v, ok := p[f]; ok && !strings.EqualFold(o.str(f), v[0]) {
+				continue outer
+			}
+		}
+		if kw := p.Get("keyword"); kw != "" && !strings.Contains(strings.ToLower(o.str("name")), strings.ToLower(kw)) {
+			continue
+		}
+		list = append(list, s.render(o))
+	}
+	return list
+}
+
+// Builds a list response, applying the page and pagesize params. Like the API, an empty
+// list is returned as an empty object.
+func listResponse(key string, list []interface{}, p url.Values) (interface{}, error) {
+	count := len(list)
+	if v := p.Get("pagesize"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return nil, errParam("Invalid value %s for parameter pagesize", v)
+		}
+		page := 1
+		if v := p.Get("page"); v != "" {
+			if page, err = strconv.Atoi(v); err != nil || page < 1 {
+				return nil, errParam("Invalid value %s for parameter page", v)
+			}
+		}
+		start := (page - 1) * size
+		if start > len(list) {
+			start = len(list)
+		}
+		end := start + size
+		if end > len(list) {
+			end = len(list)
+		}
+		list = list[start:end]
+	}
+
+	if count == 0 {
+		return object{}, nil
+	}
+	return object{"count": count, key: list}, nil
+}
@@ -0,0 +1,119 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// RequestInfo describes a single HTTP request to the API, as passed to a BeforeRequestHook.
+type RequestInfo struct {
+	Command string
+	Method  string
+	Params  url.Values // The request parameters, without credentials and with sensitive values redacted
+	Attempt int        // 1 for the first attempt, higher for retries
+}
+
+// ResponseInfo describes the outcome of a single HTTP request to the API, as passed to an
+// AfterResponseHook.
+type ResponseInfo struct {
+	RequestInfo
+	Duration   time.Duration
+	StatusCode int    // 0 if no response was received
+	Body       []byte // The response body with sensitive values redacted; nil if no response was received
+	Err        error  // The error of the request, if any
+}
+
+// BeforeRequestHook is called right before every HTTP request is sent, including retries.
+type BeforeRequestHook func(ctx context.Context, r *RequestInfo)
+
+// AfterResponseHook is called after every HTTP request, when the response was read or the
+// request failed.
+type AfterResponseHook func(ctx context.Context, r *ResponseInfo)
+
+// Matches the JSON fields of a response body whose values must not be exposed
+var sensitiveFieldRegex = regexp.MustCompile(`(?i)("(?:[a-z]*password|userdata|sessionkey|secretkey|apikey)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// Returns a copy of the response body b which is safe to log or to pass to the hooks.
+func sanitizeBody(b []byte) []byte {
+	return sensitiveFieldRegex.ReplaceAll(b, []byte(`${1}"`+redactedValue+`"`))
+}
+
+// The URL of a failed GET request contains the API key and the signature, so they are
+// removed from a *url.Error before it is returned.
+func redactURLError(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		if u, perr := url.Parse(ue.URL); perr == nil {
+			u.RawQuery = sanitizeParams(u.Query()).Encode()
+			ue.URL = u.String()
+		}
+	}
+	return err
+}
+
+// Reports whether requests are logged or passed to a hook, so their details must be collected.
+func (cs *KCPSClient) tracing(ctx context.Context) bool {
+	return cs.beforeRequest != nil || cs.afterResponse != nil ||
+		(cs.logger != nil && cs.logger.Enabled(ctx, slog.LevelDebug))
+}
+
+func (cs *KCPSClient) traceRequest(ctx context.Context, r *RequestInfo) {
+	if cs.logger != nil {
+		cs.logger.LogAttrs(ctx, slog.LevelDebug, "Sending request",
+			slog.String("command", r.Command), slog.String("method", r.Method), slog.Int("attempt", r.Attempt),
+			slog.String("params", r.Params.Encode()))
+	}
+	if cs.beforeRequest != nil {
+		cs.beforeRequest(ctx, r)
+	}
+}
+
+// Completes r with the outcome of the request and reports it. It does nothing when r is nil,
+// i.e. when tracing was off when the request was sent.
+func (cs *KCPSClient) traceResponse(ctx context.Context, r *ResponseInfo, start time.Time, resp *http.Response, body *bytes.Buffer, err error) {
+	if r == nil {
+		return
+	}
+	r.Duration = time.Since(start)
+	r.Err = err
+	if resp != nil {
+		r.StatusCode = resp.StatusCode
+		r.Body = sanitizeBody(body.Bytes())
+	}
+
+	if cs.logger != nil {
+		attrs := []slog.Attr{
+			slog.String("command", r.Command), slog.Int("attempt", r.Attempt), slog.Int("status", r.StatusCode),
+			slog.Duration("duration", r.Duration), slog.String("body", string(r.Body)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+		}
+		cs.logger.LogAttrs(ctx, slog.LevelDebug, "Received response", attrs...)
+	}
+	if cs.afterResponse != nil {
+		cs.afterResponse(ctx, r)
+	}
+}
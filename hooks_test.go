@@ -0,0 +1,179 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/uesyn/gokcps/gokcpstest"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"id":"1","name":"vm1"}`, `{"id":"1","name":"vm1"}`},
+		{`{"password":"secret","id":"1"}`, `{"password":"[REDACTED]","id":"1"}`},
+		{`{"vm":{"newpassword" : "se\"cret"}}`, `{"vm":{"newpassword" : "[REDACTED]"}}`},
+		{`{"SessionKey":"abc","userdata":"c2VjcmV0"}`, `{"SessionKey":"[REDACTED]","userdata":"[REDACTED]"}`},
+		{`{"user":[{"apikey":"key","secretkey":"secret"}]}`, `{"user":[{"apikey":"[REDACTED]","secretkey":"[REDACTED]"}]}`},
+	}
+
+	for _, tt := range tests {
+		if got := string(sanitizeBody([]byte(tt.in))); got != tt.want {
+			t.Errorf("sanitizeBody(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactURLError(t *testing.T) {
+	ue := &url.Error{
+		Op:  "Get",
+		URL: "https://api.example.com/client/api?apiKey=key&command=listZones&password=pw&signature=sig",
+		Err: io.ErrUnexpectedEOF,
+	}
+	msg := redactURLError(ue).Error()
+	for _, s := range []string{"apiKey", "key&", "pw", "sig"} {
+		if strings.Contains(msg, s) {
+			t.Errorf("%q is in the error %q", s, msg)
+		}
+	}
+	if !strings.Contains(msg, "https://api.example.com/client/api") {
+		t.Errorf("the error %q lost the URL", msg)
+	}
+
+	// A request that cannot be sent at all
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	cs, err := New(closed.URL, "key", "secret", WithHTTPGETOnly(true), WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	_, err = cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	if err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if msg := err.Error(); strings.Contains(msg, "key") || strings.Contains(msg, "signature") {
+		t.Errorf("the error %q contains the credentials", msg)
+	}
+}
+
+// Records the credentials sent to and returned by the API, so tests can check they are not exposed.
+type secretRecorder struct {
+	mu      sync.Mutex
+	secrets map[string]bool
+}
+
+func (r *secretRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	params := req.URL.Query()
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(b))
+		if form, err := url.ParseQuery(string(b)); err == nil {
+			for k, v := range form {
+				params[k] = v
+			}
+		}
+	}
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, v := range params {
+		switch strings.ToLower(k) {
+		case "apikey", "signature", "sessionkey", "password":
+			r.secrets[v[0]] = true
+		}
+	}
+	for _, c := range req.Cookies() {
+		r.secrets[c.Value] = true
+	}
+	if resp != nil {
+		for _, c := range resp.Cookies() {
+			r.secrets[c.Value] = true
+		}
+	}
+	return resp, err
+}
+
+func TestTraceRedaction(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+
+	var (
+		mu  sync.Mutex
+		out bytes.Buffer
+	)
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	before := func(ctx context.Context, r *RequestInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(&out, "%+v\n", *r)
+	}
+	after := func(ctx context.Context, r *ResponseInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(&out, "%+v %s\n", *r, r.Body)
+	}
+	rec := &secretRecorder{secrets: map[string]bool{srv.SecretKey: true}}
+	opts := []Option{WithLogger(logger), WithBeforeRequest(before), WithAfterResponse(after), WithTransport(rec)}
+
+	signed, err := New(srv.URL, srv.APIKey, srv.SecretKey, opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := signed.AccountDomain.ListZones(signed.AccountDomain.NewListZonesParams()); err != nil {
+		t.Fatalf("ListZones: %v", err)
+	}
+
+	login, err := NewClientWithLogin(srv.URL, srv.Username, srv.Password, "", opts...)
+	if err != nil {
+		t.Fatalf("NewClientWithLogin: %v", err)
+	}
+	if _, err := login.AccountDomain.ListZones(login.AccountDomain.NewListZonesParams()); err != nil {
+		t.Fatalf("ListZones: %v", err)
+	}
+	key, cookie := login.session.get()
+	rec.secrets[key] = true
+	rec.secrets[cookie.Value] = true
+
+	if !strings.Contains(out.String(), "login") || !strings.Contains(out.String(), "listZones") {
+		t.Fatalf("the requests were not traced:\n%s", out.String())
+	}
+	for s := range rec.secrets {
+		if s != "" && strings.Contains(out.String(), s) {
+			t.Errorf("%q is exposed by the logs or the hooks:\n%s", s, out.String())
+		}
+	}
+	if len(rec.secrets) < 6 {
+		t.Errorf("recorded only %d secrets, want the API key, secret key, signature, password, session key and cookie", len(rec.secrets))
+	}
+}
@@ -0,0 +1,104 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+)
+
+// Job is a running async job, returned by the XxxJob variants of the async API calls. The
+// result of the job is polled in the background by a JobTracker shared by all jobs of the
+// client, so any number of jobs can be awaited at the same time.
+type Job[T any] struct {
+	ID string // The ID of the async job
+
+	status atomic.Int32
+	done   chan struct{}
+	result T
+	err    error
+}
+
+// Done returns a channel that is closed when the job finished, failed or could not be
+// polled any longer.
+func (j *Job[T]) Done() <-chan struct{} {
+	return j.done
+}
+
+// Status returns the current status of the job, see the JobStatusXxx constants.
+func (j *Job[T]) Status() int {
+	return int(j.status.Load())
+}
+
+// Wait blocks until the job is done or ctx is done, and returns the result of the job. If the
+// job failed, the error is an *AsyncJobError. If the job did not finish within the timeout of
+// the client's JobWaiter, the error is an *AsyncTimeoutError.
+func (j *Job[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-j.done:
+		return j.result, j.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Creates a job and hands it to the job tracker of the client. The result of the job is decoded
+// into r using decode.
+func newJob[T any](cs *KCPSClient, jobID string, ex bool, r T, decode func(json.RawMessage, interface{}) error) *Job[T] {
+	j := &Job[T]{
+		ID:   jobID,
+		done: make(chan struct{}),
+	}
+	complete := func(c JobCompletion) {
+		err := c.Err
+		switch {
+		case c.Result != nil:
+			j.status.Store(JobStatusSucceeded)
+			if err = decode(c.Result.Jobresult, r); err == nil {
+				cs.localize(r)
+			}
+		case errors.As(err, new(*AsyncJobError)):
+			j.status.Store(JobStatusFailed)
+		}
+		if err == nil {
+			j.result = r
+		}
+		j.err = err
+		close(j.done)
+	}
+	if err := cs.Asyncjob.jobTracker().track(jobID, ex, complete); err != nil {
+		complete(JobCompletion{JobID: jobID, Err: err})
+	}
+	return j
+}
+
+// Decodes the result of an async job into v.
+func decodeJobResult(b json.RawMessage, v interface{}) error {
+	b, err := getRawValue(b)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Like decodeJobResult, for jobs whose result is not wrapped in an object.
+func decodePlainJobResult(b json.RawMessage, v interface{}) error {
+	return json.Unmarshal(b, v)
+}
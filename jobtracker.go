@@ -0,0 +1,378 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// Minimum number of pending jobs for which listAsyncJobs is used instead of one query per job
+	trackerListThreshold = 2
+
+	// Page size used when listing the async jobs
+	trackerListPageSize = 500
+
+	// The jobs are listed starting this long before the oldest tracked job was started, to
+	// cover clock skew between the client and the API
+	trackerStartdateMargin = 5 * time.Minute
+
+	// Extra margin used when the client has no location, as the startdate is then sent in UTC
+	// while the API may read it in any time zone
+	trackerStartdateZoneMargin = 14 * time.Hour
+
+	// Layout of the startdate parameter of listAsyncJobs, which has no time zone
+	trackerStartdateLayout = "2006-01-02 15:04:05"
+)
+
+// ErrTrackerClosed is the error of the jobs tracked after their JobTracker was closed.
+var ErrTrackerClosed = errors.New("Job tracker is closed")
+
+// JobCompletion reports a finished async job tracked by a JobTracker.
+type JobCompletion struct {
+	JobID  string
+	Result *JobResult // The result of the job, if it succeeded
+	Err    error      // An *AsyncJobError if the job failed, an *AsyncTimeoutError if it did not finish in time or the polling error
+}
+
+// JobTracker watches any number of async jobs and polls them with a single shared loop. While
+// several jobs are pending, each round lists all recent jobs with one listAsyncJobs call. Jobs
+// that are not listed, or all jobs if listAsyncJobs fails, are queried one by one. The loop
+// only runs while there are pending jobs.
+type JobTracker struct {
+	s       *AsyncjobService
+	waiter  JobWaiter
+	results chan JobCompletion
+
+	ctx       context.Context // Cancelled by Close
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	jobs    map[string]*trackedJob
+	round   int  // Number of polling rounds since the last job was added
+	noList  bool // Set once listAsyncJobs failed with an API error
+	running bool
+	wake    chan struct{}
+	stopped chan struct{} // Closed when the loop is not running
+
+	queue      []JobCompletion // Completions waiting to be sent on the results channel
+	forwarding bool
+	forwarded  chan struct{} // Closed when no completions are being sent
+}
+
+type trackedJob struct {
+	id       string
+	ex       bool
+	start    time.Time
+	complete func(JobCompletion)
+}
+
+// NewJobTracker returns a JobTracker that polls using the JobWaiter of the client and delivers
+// the completions on a channel with the given buffer size. Completions that do not fit in the
+// channel are queued, so a slow reader never holds up the polling.
+func (s *AsyncjobService) NewJobTracker(buffer int) *JobTracker {
+	t := newJobTracker(s)
+	t.results = make(chan JobCompletion, buffer)
+	return t
+}
+
+func newJobTracker(s *AsyncjobService) *JobTracker {
+	t := &JobTracker{
+		s:         s,
+		waiter:    s.cs.jobWaiter,
+		jobs:      make(map[string]*trackedJob),
+		wake:      make(chan struct{}, 1),
+		stopped:   make(chan struct{}),
+		forwarded: make(chan struct{}),
+	}
+	close(t.stopped)
+	close(t.forwarded)
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+	return t
+}
+
+// Returns the tracker used by the Job returned by the XxxJob calls, creating it when needed.
+func (s *AsyncjobService) jobTracker() *JobTracker {
+	s.trackerOnce.Do(func() {
+		s.tracker = newJobTracker(s)
+	})
+	return s.tracker
+}
+
+// Track starts watching the async job with the given ID. Tracking the same job twice only
+// delivers a single completion. After Close it returns ErrTrackerClosed.
+func (t *JobTracker) Track(jobID string) error {
+	return t.track(jobID, false, t.deliver)
+}
+
+// TrackEx is like Track, but for the KDDI specific jobs which need queryExAsyncJobResult, like the
+// ones started by deployValueVirtualMachine, deployPremiumVirtualMachine and startVirtualMachine.
+func (t *JobTracker) TrackEx(jobID string) error {
+	return t.track(jobID, true, t.deliver)
+}
+
+// Completions returns the channel the completions of the tracked jobs are delivered on. It is
+// closed by Close.
+func (t *JobTracker) Completions() <-chan JobCompletion {
+	return t.results
+}
+
+// Pending returns the number of tracked jobs which did not complete yet.
+func (t *JobTracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.jobs)
+}
+
+// Close stops tracking all pending jobs and closes the completions channel, dropping the
+// completions that were not received yet.
+func (t *JobTracker) Close() {
+	t.closeOnce.Do(func() {
+		t.cancel()
+		t.mu.Lock()
+		stopped := t.stopped
+		t.mu.Unlock()
+		<-stopped
+
+		// The loop no longer delivers, so the forwarder read here is the last one
+		t.mu.Lock()
+		forwarded := t.forwarded
+		t.queue = nil
+		t.mu.Unlock()
+		<-forwarded
+		if t.results != nil {
+			close(t.results)
+		}
+	})
+}
+
+// Queues a completion for the results channel, without blocking the polling loop.
+func (t *JobTracker) deliver(c JobCompletion) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx.Err() != nil {
+		return
+	}
+	t.queue = append(t.queue, c)
+	if !t.forwarding {
+		t.forwarding = true
+		t.forwarded = make(chan struct{})
+		go t.forward(t.forwarded)
+	}
+}
+
+// Sends the queued completions on the results channel, in order, until the queue is empty.
+func (t *JobTracker) forward(forwarded chan struct{}) {
+	defer close(forwarded)
+	for {
+		t.mu.Lock()
+		if len(t.queue) == 0 || t.ctx.Err() != nil {
+			t.forwarding = false
+			t.mu.Unlock()
+			return
+		}
+		c := t.queue[0]
+		t.queue = t.queue[1:]
+		t.mu.Unlock()
+
+		select {
+		case t.results <- c:
+		case <-t.ctx.Done():
+		}
+	}
+}
+
+func (t *JobTracker) track(jobID string, ex bool, complete func(JobCompletion)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx.Err() != nil {
+		return ErrTrackerClosed
+	}
+
+	t.jobs[jobID] = &trackedJob{id: jobID, ex: ex, start: time.Now(), complete: complete}
+	t.round = 0
+	if !t.running {
+		t.running = true
+		t.stopped = make(chan struct{})
+		go t.run(t.stopped)
+	}
+
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (t *JobTracker) run(stopped chan struct{}) {
+	defer close(stopped)
+	for {
+		t.mu.Lock()
+		if len(t.jobs) == 0 || t.ctx.Err() != nil {
+			t.running = false
+			t.mu.Unlock()
+			return
+		}
+		jobs := make([]*trackedJob, 0, len(t.jobs))
+		for _, j := range t.jobs {
+			jobs = append(jobs, j)
+		}
+		useList := !t.noList && len(jobs) >= trackerListThreshold
+		t.mu.Unlock()
+
+		t.poll(jobs, useList)
+
+		t.mu.Lock()
+		wait := backoff(t.waiter.InitialInterval, t.waiter.MaxInterval, t.waiter.Multiplier, t.waiter.Jitter, t.round)
+		t.round++
+		t.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-t.wake:
+		case <-t.ctx.Done():
+		}
+	}
+}
+
+// Runs a single polling round over the given jobs.
+func (t *JobTracker) poll(jobs []*trackedJob, useList bool) {
+	var listed map[string]*AsyncJob
+	if useList {
+		var err error
+		if listed, err = t.list(jobs); err != nil {
+			if _, ok := asCSError(err); ok && !IsThrottled(err) {
+				// The API does not support listing the jobs, so stop trying
+				t.mu.Lock()
+				t.noList = true
+				t.mu.Unlock()
+			}
+			if l := t.s.cs.logger; l != nil {
+				l.LogAttrs(t.ctx, slog.LevelDebug, "Listing async jobs failed, querying them one by one", slog.Any("error", err))
+			}
+			listed = nil
+		}
+	}
+
+	for _, j := range jobs {
+		if t.ctx.Err() != nil {
+			return
+		}
+
+		var r *QueryAsyncJobResultResponse
+		if a, ok := listed[j.id]; ok {
+			if a.Jobstatus == JobStatusPending {
+				t.checkTimeout(j)
+				continue
+			}
+			// Listed jobs may come without their result, so only use the ones that have it
+			if a.Jobstatus == JobStatusFailed || len(a.Jobresult) > 0 {
+				r = a.queryResponse()
+			}
+		}
+
+		if r == nil {
+			var err error
+			r, err = t.waiter.withExQuery(j.ex).query(t.ctx, t.s.cs, j.id)
+			if err != nil {
+				if t.ctx.Err() == nil {
+					t.finish(j, JobCompletion{JobID: j.id, Err: err})
+				}
+				continue
+			}
+		}
+
+		switch r.Jobstatus {
+		case JobStatusSucceeded:
+			t.finish(j, JobCompletion{JobID: j.id, Result: &JobResult{JobID: j.id, QueryAsyncJobResultResponse: *r}})
+		case JobStatusFailed:
+			t.finish(j, JobCompletion{JobID: j.id, Err: newAsyncJobError(j.id, r)})
+		default:
+			t.checkTimeout(j)
+		}
+	}
+}
+
+// Lists all async jobs started since shortly before the oldest of the given jobs.
+func (t *JobTracker) list(jobs []*trackedJob) (map[string]*AsyncJob, error) {
+	oldest := jobs[0].start
+	for _, j := range jobs[1:] {
+		if j.start.Before(oldest) {
+			oldest = j.start
+		}
+	}
+
+	p := t.s.NewListAsyncJobsParams()
+	p.SetStartdate(t.startdate(oldest))
+	p.SetPagesize(trackerListPageSize)
+
+	listed := make(map[string]*AsyncJob)
+	pg := t.s.ListAsyncJobsPager(p)
+	for pg.Next(t.ctx) {
+		a := pg.Value()
+		listed[a.Jobid] = a
+	}
+	if err := pg.Err(); err != nil {
+		return nil, err
+	}
+	return listed, nil
+}
+
+// Formats the startdate to list the jobs started since oldest with. The API reads it in its own
+// time zone, which is taken to be the location of the client. Without a location it is sent in
+// UTC and moved back far enough to cover any time zone.
+func (t *JobTracker) startdate(oldest time.Time) string {
+	start := oldest.Add(-trackerStartdateMargin)
+	if loc := t.s.cs.location; loc != nil {
+		return start.In(loc).Format(trackerStartdateLayout)
+	}
+	return start.Add(-trackerStartdateZoneMargin).UTC().Format(trackerStartdateLayout)
+}
+
+func (t *JobTracker) checkTimeout(j *trackedJob) {
+	if t.waiter.Timeout > 0 && time.Since(j.start) >= t.waiter.Timeout {
+		t.finish(j, JobCompletion{JobID: j.id, Err: &AsyncTimeoutError{JobID: j.id, Timeout: t.waiter.Timeout}})
+	}
+}
+
+func (t *JobTracker) finish(j *trackedJob, c JobCompletion) {
+	t.mu.Lock()
+	delete(t.jobs, j.id)
+	t.mu.Unlock()
+	j.complete(c)
+}
+
+func (a *AsyncJob) queryResponse() *QueryAsyncJobResultResponse {
+	return &QueryAsyncJobResultResponse{
+		Accountid:       a.Accountid,
+		Cmd:             a.Cmd,
+		Created:         a.Created,
+		Jobinstanceid:   a.Jobinstanceid,
+		Jobinstancetype: a.Jobinstancetype,
+		Jobprocstatus:   a.Jobprocstatus,
+		Jobresult:       a.Jobresult,
+		Jobresultcode:   a.Jobresultcode,
+		Jobresulttype:   a.Jobresulttype,
+		Jobstatus:       a.Jobstatus,
+		Userid:          a.Userid,
+	}
+}
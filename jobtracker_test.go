@@ -0,0 +1,142 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobTrackerStartdate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name   string
+		loc    *time.Location
+		parse  *time.Location
+		margin time.Duration
+	}{
+		{name: "client location", loc: jst, parse: jst, margin: trackerStartdateMargin},
+		{name: "no location", parse: time.UTC, margin: trackerStartdateMargin + trackerStartdateZoneMargin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1})}
+			if tt.loc != nil {
+				opts = append(opts, WithLocation(tt.loc))
+			}
+			srv, cs := newTestClient(t, opts...)
+			srv.SetJobDelay(50 * time.Millisecond)
+
+			ctx := context.Background()
+			start := time.Now()
+
+			var jobs []*Job[*CreateVolumeResponse]
+			for i := 0; i < 3; i++ {
+				j, err := cs.Volume.CreateVolumeJob(ctx, newTestVolumeParams(cs, srv, "vol"))
+				if err != nil {
+					t.Fatalf("CreateVolumeJob: %v", err)
+				}
+				jobs = append(jobs, j)
+			}
+			for _, j := range jobs {
+				if _, err := j.Wait(ctx); err != nil {
+					t.Fatalf("Wait: %v", err)
+				}
+			}
+
+			var listed bool
+			for _, r := range srv.Requests() {
+				if r.Command != "listAsyncJobs" {
+					continue
+				}
+				listed = true
+
+				v := r.Params.Get("startdate")
+				d, err := time.ParseInLocation(trackerStartdateLayout, v, tt.parse)
+				if err != nil {
+					t.Fatalf("startdate %q: %v", v, err)
+				}
+				if lag := start.Sub(d); lag < 0 || lag > tt.margin+time.Second {
+					t.Errorf("startdate %s is %s before the first job, want about %s", v, lag, tt.margin)
+				}
+			}
+			if !listed {
+				t.Error("the jobs were not polled with listAsyncJobs")
+			}
+		})
+	}
+}
+
+func TestJobTrackerSlowReader(t *testing.T) {
+	srv, cs := newTestClient(t, WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1}))
+	srv.SetJobDelay(20 * time.Millisecond)
+
+	tr := cs.Asyncjob.NewJobTracker(0)
+	defer tr.Close()
+
+	want := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		r, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol"))
+		if err != nil {
+			t.Fatalf("CreateVolume: %v", err)
+		}
+		if err := tr.Track(r.JobID); err != nil {
+			t.Fatalf("Track: %v", err)
+		}
+		want[r.JobID] = true
+	}
+
+	// Nothing reads the completions yet, which must not keep the other jobs from being polled
+	deadline := time.Now().Add(5 * time.Second)
+	for tr.Pending() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d jobs still pending while the completions were not read", tr.Pending())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for len(want) > 0 {
+		select {
+		case c := <-tr.Completions():
+			if c.Err != nil {
+				t.Errorf("job %s: %v", c.JobID, c.Err)
+			}
+			delete(want, c.JobID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("jobs %v were not delivered", want)
+		}
+	}
+}
+
+func TestJobTrackerTrackAfterClose(t *testing.T) {
+	_, cs := newTestClient(t)
+
+	tr := cs.Asyncjob.NewJobTracker(1)
+	tr.Close()
+	if err := tr.Track("job1"); !errors.Is(err, ErrTrackerClosed) {
+		t.Errorf("Track after Close = %v, want ErrTrackerClosed", err)
+	}
+	if err := tr.TrackEx("job2"); !errors.Is(err, ErrTrackerClosed) {
+		t.Errorf("TrackEx after Close = %v, want ErrTrackerClosed", err)
+	}
+	if _, ok := <-tr.Completions(); ok {
+		t.Error("a completion was delivered after Close")
+	}
+}
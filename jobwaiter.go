@@ -0,0 +1,144 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Status values of an async job
+const (
+	JobStatusPending   = 0
+	JobStatusSucceeded = 1
+	JobStatusFailed    = 2
+)
+
+// JobWaiter defines how the result of an async job is polled. The wait time between two polls
+// starts at InitialInterval and is multiplied by Multiplier after every poll, up to MaxInterval.
+// Waiting also ends when the context passed to WaitForJob is done.
+type JobWaiter struct {
+	InitialInterval time.Duration // Wait time before the second poll
+	MaxInterval     time.Duration // Upper bound of the wait time between two polls; 0 means no limit
+	Multiplier      float64       // Factor the wait time grows with after every poll
+	Jitter          float64       // Randomization factor between 0 and 1 applied to every wait time
+	Timeout         time.Duration // Max time to wait for the job to finish; 0 means no limit
+	UseExQuery      bool          // Poll with the KDDI specific queryExAsyncJobResult instead of queryAsyncJobResult
+
+	once bool // Poll a single time, for a zero timeout passed to GetAsyncJobResult
+}
+
+// DefaultJobWaiter returns the JobWaiter used by new clients. It polls after one second first
+// and then backs off to one poll every 15 seconds, giving up after 300 seconds.
+func DefaultJobWaiter() JobWaiter {
+	return JobWaiter{
+		InitialInterval: DefaultPollInterval,
+		MaxInterval:     15 * DefaultPollInterval,
+		Multiplier:      1.5,
+		Jitter:          0.1,
+		Timeout:         DefaultAsyncTimeout,
+	}
+}
+
+// JobResult is the result of a finished async job.
+type JobResult struct {
+	JobID string
+	QueryAsyncJobResultResponse
+}
+
+// Decode unmarshals the job result into v, which is usually a pointer to the response
+// type of the API call that started the job.
+func (r *JobResult) Decode(v interface{}) error {
+	b, err := getRawValue(r.Jobresult)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// WaitForJob polls the result of the async job with the given ID until the job is finished,
+// using w or the JobWaiter configured for the client if w is nil. If the job failed, an
+// *AsyncJobError is returned. If the job did not finish in time, including when the deadline
+// of ctx is exceeded, an *AsyncTimeoutError is returned.
+func (cs *KCPSClient) WaitForJob(ctx context.Context, jobID string, w *JobWaiter) (*JobResult, error) {
+	if w == nil {
+		w = &cs.jobWaiter
+	}
+	return w.wait(ctx, cs, jobID)
+}
+
+func (w *JobWaiter) wait(ctx context.Context, cs *KCPSClient, jobID string) (*JobResult, error) {
+	start := time.Now()
+	for poll := 0; ; poll++ {
+		r, err := w.query(ctx, cs, jobID)
+		if err != nil {
+			return nil, jobWaitError(ctx, jobID, start, err)
+		}
+
+		switch r.Jobstatus {
+		case JobStatusSucceeded:
+			return &JobResult{JobID: jobID, QueryAsyncJobResultResponse: *r}, nil
+		case JobStatusFailed:
+			return nil, newAsyncJobError(jobID, r)
+		}
+
+		if w.once {
+			return nil, &AsyncTimeoutError{JobID: jobID}
+		}
+
+		wait := backoff(w.InitialInterval, w.MaxInterval, w.Multiplier, w.Jitter, poll)
+		if w.Timeout > 0 {
+			// Make sure to poll one last time when the timeout is reached
+			remaining := w.Timeout - time.Since(start)
+			if remaining <= 0 {
+				return nil, &AsyncTimeoutError{JobID: jobID, Timeout: w.Timeout}
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, jobWaitError(ctx, jobID, start, err)
+		}
+	}
+}
+
+// Returns a copy of w which uses queryExAsyncJobResult if ex is true.
+func (w JobWaiter) withExQuery(ex bool) *JobWaiter {
+	w.UseExQuery = ex
+	return &w
+}
+
+func (w *JobWaiter) query(ctx context.Context, cs *KCPSClient, jobID string) (*QueryAsyncJobResultResponse, error) {
+	if w.UseExQuery {
+		r, err := cs.Asyncjob.QueryExAsyncJobResultWithContext(ctx, cs.Asyncjob.NewQueryExAsyncJobResultParams(jobID))
+		return (*QueryAsyncJobResultResponse)(r), err
+	}
+	return cs.Asyncjob.QueryAsyncJobResultWithContext(ctx, cs.Asyncjob.NewQueryAsyncJobResultParams(jobID))
+}
+
+// Turns an error caused by exceeding the deadline of ctx into an *AsyncTimeoutError, so the
+// caller gets to know the job ID and can resume waiting later.
+func jobWaitError(ctx context.Context, jobID string, start time.Time, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &AsyncTimeoutError{JobID: jobID, Timeout: time.Since(start), Err: ctx.Err()}
+	}
+	return err
+}
@@ -18,32 +18,32 @@ package gokcps
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha1"
-	"crypto/tls"
-	"encoding/base64"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"math/rand"
+	"log/slog"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"regexp"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Difference between the clock of the API server and the local clock that is tolerated before
+// the expiry of signatures is corrected
+const maxClockSkew = 5 * time.Second
+
 // UnlimitedResourceID is a special ID to define an unlimited resource
 const UnlimitedResourceID = "-1"
 
 var idRegex = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|-1)$`)
 
-// For KDDI
-// var requestMux = &sync.Mutex{}
-
 // IsID return true if the passed ID is either a UUID or a UnlimitedResourceID
 func IsID(id string) bool {
 	return idRegex.MatchString(id)
@@ -52,27 +52,31 @@ func IsID(id string) bool {
 // OptionFunc can be passed to the courtesy helper functions to set additional parameters
 type OptionFunc func(*KCPSClient, interface{}) error
 
-type CSError struct {
-	ErrorCode   int    `json:"errorcode"`
-	CSErrorCode int    `json:"cserrorcode"`
-	ErrorText   string `json:"errortext"`
-}
-
-func (e *CSError) Error() error {
-	return fmt.Errorf("CloudStack API error %d (CSExceptionErrorCode: %d): %s", e.ErrorCode, e.CSErrorCode, e.ErrorText)
-}
-
 type KCPSClient struct {
 	HTTPGETOnly bool // If `true` only use HTTP GET calls
 
-	lock *sync.Mutex
-
-	client  *http.Client // The http client for communicating
-	baseURL string       // The base URL of the API
-	apiKey  string       // Api key
-	secret  string       // Secret key
-	async   bool         // Wait for async calls to finish
-	timeout int64        // Max waiting timeout in seconds for async jobs to finish; defaults to 300 seconds
+	client      *http.Client   // The http client for communicating
+	baseURL     string         // The base URL of the API
+	apiKey      string         // Api key
+	secret      string         // Secret key
+	userAgent   string         // User-Agent header sent with every request
+	async       bool           // Wait for async calls to finish
+	jobWaiter   JobWaiter      // Polls the results of async jobs
+	limiter     Limiter        // Optional limiter for outgoing requests; nil means unlimited
+	retryPolicy RetryPolicy    // Decides which failed requests are retried
+	retryHook   RetryHook      // Optional hook called before every retry
+	logger      *slog.Logger   // Optional logger; nil means nothing is logged
+	location    *time.Location // Location the timestamps of the responses are converted to; nil keeps them as returned
+
+	defaultNetwork string             // Name or ID of the network used by deploy calls without networks; empty means none
+	beforeRequest  BeforeRequestHook  // Optional hook called before every HTTP request
+	afterResponse  AfterResponseHook  // Optional hook called after every HTTP request
+	provider       CredentialProvider // Optional provider of the URL and keys, overriding the ones above
+	session        *session           // Login session used instead of the API keys; nil when using the keys
+	middleware     []Middleware       // Chain every command goes through, outermost first
+
+	signatureExpiry time.Duration // How long signatures are valid; 0 means they do not expire
+	clockSkew       atomic.Int64  // Detected offset of the clock of the API server, in nanoseconds
 
 	Asyncjob       *AsyncjobService
 	Event          *EventService
@@ -91,22 +95,8 @@ type KCPSClient struct {
 	Tags           *TagsService
 }
 
-// Creates a new client for communicating with CloudStack
-func newClient(apiurl string, apikey string, secret string, async bool, verifyssl bool) *KCPSClient {
-	cs := &KCPSClient{
-		client: &http.Client{
-			Transport: &http.Transport{
-				Proxy:           http.ProxyFromEnvironment,
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: !verifyssl}, // If verifyssl is true, skipping the verify should be false and vice versa
-			},
-			Timeout: time.Duration(60 * time.Second),
-		},
-		baseURL: apiurl,
-		apiKey:  apikey,
-		secret:  secret,
-		async:   async,
-		timeout: 300,
-	}
+// Creates the services of the client
+func (cs *KCPSClient) initServices() {
 	cs.Asyncjob = NewAsyncjobService(cs)
 	cs.Event = NewEventService(cs)
 	cs.Firewall = NewFirewallService(cs)
@@ -122,15 +112,14 @@ func newClient(apiurl string, apikey string, secret string, async bool, verifyss
 	cs.VirtualMachine = NewVirtualMachineService(cs)
 	cs.Volume = NewVolumeService(cs)
 	cs.Tags = NewTagsService(cs)
-	return cs
 }
 
 // Default non-async client. So for async calls you need to implement and check the async job result yourself. When using
 // HTTPS with a self-signed certificate to connect to your CloudStack API, you would probably want to set 'verifyssl' to
 // false so the call ignores the SSL errors/warnings.
 func NewClient(apiurl string, apikey string, secret string, verifyssl bool) *KCPSClient {
-	cs := newClient(apiurl, apikey, secret, false, verifyssl)
-	cs.lock = new(sync.Mutex)
+	// These options never fail
+	cs, _ := New(apiurl, apikey, secret, WithInsecureSkipVerify(!verifyssl))
 	return cs
 }
 
@@ -139,200 +128,400 @@ func NewClient(apiurl string, apikey string, secret string, verifyssl bool) *KCP
 // job finishes successfully it will return actual object received from the API and nil, but when the timout is
 // reached it will return the initial object containing the async job ID for the running job and a warning.
 func NewAsyncClient(apiurl string, apikey string, secret string, verifyssl bool) *KCPSClient {
-	cs := newClient(apiurl, apikey, secret, true, verifyssl)
-	cs.lock = new(sync.Mutex)
+	// These options never fail
+	cs, _ := New(apiurl, apikey, secret, WithAsync(true), WithInsecureSkipVerify(!verifyssl))
 	return cs
 }
 
 // When using the async client an api call will wait for the async call to finish before returning. The default is to poll for 300 seconds
 // seconds, to check if the async job is finished.
 func (cs *KCPSClient) AsyncTimeout(timeoutInSeconds int64) {
-	cs.timeout = timeoutInSeconds
+	cs.jobWaiter.Timeout = time.Duration(timeoutInSeconds) * time.Second
 }
 
-var AsyncTimeoutErr = errors.New("Timeout while waiting for async job to finish")
+// Sets the name or ID of the network that deployValueVirtualMachine and deployPremiumVirtualMachine
+// attach a virtual machine to, when the params do not contain any network. The default is
+// DefaultNetworkName, use an empty string to let the API decide. The network is looked up in the zone
+// of each request, the params themselves are never modified.
+func (cs *KCPSClient) SetDefaultNetwork(nameOrID string) {
+	cs.defaultNetwork = nameOrID
+}
 
-// A helper function that you can use to get the result of a running async job. If the job is not finished within the configured
-// timeout, the async job returns a AsyncTimeoutErr.
-func (cs *KCPSClient) GetAsyncJobResult(jobid string, timeout int64) (json.RawMessage, error) {
-	var timer time.Duration
-	currentTime := time.Now().Unix()
+// The client is safe for concurrent use and by default sends requests in parallel without any limit. Set a
+// Limiter to restrict the number of concurrent requests or the request rate, e.g. to respect the KCPS API
+// throttling. The limiter is acquired for every single HTTP request, so it is not held while waiting for a
+// retry or for an async job to finish.
+func (cs *KCPSClient) SetLimiter(l Limiter) {
+	cs.limiter = l
+}
 
-	for {
-		p := cs.Asyncjob.NewQueryAsyncJobResultParams(jobid)
-		r, err := cs.Asyncjob.QueryAsyncJobResult(p)
-		if err != nil {
-			return nil, err
-		}
+// Sets the policy that decides which failed requests are retried. The default is DefaultRetryPolicy(), use
+// NoRetry to disable retries.
+func (cs *KCPSClient) SetRetryPolicy(p RetryPolicy) {
+	cs.retryPolicy = p
+}
 
-		// Status 1 means the job is finished successfully
-		if r.Jobstatus == 1 {
-			return r.Jobresult, nil
-		}
+// Sets a hook which is called before a failed request is retried, e.g. to log or count the retries.
+func (cs *KCPSClient) SetRetryHook(h RetryHook) {
+	cs.retryHook = h
+}
 
-		// When the status is 2, the job has failed
-		if r.Jobstatus == 2 {
-			if r.Jobresulttype == "text" {
-				return nil, fmt.Errorf(string(r.Jobresult))
-			} else {
-				return nil, fmt.Errorf("Undefined error: %s", string(r.Jobresult))
-			}
-		}
+// ClockSkew returns how far the clock of the API server is ahead of the local clock, as detected
+// when a request with an expiring signature was refused. It is 0 until a skew was detected.
+func (cs *KCPSClient) ClockSkew() time.Duration {
+	return time.Duration(cs.clockSkew.Load())
+}
 
-		if time.Now().Unix()-currentTime > timeout {
-			return nil, AsyncTimeoutErr
-		}
+// Sets a hook which is called right before every HTTP request to the API, e.g. to trace the requests.
+// The params passed to the hook have the credentials and sensitive values removed.
+func (cs *KCPSClient) SetBeforeRequest(h BeforeRequestHook) {
+	cs.beforeRequest = h
+}
 
-		// Add an (extremely simple) exponential backoff like feature to prevent
-		// flooding the CloudStack API
-		if timer < 15 {
-			timer++
-		}
+// Sets a hook which is called after every HTTP request to the API with the duration, status and body of
+// the response. Sensitive values are removed from the params and the body passed to the hook.
+func (cs *KCPSClient) SetAfterResponse(h AfterResponseHook) {
+	cs.afterResponse = h
+}
 
-		time.Sleep(timer * time.Second)
-	}
+// A helper function that you can use to get the result of a running async job. If the job is not finished within the configured
+// timeout, the async job returns an *AsyncTimeoutError. If the job failed, an *AsyncJobError is returned.
+// A timeout of 0 or less polls the job a single time.
+func (cs *KCPSClient) GetAsyncJobResult(jobid string, timeout int64) (json.RawMessage, error) {
+	return cs.GetAsyncJobResultWithContext(context.Background(), jobid, timeout)
+}
+
+// GetAsyncJobResultWithContext is like GetAsyncJobResult, but stops waiting as soon as ctx is done.
+func (cs *KCPSClient) GetAsyncJobResultWithContext(ctx context.Context, jobid string, timeout int64) (json.RawMessage, error) {
+	return cs.jobResult(cs.legacyJobWaiter(timeout, false).wait(ctx, cs, jobid))
 }
 
 // A helper function that you can use to get the result of a running async job. If the job is not finished within the configured
-// timeout, the async job returns a AsyncTimeoutErr.
+// timeout, the async job returns an *AsyncTimeoutError. If the job failed, an *AsyncJobError is returned.
+// A timeout of 0 or less polls the job a single time.
 func (cs *KCPSClient) GetExAsyncJobResult(jobid string, timeout int64) (json.RawMessage, error) {
-	var timer time.Duration
-	currentTime := time.Now().Unix()
-
-	for {
-		p := cs.Asyncjob.NewQueryExAsyncJobResultParams(jobid)
-		r, err := cs.Asyncjob.QueryExAsyncJobResult(p)
-		if err != nil {
-			return nil, err
-		}
+	return cs.GetExAsyncJobResultWithContext(context.Background(), jobid, timeout)
+}
 
-		// Status 1 means the job is finished successfully
-		if r.Jobstatus == 1 {
-			return r.Jobresult, nil
-		}
+// GetExAsyncJobResultWithContext is like GetExAsyncJobResult, but stops waiting as soon as ctx is done.
+func (cs *KCPSClient) GetExAsyncJobResultWithContext(ctx context.Context, jobid string, timeout int64) (json.RawMessage, error) {
+	return cs.jobResult(cs.legacyJobWaiter(timeout, true).wait(ctx, cs, jobid))
+}
 
-		// When the status is 2, the job has failed
-		if r.Jobstatus == 2 {
-			if r.Jobresulttype == "text" {
-				return nil, fmt.Errorf(string(r.Jobresult))
-			} else {
-				return nil, fmt.Errorf("Undefined error: %s", string(r.Jobresult))
-			}
-		}
+// Returns the JobWaiter for GetAsyncJobResult and GetExAsyncJobResult. Their timeout is in seconds,
+// and the job is polled a single time when it is 0 or less.
+func (cs *KCPSClient) legacyJobWaiter(timeout int64, ex bool) *JobWaiter {
+	w := cs.jobWaiter.withExQuery(ex)
+	w.Timeout = time.Duration(timeout) * time.Second
+	w.once = timeout <= 0
+	return w
+}
 
-		if time.Now().Unix()-currentTime > timeout {
-			return nil, AsyncTimeoutErr
-		}
+// Waits for the async job started by an API call, using the JobWaiter configured for the client.
+func (cs *KCPSClient) waitForAsyncJob(ctx context.Context, jobid string) (json.RawMessage, error) {
+	w := cs.jobWaiter
+	w.UseExQuery = false
+	return cs.jobResult(w.wait(ctx, cs, jobid))
+}
 
-		// Add an (extremely simple) exponential backoff like feature to prevent
-		// flooding the CloudStack API
-		if timer < 15 {
-			timer++
-		}
+// Like waitForAsyncJob, but for the KDDI specific commands that need queryExAsyncJobResult.
+func (cs *KCPSClient) waitForExAsyncJob(ctx context.Context, jobid string) (json.RawMessage, error) {
+	w := cs.jobWaiter
+	w.UseExQuery = true
+	return cs.jobResult(w.wait(ctx, cs, jobid))
+}
 
-		time.Sleep(timer * time.Second)
+func (cs *KCPSClient) jobResult(r *JobResult, err error) (json.RawMessage, error) {
+	if err != nil {
+		return nil, err
 	}
+	return r.Jobresult, nil
 }
 
 // Execute the request against a CS API. Will return the raw JSON data returned by the API and nil if
 // no error occured. If the API returns an error the result will be nil and the HTTP error code and CS
-// error details. If a processing (code) error occurs the result will be nil and the generated error
-func (cs *KCPSClient) newRequest(api string, params url.Values) (json.RawMessage, error) {
-
-	cs.lock.Lock()
-	defer cs.lock.Unlock()
-
-	maxret := 5
-	retry := 0
-	var (
-		err     error
-		message json.RawMessage
-	)
-
-	for {
-		retry++
-		if maxret < retry {
-			err = fmt.Errorf("api request max retry")
-			break
+// error details. If a processing (code) error occurs the result will be nil and the generated error.
+// Failed requests are retried according to the configured RetryPolicy.
+func (cs *KCPSClient) newRequest(ctx context.Context, api string, params url.Values) (json.RawMessage, error) {
+	var b json.RawMessage
+	if err := cs.request(ctx, api, params, &b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Like newRequest, but decodes the response straight into v.
+func (cs *KCPSClient) request(ctx context.Context, api string, params url.Values, v interface{}) error {
+	policy := cs.retryPolicy
+	if policy == nil {
+		policy = NoRetry
+	}
+	start := time.Now()
+	relogin := false
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			resetValue(v)
+		}
+		sent, err := cs.oneRequest(ctx, api, params, v, attempt)
+		if err == nil {
+			cs.localize(v)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
 		}
 
-		m, e := cs.oneRequest(api, params)
-		if e != nil && strings.HasSuffix(e.Error(), "connection reset by peer") {
-			time.Sleep(time.Duration(rand.Int63n(10)) * time.Second)
+		// An expired login session is renewed once, by logging in again with the next attempt
+		if cs.session != nil && sent && IsAuthError(err) && !relogin {
+			relogin = true
 			continue
 		}
-		message = m
-		err = e
-		break
+
+		a := &RetryAttempt{
+			Command: api,
+			Attempt: attempt,
+			Elapsed: time.Since(start),
+			Err:     err,
+			Sent:    sent,
+		}
+		wait, retry := policy.Retry(a)
+		if !retry {
+			return err
+		}
+		if cs.logger != nil {
+			cs.logger.LogAttrs(ctx, slog.LevelDebug, "Retrying failed request",
+				slog.String("command", api), slog.Int("attempt", attempt), slog.Duration("wait", wait), slog.Any("error", err))
+		}
+		if cs.retryHook != nil {
+			cs.retryHook(a, wait)
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
 	}
+}
 
-	return message, err
+// Sends a single request to the API and decodes the response into v. It reports whether the request
+// was written to the connection, so the caller can tell if the API may have received it.
+func (cs *KCPSClient) oneRequest(ctx context.Context, api string, params url.Values, v interface{}, attempt int) (bool, error) {
+	if cs.session == nil {
+		return cs.sendRequest(ctx, api, params, v, attempt, "", nil)
+	}
+	sessionKey, cookie, err := cs.session.current(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+	return cs.sendRequest(ctx, api, params, v, attempt, sessionKey, cookie)
 }
 
-func (cs *KCPSClient) oneRequest(api string, params url.Values) (json.RawMessage, error) {
-	params.Set("apiKey", cs.apiKey)
+// Like oneRequest, but authenticates the request with the given login session. When sessionKey
+// is empty, the request is signed with the API keys instead.
+func (cs *KCPSClient) sendRequest(ctx context.Context, api string, params url.Values, v interface{}, attempt int, sessionKey string, cookie *http.Cookie) (bool, error) {
+	// Work on a copy, as the params are reused when the request is retried
+	p := make(url.Values, len(params)+4)
+	for k, v := range params {
+		p[k] = v
+	}
+	params = p
+
 	params.Set("command", api)
 	params.Set("response", "json")
 
-	// Generate signature for API call
-	// * Serialize parameters, URL encoding only values and sort them by key, done by encodeValues
-	// * Convert the entire argument string to lowercase
-	// * Replace all instances of '+' to '%20'
-	// * Calculate HMAC SHA1 of argument string with CloudStack secret
-	// * URL encode the string and convert to base64
+	// Requests are either signed with the API keys, or carry the key of a login session
+	apiURL := cs.baseURL
+	var signer Signer
+	var signature string
+	if sessionKey != "" {
+		params.Set("sessionkey", sessionKey)
+	} else {
+		creds, err := cs.credentials(ctx)
+		if err != nil {
+			return false, err
+		}
+		apiURL = creds.APIURL
+		signer = Signer{
+			APIKey:      creds.APIKey,
+			SecretKey:   creds.SecretKey,
+			Expiry:      cs.signatureExpiry,
+			ClockOffset: cs.ClockSkew(),
+		}
+		signature = signer.Sign(params)
+	}
 	s := encodeValues(params)
-	s2 := strings.ToLower(s)
-	s3 := strings.Replace(s2, "+", "%20", -1)
-	mac := hmac.New(sha1.New, []byte(cs.secret))
-	mac.Write([]byte(s3))
-	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
 
+	var sent atomic.Bool
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) { sent.Store(true) },
+	})
+
+	var req *http.Request
 	var err error
-	var resp *http.Response
 	if !cs.HTTPGETOnly && (api == "deployVirtualMachine" || api == "updateVirtualMachine") {
 		// The deployVirtualMachine API should be called using a POST call
 		// so we don't have to worry about the userdata size
 
 		// Add the unescaped signature to the POST params
-		params.Set("signature", signature)
+		if signature != "" {
+			params.Set("signature", signature)
+		}
 
-		// Make a POST call
-		resp, err = cs.client.PostForm(cs.baseURL, params)
+		// Create a POST request
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(params.Encode()))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	} else {
 		// Create the final URL before we issue the request
-		url := cs.baseURL + "?" + s + "&signature=" + url.QueryEscape(signature)
+		reqURL := apiURL + "?" + s
+		if signature != "" {
+			reqURL += "&signature=" + url.QueryEscape(signature)
+		}
+
+		// Create a GET request
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if cs.userAgent != "" {
+		req.Header.Set("User-Agent", cs.userAgent)
+	}
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	if cs.limiter != nil {
+		release, err := cs.limiter.Acquire(ctx)
+		if err != nil {
+			return false, err
+		}
+		defer release()
+	}
 
-		// Make a GET call
-		//		requestMux.Lock()
-		resp, err = cs.client.Get(url)
-		// For KDDI
-		//  time.Sleep(2 * time.Second)
-		//	requestMux.Unlock()
+	var trace *ResponseInfo
+	if cs.tracing(ctx) {
+		trace = &ResponseInfo{RequestInfo: RequestInfo{Command: api, Method: req.Method, Params: sanitizeParams(params), Attempt: attempt}}
+		cs.traceRequest(ctx, &trace.RequestInfo)
 	}
+	start := time.Now()
+
+	resp, err := cs.client.Do(req)
 	if err != nil {
-		return nil, err
+		err = redactURLError(err)
+		cs.traceResponse(ctx, trace, start, nil, nil, err)
+		return sent.Load(), err
 	}
 	defer resp.Body.Close()
 
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	// Keep a copy of the body for the hooks and the logger
+	var body io.Reader = resp.Body
+	var buf bytes.Buffer
+	if trace != nil {
+		body = io.TeeReader(resp.Body, &buf)
 	}
 
-	// Need to get the raw value to make the result play nice
-	b, err = getRawValue(b)
+	if resp.StatusCode != 200 {
+		b, err := ioutil.ReadAll(body)
+		if err == nil {
+			err = cs.checkClockSkew(ctx, resp, signer, newCSError(resp.StatusCode, api, params, b))
+		}
+		if sessionKey != "" && IsAuthError(err) {
+			cs.session.expire(sessionKey)
+		}
+		cs.traceResponse(ctx, trace, start, resp, &buf, err)
+		return true, err
+	}
+
+	// Decode the value wrapped in the response envelope while reading the body
+	err = decodeEnvelope(body, v)
+	if trace != nil {
+		io.Copy(ioutil.Discard, body)
+		cs.traceResponse(ctx, trace, start, resp, &buf, err)
+	}
+	return true, err
+}
+
+// When a request with an expiring signature is refused, compares the clock of the API server
+// with the local clock. If they differ too much, the expiry of the following requests is
+// corrected and a *ClockSkewError is returned, so the request is retried.
+func (cs *KCPSClient) checkClockSkew(ctx context.Context, resp *http.Response, signer Signer, err *CSError) error {
+	if signer.Expiry == 0 || !IsAuthError(err) {
+		return err
+	}
+	date, perr := http.ParseTime(resp.Header.Get("Date"))
+	if perr != nil {
+		return err
+	}
+
+	skew := date.Sub(time.Now())
+	if d := skew - signer.ClockOffset; d > -maxClockSkew && d < maxClockSkew {
+		return err
+	}
+	cs.clockSkew.Store(int64(skew))
+
+	if cs.logger != nil {
+		cs.logger.LogAttrs(ctx, slog.LevelWarn, "The clock of the API server differs from the local clock",
+			slog.String("command", err.Command), slog.Duration("skew", skew))
+	}
+	return &ClockSkewError{Skew: skew, Err: err}
+}
+
+// Returns the credentials to sign the next request with.
+func (cs *KCPSClient) credentials(ctx context.Context) (Credentials, error) {
+	if cs.provider == nil {
+		return Credentials{APIURL: cs.baseURL, APIKey: cs.apiKey, SecretKey: cs.secret}, nil
+	}
+	c, err := cs.provider.Credentials(ctx)
 	if err != nil {
-		return nil, err
+		return Credentials{}, err
+	}
+	if c.APIURL == "" {
+		c.APIURL = cs.baseURL
 	}
+	return c, nil
+}
 
-	if resp.StatusCode != 200 {
-		var e CSError
-		if err := json.Unmarshal(b, &e); err != nil {
-			return nil, err
+// Builds the error for a failed API call. Error bodies that cannot be decoded, e.g. the HTML
+// page of a proxy, are returned as the error text.
+func newCSError(status int, api string, params url.Values, body []byte) *CSError {
+	e := &CSError{
+		HTTPStatus: status,
+		Command:    api,
+		Params:     sanitizeParams(params),
+	}
+
+	if raw, err := getRawValue(body); err == nil {
+		if err := json.Unmarshal(raw, e); err == nil && e.ErrorText != "" {
+			return e
 		}
-		return nil, e.Error()
 	}
-	return b, nil
+
+	text := strings.TrimSpace(string(body))
+	if len(text) > 512 {
+		text = text[:512] + "..."
+	}
+	if text == "" {
+		text = http.StatusText(status)
+	}
+	e.ErrorText = text
+	return e
+}
+
+// Pauses the current goroutine for at least the duration d, or until ctx is done. The
+// returned error is ctx.Err() when ctx finished first and nil otherwise.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
 
 // Custom version of net/url Encode that only URL escapes values
@@ -393,8 +582,31 @@ func WithVPCID(id string) OptionFunc {
 	}
 }
 
+// ZoneIDSetter is an interface that every type that can set a zone ID must implement
+type ZoneIDSetter interface {
+	SetZoneid(string)
+}
+
+// WithZoneID takes a zone ID and sets the `zoneid` parameter
+func WithZoneID(id string) OptionFunc {
+	return func(cs *KCPSClient, p interface{}) error {
+		zs, ok := p.(ZoneIDSetter)
+
+		if !ok || id == "" {
+			return nil
+		}
+
+		zs.SetZoneid(id)
+
+		return nil
+	}
+}
+
 type AsyncjobService struct {
 	cs *KCPSClient
+
+	trackerOnce sync.Once
+	tracker     *JobTracker // Tracks the jobs returned by the XxxJob calls
 }
 
 func NewAsyncjobService(cs *KCPSClient) *AsyncjobService {
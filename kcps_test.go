@@ -0,0 +1,208 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/uesyn/gokcps/gokcpstest"
+)
+
+// Starts a fake KCPS API and returns it with a client using its credentials.
+func newTestClient(t *testing.T, opts ...Option) (*gokcpstest.Server, *KCPSClient) {
+	t.Helper()
+
+	srv := gokcpstest.NewServer()
+	t.Cleanup(srv.Close)
+
+	cs, err := New(srv.URL, srv.APIKey, srv.SecretKey, opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return srv, cs
+}
+
+func TestGetAsyncJobResultZeroTimeout(t *testing.T) {
+	for _, timeout := range []int64{0, -1} {
+		srv, cs := newTestClient(t)
+		srv.SetJobDelay(time.Hour)
+
+		r, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol1"))
+		if err != nil {
+			t.Fatalf("CreateVolume: %v", err)
+		}
+
+		_, err = cs.GetAsyncJobResult(r.JobID, timeout)
+		if !errors.Is(err, AsyncTimeoutErr) {
+			t.Errorf("timeout %d: err = %v, want AsyncTimeoutErr", timeout, err)
+		}
+		if n := srv.Count("queryAsyncJobResult"); n != 1 {
+			t.Errorf("timeout %d: polled %d times, want 1", timeout, n)
+		}
+	}
+}
+
+func TestWrongSecret(t *testing.T) {
+	srv, _ := newTestClient(t)
+	cs, err := New(srv.URL, srv.APIKey, "wrong")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	if !IsAuthError(err) {
+		t.Fatalf("err = %v, want an auth error", err)
+	}
+	if e, ok := err.(*CSError); !ok || e.HTTPStatus != 401 || e.Command != "listZones" {
+		t.Errorf("err = %#v, want a *CSError with status 401 for listZones", err)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	srv, cs := newTestClient(t)
+	srv.FailNext("listZones", 431, 431, "Unable to execute API command")
+
+	_, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	e, ok := err.(*CSError)
+	if !ok || e.HTTPStatus != 431 || e.ErrorText != "Unable to execute API command" {
+		t.Fatalf("err = %v, want the injected failure", err)
+	}
+
+	r, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	if err != nil {
+		t.Fatalf("ListZones after the failure: %v", err)
+	}
+	if len(r.Zones) != 1 || r.Zones[0].Id != srv.ZoneID {
+		t.Errorf("zones = %+v, want the zone of the server", r.Zones)
+	}
+}
+
+// Returns the params for a 10 GB data volume in the zone of srv.
+func newTestVolumeParams(cs *KCPSClient, srv *gokcpstest.Server, name string) *CreateVolumeParams {
+	p := cs.Volume.NewCreateVolumeParams()
+	p.SetName(name)
+	p.SetZoneid(srv.ZoneID)
+	p.SetDiskofferingid(srv.DiskOfferingID)
+	p.SetSize(10)
+	return p
+}
+
+func TestFailNextJob(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true))
+	srv.FailNextJob("createVolume", "Insufficient capacity")
+
+	_, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol1"))
+	var e *AsyncJobError
+	if !errors.As(err, &e) || e.ErrorText != "Insufficient capacity" || e.JobID == "" {
+		t.Fatalf("err = %v, want an *AsyncJobError with the injected text", err)
+	}
+
+	if _, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol2")); err != nil {
+		t.Errorf("CreateVolume after the failed job: %v", err)
+	}
+}
+
+func TestSetJobDelay(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true), WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1}))
+	srv.SetJobDelay(50 * time.Millisecond)
+
+	start := time.Now()
+	r, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol1"))
+	if err != nil {
+		t.Fatalf("CreateVolume: %v", err)
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("CreateVolume returned after %s, before the job finished", d)
+	}
+	if r.Id == "" || r.Name != "vol1" {
+		t.Errorf("volume = %+v, want the created volume", r)
+	}
+	if n := srv.Count("queryAsyncJobResult"); n < 2 {
+		t.Errorf("polled %d times, want the job to be polled until it finished", n)
+	}
+}
+
+func TestSetJobDelayTimeout(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true),
+		WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1, Timeout: 30 * time.Millisecond}))
+	srv.SetJobDelay(time.Hour)
+
+	_, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, "vol1"))
+	var e *AsyncTimeoutError
+	if !errors.As(err, &e) || e.JobID == "" {
+		t.Fatalf("err = %v, want an *AsyncTimeoutError", err)
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	t.Run("cancelled before the call", func(t *testing.T) {
+		srv, cs := newTestClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := cs.AccountDomain.ListZonesWithContext(ctx, cs.AccountDomain.NewListZonesParams())
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+		if n := srv.Count("listZones"); n != 0 {
+			t.Errorf("listZones was sent %d times, want 0", n)
+		}
+	})
+
+	t.Run("during the request", func(t *testing.T) {
+		hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer hung.Close()
+		cs, err := New(hung.URL, "key", "secret")
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = cs.AccountDomain.ListZonesWithContext(ctx, cs.AccountDomain.NewListZonesParams())
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want context.DeadlineExceeded", err)
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			t.Errorf("returned after %s, want right after the deadline", d)
+		}
+	})
+
+	t.Run("while waiting for the job", func(t *testing.T) {
+		srv, cs := newTestClient(t, WithAsync(true), WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1}))
+		srv.SetJobDelay(time.Hour)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+		_, err := cs.VirtualMachine.DeployValueVirtualMachineWithContext(ctx, p)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want context.DeadlineExceeded", err)
+		}
+		if n := srv.Count("queryExAsyncJobResult"); n == 0 {
+			t.Error("the job was not polled before the deadline")
+		}
+	})
+}
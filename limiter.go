@@ -0,0 +1,133 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter throttles the HTTP requests a client sends to the API. Acquire blocks until a
+// request may be sent or until ctx is done. The returned release function must be called
+// once the request has finished.
+type Limiter interface {
+	Acquire(ctx context.Context) (release func(), err error)
+}
+
+func noopRelease() {}
+
+type concurrencyLimiter struct {
+	sem chan struct{}
+}
+
+// NewConcurrencyLimiter returns a Limiter that allows at most n requests to be in flight
+// at the same time.
+func NewConcurrencyLimiter(n int) Limiter {
+	if n < 1 {
+		n = 1
+	}
+	return &concurrencyLimiter{sem: make(chan struct{}, n)}
+}
+
+func (l *concurrencyLimiter) Acquire(ctx context.Context) (func(), error) {
+	select {
+	case l.sem <- struct{}{}:
+		return func() { <-l.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter returns a token bucket Limiter that allows one request per interval,
+// with bursts of up to burst requests. For example NewRateLimiter(2*time.Second, 1)
+// spaces all requests at least two seconds apart.
+func NewRateLimiter(interval time.Duration, burst int) Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+func (l *rateLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l.interval <= 0 {
+		return noopRelease, nil
+	}
+
+	// Reserve a token up front, so waiting callers are served in order
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	wait := time.Duration(-l.tokens * float64(l.interval))
+	l.mu.Unlock()
+
+	if wait > 0 {
+		if err := sleepContext(ctx, wait); err != nil {
+			// Give back the reservation we are not going to use
+			l.mu.Lock()
+			l.tokens++
+			l.mu.Unlock()
+			return nil, err
+		}
+	}
+	return noopRelease, nil
+}
+
+type multiLimiter []Limiter
+
+// MultiLimiter returns a Limiter that acquires all given limiters in order, for example
+// to combine a concurrency limit with a rate limit.
+func MultiLimiter(limiters ...Limiter) Limiter {
+	return multiLimiter(limiters)
+}
+
+func (m multiLimiter) Acquire(ctx context.Context) (func(), error) {
+	releases := make([]func(), 0, len(m))
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, l := range m {
+		r, err := l.Acquire(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
@@ -0,0 +1,120 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Counts the requests in flight, delaying each one a little so they overlap.
+type inflightTransport struct {
+	cur, max atomic.Int32
+}
+
+func (t *inflightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := t.cur.Add(1)
+	defer t.cur.Add(-1)
+	for {
+		m := t.max.Load()
+		if n <= m || t.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	tr := &inflightTransport{}
+	srv, cs := newTestClient(t, WithTransport(tr), WithLimiter(NewConcurrencyLimiter(2)))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ListZones: %v", err)
+		}
+	}
+
+	if n := srv.Count("listZones"); n != 10 {
+		t.Errorf("listZones was sent %d times, want 10", n)
+	}
+	if m := tr.max.Load(); m > 2 {
+		t.Errorf("%d requests were in flight at the same time, want at most 2", m)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	const interval = 30 * time.Millisecond
+	srv, cs := newTestClient(t, WithLimiter(NewRateLimiter(interval, 2)))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+			t.Fatalf("ListZones: %v", err)
+		}
+	}
+	// The burst of 2 is sent right away, the 3 other requests wait an interval each
+	if d := time.Since(start); d < 3*interval-5*time.Millisecond {
+		t.Errorf("5 requests took %s, want at least %s", d, 3*interval)
+	}
+	if n := srv.Count("listZones"); n != 5 {
+		t.Errorf("listZones was sent %d times, want 5", n)
+	}
+}
+
+func TestLimiterContext(t *testing.T) {
+	l := NewRateLimiter(time.Hour, 1)
+	release, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire = %v, want context.DeadlineExceeded", err)
+	}
+
+	// A full concurrency limiter also gives up when ctx is done, also within a MultiLimiter
+	c := NewConcurrencyLimiter(1)
+	if _, err := c.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := MultiLimiter(NewRateLimiter(0, 1), c).Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire of a full concurrency limiter = %v, want context.DeadlineExceeded", err)
+	}
+}
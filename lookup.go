@@ -0,0 +1,67 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+// Helpers shared by the GetXxxByName, GetXxxByID and GetXxxID calls of the services.
+
+// Returns the only item with exactly the given name. As the API matches names loosely,
+// the items are filtered again. The returned count is the number of matching items.
+func pickByName[T any](kind, name string, items []T, nameOf, idOf func(T) string) (T, int, error) {
+	var matches []T
+	for _, v := range items {
+		if nameOf(v) == name {
+			matches = append(matches, v)
+		}
+	}
+	return pickOne(kind, name, matches, idOf)
+}
+
+// Like pickByName, for the item with the given ID.
+func pickByID[T any](kind, id string, items []T, idOf func(T) string) (T, int, error) {
+	return pickByName(kind, id, items, idOf, idOf)
+}
+
+// Returns the only item with a tag with the given key and value. The returned count is the
+// number of matching items.
+func pickByTag[T any](kind, key, value string, items []T, tagsOf func(T) []Tag, idOf func(T) string) (T, int, error) {
+	var matches []T
+	for _, v := range items {
+		for _, t := range tagsOf(v) {
+			if t.Key == key && t.Value == value {
+				matches = append(matches, v)
+				break
+			}
+		}
+	}
+	return pickOne(kind, key+"="+value, matches, idOf)
+}
+
+func pickOne[T any](kind, key string, matches []T, idOf func(T) string) (T, int, error) {
+	var zero T
+	switch len(matches) {
+	case 0:
+		return zero, 0, &NotFoundError{Kind: kind, Key: key}
+	case 1:
+		return matches[0], 1, nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, v := range matches {
+		ids[i] = idOf(v)
+	}
+	return zero, len(matches), &AmbiguousError{Kind: kind, Key: key, IDs: ids}
+}
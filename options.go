@@ -0,0 +1,461 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultUserAgent is the User-Agent header sent with every request, unless WithUserAgent is used.
+const DefaultUserAgent = "gokcps"
+
+// Defaults of the client settings that can be changed with an Option
+const (
+	DefaultHTTPTimeout  = 60 * time.Second
+	DefaultAsyncTimeout = 300 * time.Second
+	DefaultPollInterval = time.Second
+)
+
+// DefaultNetworkName is the network virtual machines are deployed in, unless the deploy params
+// contain a network or WithDefaultNetwork is used.
+const DefaultNetworkName = "PublicFrontSegment"
+
+// Option configures a client created with New.
+type Option func(*clientConfig) error
+
+// Collects the settings of all options, before the client and its transport are built
+type clientConfig struct {
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	tlsConfig   *tls.Config
+	tlsChanged  bool  // Whether an option changed tlsConfig
+	skipVerify  *bool // Set by WithInsecureSkipVerify, applied to tlsConfig after all options
+	proxy       func(*http.Request) (*url.URL, error)
+	httpTimeout time.Duration
+	userAgent   string
+	httpGETOnly bool
+	async       bool
+	jobWaiter   JobWaiter
+	retryPolicy RetryPolicy
+	retryHook   RetryHook
+	limiter     Limiter
+	logger      *slog.Logger
+	location    *time.Location
+	network     string
+	before      BeforeRequestHook
+	after       AfterResponseHook
+	provider    CredentialProvider
+	expiry      time.Duration
+	session     *session
+	middleware  []Middleware
+}
+
+// New creates a new client for communicating with the KCPS API, configured by the given options.
+// Without any options it returns a non-async client that verifies the TLS certificate of the API.
+func New(apiurl string, apikey string, secret string, opts ...Option) (*KCPSClient, error) {
+	cfg := &clientConfig{
+		tlsConfig:   &tls.Config{},
+		proxy:       http.ProxyFromEnvironment,
+		httpTimeout: DefaultHTTPTimeout,
+		userAgent:   DefaultUserAgent,
+		jobWaiter:   DefaultJobWaiter(),
+		retryPolicy: DefaultRetryPolicy(),
+		network:     DefaultNetworkName,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.session != nil && (apikey != "" || secret != "" || cfg.provider != nil) {
+		return nil, errors.New("WithLogin cannot be combined with API keys or WithCredentials")
+	}
+
+	client, err := cfg.buildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &KCPSClient{
+		HTTPGETOnly: cfg.httpGETOnly,
+		client:      client,
+		baseURL:     apiurl,
+		apiKey:      apikey,
+		secret:      secret,
+		userAgent:   cfg.userAgent,
+		async:       cfg.async,
+		jobWaiter:   cfg.jobWaiter,
+		limiter:     cfg.limiter,
+		retryPolicy: cfg.retryPolicy,
+		retryHook:   cfg.retryHook,
+		logger:      cfg.logger,
+		location:    cfg.location,
+
+		defaultNetwork: cfg.network,
+		beforeRequest:  cfg.before,
+		afterResponse:  cfg.after,
+		provider:       cfg.provider,
+
+		signatureExpiry: cfg.expiry,
+		session:         cfg.session,
+		middleware:      cfg.middleware,
+	}
+	cs.initServices()
+	return cs, nil
+}
+
+// Returns the HTTP client to use. The TLS options only apply to the transport built by the
+// client itself, so combining them with a custom client or transport is an error.
+func (cfg *clientConfig) buildHTTPClient() (*http.Client, error) {
+	if cfg.httpClient != nil && cfg.transport != nil {
+		return nil, errors.New("WithHTTPClient cannot be combined with WithTransport")
+	}
+	if cfg.tlsChanged && (cfg.httpClient != nil || cfg.transport != nil) {
+		return nil, errors.New("TLS options cannot be combined with WithHTTPClient or WithTransport")
+	}
+
+	if cfg.httpClient != nil {
+		return cfg.httpClient, nil
+	}
+
+	if cfg.skipVerify != nil {
+		cfg.tlsConfig.InsecureSkipVerify = *cfg.skipVerify
+	}
+
+	transport := cfg.transport
+	if transport == nil {
+		transport = &http.Transport{
+			Proxy:               cfg.proxy,
+			TLSClientConfig:     cfg.tlsConfig,
+			TLSHandshakeTimeout: 10 * time.Second,
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   cfg.httpTimeout,
+	}, nil
+}
+
+// WithHTTPClient makes the client send all requests using c. The client is used as is, so the
+// options configuring the transport or the HTTP timeout have no effect.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *clientConfig) error {
+		if c == nil {
+			return errors.New("WithHTTPClient: nil client")
+		}
+		cfg.httpClient = c
+		return nil
+	}
+}
+
+// WithTransport makes the client send all requests using rt, e.g. a preconfigured mTLS
+// transport or a test double.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(cfg *clientConfig) error {
+		if rt == nil {
+			return errors.New("WithTransport: nil transport")
+		}
+		cfg.transport = rt
+		return nil
+	}
+}
+
+// WithProxy sets the function returning the proxy for a request. The default is
+// http.ProxyFromEnvironment, pass nil to never use a proxy.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(cfg *clientConfig) error {
+		cfg.proxy = proxy
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API. Options like WithCABundle
+// that are passed after it modify a copy of c, the ones passed before it are discarded, except
+// WithInsecureSkipVerify.
+func WithTLSConfig(c *tls.Config) Option {
+	return func(cfg *clientConfig) error {
+		if c == nil {
+			return errors.New("WithTLSConfig: nil config")
+		}
+		cfg.tlsConfig = c.Clone()
+		cfg.tlsChanged = true
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the API certificate when skip is true.
+// Only use this when connecting to an API with a self-signed certificate. The last one passed
+// wins, also over the setting of a config passed to WithTLSConfig, so false turns the
+// verification back on.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(cfg *clientConfig) error {
+		cfg.skipVerify = &skip
+		cfg.tlsChanged = true
+		return nil
+	}
+}
+
+// WithCABundle makes the client trust the PEM encoded CA certificates in pem, instead of the
+// system certificates.
+func WithCABundle(pem []byte) Option {
+	return func(cfg *clientConfig) error {
+		pool := cfg.tlsConfig.RootCAs
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("WithCABundle: no certificates found")
+		}
+		cfg.tlsConfig.RootCAs = pool
+		cfg.tlsChanged = true
+		return nil
+	}
+}
+
+// WithCAFile is like WithCABundle, but reads the certificates from the file at path.
+func WithCAFile(path string) Option {
+	return func(cfg *clientConfig) error {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("WithCAFile: %w", err)
+		}
+		return WithCABundle(pem)(cfg)
+	}
+}
+
+// WithClientCertificate makes the client authenticate itself with cert when the API asks
+// for a client certificate.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(cfg *clientConfig) error {
+		cfg.tlsConfig.Certificates = append(cfg.tlsConfig.Certificates, cert)
+		cfg.tlsChanged = true
+		return nil
+	}
+}
+
+// WithClientCertFile is like WithClientCertificate, but loads the PEM encoded certificate
+// and key from the given files.
+func WithClientCertFile(certFile, keyFile string) Option {
+	return func(cfg *clientConfig) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("WithClientCertFile: %w", err)
+		}
+		return WithClientCertificate(cert)(cfg)
+	}
+}
+
+// WithHTTPTimeout sets the time limit for a single HTTP request, including reading the response.
+// The default is 60 seconds, 0 means no limit.
+func WithHTTPTimeout(d time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if d < 0 {
+			return errors.New("WithHTTPTimeout: negative timeout")
+		}
+		cfg.httpTimeout = d
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.userAgent = ua
+		return nil
+	}
+}
+
+// WithHTTPGETOnly makes the client use HTTP GET for all calls, see KCPSClient.HTTPGETOnly.
+func WithHTTPGETOnly(getOnly bool) Option {
+	return func(cfg *clientConfig) error {
+		cfg.httpGETOnly = getOnly
+		return nil
+	}
+}
+
+// WithAsync makes async API calls wait until the async job is finished, see NewAsyncClient.
+func WithAsync(async bool) Option {
+	return func(cfg *clientConfig) error {
+		cfg.async = async
+		return nil
+	}
+}
+
+// WithAsyncTimeout sets how long async API calls wait for the async job to finish. The
+// default is 300 seconds.
+func WithAsyncTimeout(d time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if d <= 0 {
+			return errors.New("WithAsyncTimeout: timeout must be positive")
+		}
+		cfg.jobWaiter.Timeout = d
+		return nil
+	}
+}
+
+// WithPollInterval sets the interval between the first polls for the result of an async job.
+// The interval grows with every poll, up to 15 times the given interval. The default is one second.
+func WithPollInterval(d time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if d <= 0 {
+			return errors.New("WithPollInterval: interval must be positive")
+		}
+		cfg.jobWaiter.InitialInterval = d
+		cfg.jobWaiter.MaxInterval = 15 * d
+		return nil
+	}
+}
+
+// WithJobWaiter sets how async API calls and WaitForJob poll the results of async jobs. Its
+// Timeout is the async timeout and UseExQuery is ignored by the API calls, as they know which
+// query they need.
+func WithJobWaiter(w JobWaiter) Option {
+	return func(cfg *clientConfig) error {
+		cfg.jobWaiter = w
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy that decides which failed requests are retried, see SetRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retryPolicy = p
+		return nil
+	}
+}
+
+// WithRetryHook sets a hook which is called before a failed request is retried, see SetRetryHook.
+func WithRetryHook(h RetryHook) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retryHook = h
+		return nil
+	}
+}
+
+// WithLimiter sets a limiter for the outgoing requests, see SetLimiter.
+func WithLimiter(l Limiter) Option {
+	return func(cfg *clientConfig) error {
+		cfg.limiter = l
+		return nil
+	}
+}
+
+// WithLogger sets the logger the client reports retries and other noteworthy events to. At the
+// debug level every request and response is logged, with sensitive values redacted. By default
+// nothing is logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(cfg *clientConfig) error {
+		cfg.logger = l
+		return nil
+	}
+}
+
+// WithLocation converts the timestamps of all responses to loc, e.g. to show them in JST
+// regardless of the location of the machine. By default they keep the time zone they were
+// returned with, and epoch milliseconds are in the local time zone.
+func WithLocation(loc *time.Location) Option {
+	return func(cfg *clientConfig) error {
+		if loc == nil {
+			return errors.New("WithLocation needs a location")
+		}
+		cfg.location = loc
+		return nil
+	}
+}
+
+// WithDefaultNetwork sets the name or ID of the network virtual machines are deployed in when
+// the deploy params do not contain any network, see SetDefaultNetwork. An empty string disables
+// the default network.
+func WithDefaultNetwork(nameOrID string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.network = nameOrID
+		return nil
+	}
+}
+
+// WithBeforeRequest sets a hook which is called before every HTTP request, see SetBeforeRequest.
+func WithBeforeRequest(h BeforeRequestHook) Option {
+	return func(cfg *clientConfig) error {
+		cfg.before = h
+		return nil
+	}
+}
+
+// WithAfterResponse sets a hook which is called after every HTTP request, see SetAfterResponse.
+func WithAfterResponse(h AfterResponseHook) Option {
+	return func(cfg *clientConfig) error {
+		cfg.after = h
+		return nil
+	}
+}
+
+// WithCredentials makes the client ask p for the API URL and keys before every request, instead
+// of using the ones it was created with. This way rotated keys are used without rebuilding the
+// client.
+func WithCredentials(p CredentialProvider) Option {
+	return func(cfg *clientConfig) error {
+		if p == nil {
+			return errors.New("WithCredentials: nil provider")
+		}
+		cfg.provider = p
+		return nil
+	}
+}
+
+// WithSignatureExpiry makes the client sign requests with signature version 3, so the API
+// refuses them when they arrive later than d after they were signed. Captured requests are
+// then only valid for a short time. By default signatures do not expire.
+func WithSignatureExpiry(d time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if d < 0 {
+			return errors.New("WithSignatureExpiry: negative expiry")
+		}
+		cfg.expiry = d
+		return nil
+	}
+}
+
+// WithLogin makes the client log in with the given username and password instead of signing
+// the requests with API keys, for users that only have portal credentials. The domain is the
+// path of the domain of the user, e.g. "/" or "ROOT/sub"; it may be empty for the root domain.
+// The client logs in with the first request, and again when the session expired. Call Close
+// to log out when done. It cannot be combined with API keys or WithCredentials.
+func WithLogin(username, password, domain string) Option {
+	return func(cfg *clientConfig) error {
+		if username == "" || password == "" {
+			return errors.New("WithLogin needs a username and password")
+		}
+		cfg.session = &session{username: username, password: password, domain: domain}
+		return nil
+	}
+}
+
+// WithMiddleware adds middleware to the chain every command of the client goes through, see Use.
+func WithMiddleware(m ...Middleware) Option {
+	return func(cfg *clientConfig) error {
+		cfg.middleware = append(cfg.middleware, m...)
+		return nil
+	}
+}
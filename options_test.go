@@ -0,0 +1,97 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Returns whether the transport of cs skips the verification of the API certificate.
+func skipsVerify(t *testing.T, cs *KCPSClient) bool {
+	t.Helper()
+	tr, ok := cs.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want an *http.Transport", cs.client.Transport)
+	}
+	return tr.TLSClientConfig.InsecureSkipVerify
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	insecure := &tls.Config{InsecureSkipVerify: true}
+	tests := []struct {
+		name string
+		opts []Option
+		want bool
+	}{
+		{name: "default"},
+		{name: "skip", opts: []Option{WithInsecureSkipVerify(true)}, want: true},
+		{name: "undone", opts: []Option{WithInsecureSkipVerify(true), WithInsecureSkipVerify(false)}},
+		{name: "before a TLS config", opts: []Option{WithInsecureSkipVerify(true), WithTLSConfig(&tls.Config{})}, want: true},
+		{name: "insecure TLS config", opts: []Option{WithTLSConfig(insecure)}, want: true},
+		{name: "after an insecure TLS config", opts: []Option{WithTLSConfig(insecure), WithInsecureSkipVerify(false)}},
+		{name: "before an insecure TLS config", opts: []Option{WithInsecureSkipVerify(false), WithTLSConfig(insecure)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := New("https://api.example.com/client/api", "key", "secret", tt.opts...)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if got := skipsVerify(t, cs); got != tt.want {
+				t.Errorf("InsecureSkipVerify = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !insecure.InsecureSkipVerify {
+		t.Error("the config passed to WithTLSConfig was modified")
+	}
+	if _, err := New("https://api.example.com/client/api", "key", "secret",
+		WithInsecureSkipVerify(false), WithTransport(http.DefaultTransport)); err == nil {
+		t.Error("expected an error for WithInsecureSkipVerify combined with WithTransport")
+	}
+}
+
+func TestNewClientFromProfileVerifyCert(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	config := "[test]\nurl = https://api.example.com/client/api\napikey = key\nsecretkey = secret\nverifycert = false\n"
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvConfigFile, path)
+
+	cs, err := NewClientFromProfile("test")
+	if err != nil {
+		t.Fatalf("NewClientFromProfile: %v", err)
+	}
+	if !skipsVerify(t, cs) {
+		t.Error("the certificate is verified, want verifycert = false to skip it")
+	}
+
+	cs, err = NewClientFromProfile("test", WithInsecureSkipVerify(false))
+	if err != nil {
+		t.Fatalf("NewClientFromProfile: %v", err)
+	}
+	if skipsVerify(t, cs) {
+		t.Error("the certificate is not verified, want WithInsecureSkipVerify(false) to override the profile")
+	}
+}
@@ -0,0 +1,142 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+)
+
+// DefaultPageSize is the page size used by a Pager when the params do not set one.
+const DefaultPageSize = 500
+
+// Pager walks all pages of a list call, requesting the next page when the items of the
+// previous one are used up, until as many items as the count reported by the API were
+// returned. A Pager is created by the ListXxxPager calls of the services and is not safe
+// for concurrent use.
+//
+//	pg := cs.VirtualMachine.ListVirtualMachinesPager(p)
+//	for pg.Next(ctx) {
+//		vm := pg.Value()
+//		...
+//	}
+//	if err := pg.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	params map[string]interface{}
+	fetch  func(context.Context, map[string]interface{}) ([]T, int, error)
+
+	paged    bool // Whether the command supports the page and pagesize params
+	page     int
+	pagesize int
+	seen     int // Number of items up to and including the current page
+	count    int
+	items    []T // Remaining items of the current page
+	value    T
+	done     bool
+	err      error
+}
+
+// Returns a Pager requesting the pages with a copy of params. The page set in params is
+// the first page requested, the page size defaults to DefaultPageSize.
+func newPager[T any](params map[string]interface{}, fetch func(context.Context, map[string]interface{}) ([]T, int, error)) *Pager[T] {
+	pg := &Pager[T]{
+		params:   make(map[string]interface{}, len(params)+2),
+		fetch:    fetch,
+		paged:    true,
+		page:     1,
+		pagesize: DefaultPageSize,
+	}
+	for k, v := range params {
+		pg.params[k] = v
+	}
+	if v, ok := params["pagesize"].(int); ok && v > 0 {
+		pg.pagesize = v
+	}
+	if v, ok := params["page"].(int); ok && v > 0 {
+		pg.page = v
+		pg.seen = (v - 1) * pg.pagesize
+	}
+	return pg
+}
+
+// Returns a Pager for a list command without the page and pagesize params, which makes a
+// single request with a copy of params.
+func newUnpagedPager[T any](params map[string]interface{}, fetch func(context.Context, map[string]interface{}) ([]T, int, error)) *Pager[T] {
+	pg := newPager(params, fetch)
+	pg.paged = false
+	return pg
+}
+
+// Next advances to the next item, requesting the next page when needed. It returns false
+// when all items were returned or when a request failed, which is reported by Err.
+func (pg *Pager[T]) Next(ctx context.Context) bool {
+	for len(pg.items) == 0 {
+		if pg.done || pg.err != nil {
+			return false
+		}
+		pg.err = pg.nextPage(ctx)
+	}
+	pg.value, pg.items = pg.items[0], pg.items[1:]
+	return true
+}
+
+func (pg *Pager[T]) nextPage(ctx context.Context) error {
+	if pg.paged {
+		pg.params["page"] = pg.page
+		pg.params["pagesize"] = pg.pagesize
+	}
+
+	items, count, err := pg.fetch(ctx, pg.params)
+	if err != nil {
+		return err
+	}
+	pg.page++
+	pg.seen += len(items)
+	pg.count = count
+	pg.items = items
+
+	// An empty page also ends the walk, in case objects were deleted in the meantime
+	if !pg.paged || len(items) == 0 || pg.seen >= count {
+		pg.done = true
+	}
+	return nil
+}
+
+// Value returns the current item.
+func (pg *Pager[T]) Value() T {
+	return pg.value
+}
+
+// Err returns the error of the request that stopped Next, if any.
+func (pg *Pager[T]) Err() error {
+	return pg.err
+}
+
+// Count returns the total number of items reported by the API with the last page.
+func (pg *Pager[T]) Count() int {
+	return pg.count
+}
+
+// All returns all remaining items.
+func (pg *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for pg.Next(ctx) {
+		all = append(all, pg.value)
+	}
+	return all, pg.err
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func newTestVolumes(t *testing.T, n int) (*KCPSClient, func() int) {
+	t.Helper()
+	srv, cs := newTestClient(t, WithAsync(true))
+	for i := 0; i < n; i++ {
+		if _, err := cs.Volume.CreateVolume(newTestVolumeParams(cs, srv, fmt.Sprintf("vol%d", i))); err != nil {
+			t.Fatalf("CreateVolume: %v", err)
+		}
+	}
+	return cs, func() int { return srv.Count("listVolumes") }
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name     string
+		volumes  int
+		pagesize int
+		requests int
+	}{
+		{name: "multiple pages", volumes: 5, pagesize: 2, requests: 3},
+		{name: "exact multiple of the page size", volumes: 4, pagesize: 2, requests: 2},
+		{name: "single page", volumes: 3, pagesize: 5, requests: 1},
+		{name: "empty", volumes: 0, pagesize: 2, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, requests := newTestVolumes(t, tt.volumes)
+
+			p := cs.Volume.NewListVolumesParams()
+			p.SetPagesize(tt.pagesize)
+			vols, err := cs.Volume.ListVolumesAll(context.Background(), p)
+			if err != nil {
+				t.Fatalf("ListVolumesAll: %v", err)
+			}
+
+			seen := make(map[string]bool)
+			for _, v := range vols {
+				seen[v.Id] = true
+			}
+			if len(vols) != tt.volumes || len(seen) != tt.volumes {
+				t.Errorf("got %d volumes, %d of them unique, want %d", len(vols), len(seen), tt.volumes)
+			}
+			if n := requests(); n != tt.requests {
+				t.Errorf("listVolumes was sent %d times, want %d", n, tt.requests)
+			}
+			if _, ok := p.p["page"]; ok {
+				t.Error("the pager changed the params")
+			}
+		})
+	}
+}
+
+func TestPagerEarlyStop(t *testing.T) {
+	cs, requests := newTestVolumes(t, 5)
+
+	p := cs.Volume.NewListVolumesParams()
+	p.SetPagesize(2)
+	pg := cs.Volume.ListVolumesPager(p)
+	for i := 0; i < 3; i++ {
+		if !pg.Next(context.Background()) {
+			t.Fatalf("Next returned false after %d volumes: %v", i, pg.Err())
+		}
+	}
+	if n := requests(); n != 2 {
+		t.Errorf("listVolumes was sent %d times for 3 volumes, want 2", n)
+	}
+	if pg.Count() != 5 {
+		t.Errorf("Count = %d, want 5", pg.Count())
+	}
+}
+
+func TestUnpagedPager(t *testing.T) {
+	var calls []map[string]interface{}
+	pg := newUnpagedPager(map[string]interface{}{"id": "iso1"}, func(ctx context.Context, params map[string]interface{}) ([]string, int, error) {
+		calls = append(calls, params)
+		return []string{"a", "b"}, 3, nil
+	})
+
+	all, err := pg.All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(all) != 2 || len(calls) != 1 {
+		t.Fatalf("got %v in %d requests, want 2 items in a single request", all, len(calls))
+	}
+	if _, ok := calls[0]["page"]; ok {
+		t.Errorf("params = %v, want no page", calls[0])
+	}
+	if _, ok := calls[0]["pagesize"]; ok {
+		t.Errorf("params = %v, want no pagesize", calls[0])
+	}
+}
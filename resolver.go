@@ -0,0 +1,214 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultResolverTTL is how long a Resolver caches a resolved ID, unless NewResolver is
+// given another duration.
+const DefaultResolverTTL = 10 * time.Minute
+
+// Resolver resolves the names of zones, offerings, templates and networks to their IDs,
+// so deploy parameters can be written with names instead of UUIDs. Values for which IsID
+// returns true are used as is. Resolved IDs are cached, and a Resolver is safe for
+// concurrent use.
+//
+// When a name matches more than one resource, the error is an *AmbiguousError listing the
+// IDs of all candidates. When it matches none, the error is a *NotFoundError.
+type Resolver struct {
+	cs  *KCPSClient
+	ttl time.Duration
+
+	mu    sync.Mutex
+	cache map[resolverKey]resolvedID
+}
+
+type resolverKey struct {
+	kind   string
+	zoneid string
+	name   string
+}
+
+type resolvedID struct {
+	id      string
+	expires time.Time
+}
+
+// NewResolver returns a Resolver caching resolved IDs for ttl. A ttl of 0 uses
+// DefaultResolverTTL.
+func (cs *KCPSClient) NewResolver(ttl time.Duration) *Resolver {
+	if ttl == 0 {
+		ttl = DefaultResolverTTL
+	}
+	return &Resolver{
+		cs:    cs,
+		ttl:   ttl,
+		cache: make(map[resolverKey]resolvedID),
+	}
+}
+
+// Flush drops all cached IDs.
+func (r *Resolver) Flush() {
+	r.mu.Lock()
+	r.cache = make(map[resolverKey]resolvedID)
+	r.mu.Unlock()
+}
+
+// ZoneID returns the ID of the zone with the given name or ID.
+func (r *Resolver) ZoneID(ctx context.Context, nameOrID string) (string, error) {
+	return r.resolve(ctx, resolverKey{kind: "Zone", name: nameOrID}, func() (string, int, error) {
+		return r.cs.AccountDomain.GetZoneIDWithContext(ctx, nameOrID)
+	})
+}
+
+// ServiceOfferingID returns the ID of the service offering with the given name or ID.
+func (r *Resolver) ServiceOfferingID(ctx context.Context, nameOrID string) (string, error) {
+	return r.resolve(ctx, resolverKey{kind: "ServiceOffering", name: nameOrID}, func() (string, int, error) {
+		return r.cs.AccountDomain.GetServiceOfferingIDWithContext(ctx, nameOrID)
+	})
+}
+
+// DiskOfferingID returns the ID of the disk offering with the given name or ID.
+func (r *Resolver) DiskOfferingID(ctx context.Context, nameOrID string) (string, error) {
+	return r.resolve(ctx, resolverKey{kind: "DiskOffering", name: nameOrID}, func() (string, int, error) {
+		return r.cs.AccountDomain.GetDiskOfferingIDWithContext(ctx, nameOrID)
+	})
+}
+
+// TemplateID returns the ID of the executable template with the given name or ID in the
+// given zone, which may also be a name.
+func (r *Resolver) TemplateID(ctx context.Context, nameOrID string, zone string) (string, error) {
+	if IsID(nameOrID) {
+		return nameOrID, nil
+	}
+	zoneid, err := r.ZoneID(ctx, zone)
+	if err != nil {
+		return "", err
+	}
+	return r.resolve(ctx, resolverKey{kind: "Template", zoneid: zoneid, name: nameOrID}, func() (string, int, error) {
+		return r.cs.Template.GetTemplateIDWithContext(ctx, nameOrID, "executable", zoneid)
+	})
+}
+
+// NetworkID returns the ID of the network with the given name or ID in the given zone,
+// which may also be a name. An empty zone matches networks in all zones.
+func (r *Resolver) NetworkID(ctx context.Context, nameOrID string, zone string) (string, error) {
+	if IsID(nameOrID) {
+		return nameOrID, nil
+	}
+	var zoneid string
+	if zone != "" {
+		var err error
+		if zoneid, err = r.ZoneID(ctx, zone); err != nil {
+			return "", err
+		}
+	}
+	return r.resolve(ctx, resolverKey{kind: "Network", zoneid: zoneid, name: nameOrID}, func() (string, int, error) {
+		return r.cs.AccountDomain.GetNetworkIDWithContext(ctx, nameOrID, WithZoneID(zoneid))
+	})
+}
+
+func (r *Resolver) resolve(ctx context.Context, key resolverKey, lookup func() (string, int, error)) (string, error) {
+	if IsID(key.name) {
+		return key.name, nil
+	}
+
+	r.mu.Lock()
+	c, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && time.Now().Before(c.expires) {
+		return c.id, nil
+	}
+
+	// Failed lookups are not cached, so a resource created later is found
+	id, _, err := lookup()
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = resolvedID{id: id, expires: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+
+	return id, nil
+}
+
+// Resolves the networks of a deploy call, which all have to be in the given zone.
+func (r *Resolver) networkList(ctx context.Context, zoneid string, networks []string) ([]IptoNetworklistParams, error) {
+	var l []IptoNetworklistParams
+	for _, n := range networks {
+		id, err := r.NetworkID(ctx, n, zoneid)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, IptoNetworklistParams{Networkid: id})
+	}
+	return l, nil
+}
+
+// NewDeployValueVirtualMachineParams is like the VirtualMachineService call of the same
+// name, but the service offering, template, zone and networks may be given by name.
+func (r *Resolver) NewDeployValueVirtualMachineParams(ctx context.Context, serviceoffering string, template string, zone string, name string, networks ...string) (*DeployValueVirtualMachineParams, error) {
+	serviceofferingid, templateid, zoneid, err := r.deployIDs(ctx, serviceoffering, template, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	p := r.cs.VirtualMachine.NewDeployValueVirtualMachineParams(serviceofferingid, templateid, zoneid, name)
+	if len(networks) > 0 {
+		l, err := r.networkList(ctx, zoneid, networks)
+		if err != nil {
+			return nil, err
+		}
+		p.SetIptoNetworklist(l)
+	}
+	return p, nil
+}
+
+// NewDeployPremiumVirtualMachineParams is like the VirtualMachineService call of the same
+// name, but the service offering, template, zone and networks may be given by name.
+func (r *Resolver) NewDeployPremiumVirtualMachineParams(ctx context.Context, serviceoffering string, template string, zone string, name string, hostname string, networks ...string) (*DeployPremiumVirtualMachineParams, error) {
+	serviceofferingid, templateid, zoneid, err := r.deployIDs(ctx, serviceoffering, template, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	p := r.cs.VirtualMachine.NewDeployPremiumVirtualMachineParams(serviceofferingid, templateid, zoneid, name, hostname)
+	if len(networks) > 0 {
+		l, err := r.networkList(ctx, zoneid, networks)
+		if err != nil {
+			return nil, err
+		}
+		p.SetIptoNetworklist(l)
+	}
+	return p, nil
+}
+
+func (r *Resolver) deployIDs(ctx context.Context, serviceoffering, template, zone string) (serviceofferingid, templateid, zoneid string, err error) {
+	if zoneid, err = r.ZoneID(ctx, zone); err != nil {
+		return
+	}
+	if serviceofferingid, err = r.ServiceOfferingID(ctx, serviceoffering); err != nil {
+		return
+	}
+	templateid, err = r.TemplateID(ctx, template, zoneid)
+	return
+}
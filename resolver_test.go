@@ -0,0 +1,107 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	srv, cs := newTestClient(t, WithAsync(true))
+	r := cs.NewResolver(0)
+	ctx := context.Background()
+
+	p, err := r.NewDeployValueVirtualMachineParams(ctx, "Medium", "CentOS 7.2 64-bit", "jp2-east03", "vm1", "PrivateBackSegment")
+	if err != nil {
+		t.Fatalf("NewDeployValueVirtualMachineParams: %v", err)
+	}
+	private, _, err := cs.AccountDomain.GetNetworkID("PrivateBackSegment")
+	if err != nil {
+		t.Fatalf("GetNetworkID: %v", err)
+	}
+	v := p.toURLValues()
+	want := map[string]string{
+		"serviceofferingid":            srv.ServiceOfferingID,
+		"templateid":                   srv.TemplateID,
+		"zoneid":                       srv.ZoneID,
+		"iptonetworklist[0].networkid": private,
+	}
+	for k, id := range want {
+		if v.Get(k) != id {
+			t.Errorf("%s = %q, want %q", k, v.Get(k), id)
+		}
+	}
+	if _, err := cs.VirtualMachine.DeployValueVirtualMachine(p); err != nil {
+		t.Errorf("DeployValueVirtualMachine: %v", err)
+	}
+
+	// IDs are used as is, and names are served from the cache
+	before := len(srv.Requests())
+	if id, err := r.ZoneID(ctx, srv.ZoneID); err != nil || id != srv.ZoneID {
+		t.Errorf("ZoneID(%s) = %q, %v, want the ID itself", srv.ZoneID, id, err)
+	}
+	if id, err := r.ServiceOfferingID(ctx, "Medium"); err != nil || id != srv.ServiceOfferingID {
+		t.Errorf("ServiceOfferingID = %q, %v, want %q", id, err, srv.ServiceOfferingID)
+	}
+	if n := len(srv.Requests()) - before; n != 0 {
+		t.Errorf("sent %d requests for cached and literal IDs, want 0", n)
+	}
+
+	r.Flush()
+	if _, err := r.ServiceOfferingID(ctx, "Medium"); err != nil {
+		t.Fatalf("ServiceOfferingID: %v", err)
+	}
+	if n := srv.Count("listServiceOfferings"); n != 2 {
+		t.Errorf("listServiceOfferings was sent %d times, want 2 after Flush", n)
+	}
+}
+
+func TestResolverErrors(t *testing.T) {
+	srv, cs := newTestClient(t)
+	r := cs.NewResolver(time.Minute)
+	ctx := context.Background()
+
+	_, err := r.ZoneID(ctx, "jp2-west01")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) || nf.Key != "jp2-west01" {
+		t.Errorf("err = %v, want a *NotFoundError for jp2-west01", err)
+	}
+
+	// The zone created after the failed lookup is found, as failures are not cached
+	west, _ := srv.AddZone("jp2-west01")
+	if id, err := r.ZoneID(ctx, "jp2-west01"); err != nil || id != west {
+		t.Errorf("ZoneID = %q, %v, want %q", id, err, west)
+	}
+
+	other, _ := srv.AddZone("jp2-east03")
+	_, err = r.ZoneID(ctx, "jp2-east03")
+	var amb *AmbiguousError
+	if !errors.As(err, &amb) {
+		t.Fatalf("err = %v, want an *AmbiguousError", err)
+	}
+	got := append([]string(nil), amb.IDs...)
+	want := []string{srv.ZoneID, other}
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("candidates = %v, want %v", amb.IDs, want)
+	}
+}
@@ -0,0 +1,191 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryAttempt describes a failed request, which may or may not be retried.
+type RetryAttempt struct {
+	Command string        // The API command
+	Attempt int           // The number of attempts made so far, starting at 1
+	Elapsed time.Duration // The time passed since the first attempt was started
+	Err     error         // The error returned by the last attempt
+	Sent    bool          // Whether the last request was written to the connection, so the API may have received it
+}
+
+// RetryPolicy decides if a failed request is retried and how long to wait before the next attempt.
+type RetryPolicy interface {
+	Retry(a *RetryAttempt) (wait time.Duration, retry bool)
+}
+
+// RetryHook is called before every retry with the failed attempt and the time the client
+// is going to wait before trying again.
+type RetryHook func(a *RetryAttempt, wait time.Duration)
+
+type noRetry struct{}
+
+func (noRetry) Retry(*RetryAttempt) (time.Duration, bool) { return 0, false }
+
+// NoRetry is a RetryPolicy that never retries a failed request.
+var NoRetry RetryPolicy = noRetry{}
+
+// BackoffRetryPolicy retries requests that failed with a retryable error (see IsRetryable),
+// waiting an exponentially growing and randomized interval between the attempts.
+type BackoffRetryPolicy struct {
+	MaxAttempts     int           // Max number of attempts including the first one; 0 means no limit
+	InitialInterval time.Duration // Wait time before the first retry
+	MaxInterval     time.Duration // Upper bound of the wait time between two attempts; 0 means no limit
+	Multiplier      float64       // Factor the wait time grows with after every attempt
+	Jitter          float64       // Randomization factor between 0 and 1 applied to every wait time
+	MaxElapsedTime  time.Duration // Give up once the next attempt would start after this time; 0 means no limit
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients. It makes up to 5 attempts
+// within one minute, starting with a wait time of half a second.
+func DefaultRetryPolicy() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts:     5,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+		MaxElapsedTime:  time.Minute,
+	}
+}
+
+func (p *BackoffRetryPolicy) Retry(a *RetryAttempt) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && a.Attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	if !IsRetryable(a) {
+		return 0, false
+	}
+
+	wait := backoff(p.InitialInterval, p.MaxInterval, p.Multiplier, p.Jitter, a.Attempt-1)
+	if p.MaxElapsedTime > 0 && a.Elapsed+wait > p.MaxElapsedTime {
+		return 0, false
+	}
+	return wait, true
+}
+
+// Returns the randomized wait time before the given (zero based) retry.
+func backoff(initial, max time.Duration, multiplier, jitter float64, retry int) time.Duration {
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(initial)
+	for i := 0; i < retry; i++ {
+		d *= multiplier
+		if max > 0 && d >= float64(max) {
+			break
+		}
+	}
+	if max > 0 && d > float64(max) {
+		d = float64(max)
+	}
+
+	if jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		d += d * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// Prefixes of commands that create something or otherwise are not safe to be executed twice
+var nonIdempotentPrefixes = []string{
+	"deploy",
+	"create",
+	"register",
+	"add",
+	"associateIpAddress",
+}
+
+// IsIdempotent returns false for commands that should not be repeated once the API may have
+// received them, as this could create resources twice. These are all deploy*, create*,
+// register* and add* commands, and associateIpAddress.
+func IsIdempotent(command string) bool {
+	for _, prefix := range nonIdempotentPrefixes {
+		if strings.HasPrefix(command, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsRetryable returns true if the failed attempt can safely be retried. Throttled requests
+// and DNS errors are always retryable. Timeouts, connection errors and 5xx HTTP responses
+// (except the CloudStack specific 53x API errors) are only retryable if the command is
+// idempotent, or if the request was not sent yet.
+func IsRetryable(a *RetryAttempt) bool {
+	err := a.Err
+	if err == nil {
+		return false
+	}
+
+	// The API refused to execute the command, so it is safe to repeat it
+	if IsThrottled(err) {
+		return true
+	}
+
+	// The expiry of the signature is corrected for the clock of the API server
+	var skewErr *ClockSkewError
+	if errors.As(err, &skewErr) {
+		return true
+	}
+
+	// We never got as far as connecting to the API
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	safe := !a.Sent || IsIdempotent(a.Command)
+
+	if e, ok := asCSError(err); ok {
+		switch e.HTTPStatus {
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return IsIdempotent(a.Command)
+		}
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return safe
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return safe
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"listVirtualMachines", true},
+		{"queryAsyncJobResult", true},
+		{"startVirtualMachine", true},
+		{"destroyVirtualMachine", true},
+		{"deployValueVirtualMachine", false},
+		{"deployPremiumVirtualMachine", false},
+		{"createVolume", false},
+		{"registerTemplate", false},
+		{"addNicToVirtualMachine", false},
+		{"associateIpAddress", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsIdempotent(tt.command); got != tt.want {
+			t.Errorf("IsIdempotent(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	timeout := &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	tests := []struct {
+		name    string
+		command string
+		err     error
+		sent    bool
+		want    bool
+	}{
+		{name: "no error", command: "listZones"},
+		{name: "throttled", command: "deployValueVirtualMachine", err: &CSError{HTTPStatus: http.StatusTooManyRequests}, sent: true, want: true},
+		{name: "request limit", command: "createVolume", err: &CSError{HTTPStatus: http.StatusServiceUnavailable, CSErrorCode: CSErrCodeRequestLimit}, sent: true, want: true},
+		{name: "clock skew", command: "createVolume", err: &ClockSkewError{Skew: time.Hour}, sent: true, want: true},
+		{name: "dns", command: "createVolume", err: &net.DNSError{Err: "no such host", Name: "api"}, want: true},
+		{name: "502 idempotent", command: "listZones", err: &CSError{HTTPStatus: http.StatusBadGateway}, sent: true, want: true},
+		{name: "502 not idempotent", command: "deployValueVirtualMachine", err: &CSError{HTTPStatus: http.StatusBadGateway}, sent: true},
+		{name: "503 idempotent", command: "listZones", err: &CSError{HTTPStatus: http.StatusServiceUnavailable}, sent: true, want: true},
+		{name: "530 api error", command: "listZones", err: &CSError{HTTPStatus: 530, ErrorCode: ErrCodeParamError}, sent: true},
+		{name: "404", command: "listZones", err: &CSError{HTTPStatus: http.StatusNotFound}, sent: true},
+		{name: "timeout idempotent", command: "listZones", err: timeout, sent: true, want: true},
+		{name: "timeout sent", command: "createVolume", err: timeout, sent: true},
+		{name: "timeout not sent", command: "createVolume", err: timeout, want: true},
+		{name: "reset idempotent", command: "listZones", err: reset, sent: true, want: true},
+		{name: "reset sent", command: "createVolume", err: reset, sent: true},
+		{name: "reset not sent", command: "createVolume", err: reset, want: true},
+		{name: "unexpected EOF sent", command: "createVolume", err: fmt.Errorf("reading: %w", io.ErrUnexpectedEOF), sent: true},
+		{name: "EOF idempotent", command: "listZones", err: io.EOF, sent: true, want: true},
+		{name: "context canceled", command: "listZones", err: context.Canceled},
+		{name: "other error", command: "listZones", err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &RetryAttempt{Command: tt.command, Attempt: 1, Err: tt.err, Sent: tt.sent}
+			if got := IsRetryable(a); got != tt.want {
+				t.Errorf("IsRetryable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffRetryPolicy(t *testing.T) {
+	p := &BackoffRetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Second,
+		MaxInterval:     3 * time.Second,
+		Multiplier:      2,
+		MaxElapsedTime:  10 * time.Second,
+	}
+	errBadGateway := &CSError{HTTPStatus: http.StatusBadGateway}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		elapsed  time.Duration
+		err      error
+		wantWait time.Duration
+		want     bool
+	}{
+		{name: "first retry", attempt: 1, err: errBadGateway, wantWait: time.Second, want: true},
+		{name: "second retry", attempt: 2, elapsed: time.Second, err: errBadGateway, wantWait: 2 * time.Second, want: true},
+		{name: "max attempts", attempt: 3, elapsed: 3 * time.Second, err: errBadGateway},
+		{name: "within max elapsed time", attempt: 2, elapsed: 8 * time.Second, err: errBadGateway, wantWait: 2 * time.Second, want: true},
+		{name: "past max elapsed time", attempt: 2, elapsed: 9 * time.Second, err: errBadGateway},
+		{name: "not retryable", attempt: 1, err: &CSError{HTTPStatus: http.StatusNotFound}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := p.Retry(&RetryAttempt{Command: "listZones", Attempt: tt.attempt, Elapsed: tt.elapsed, Err: tt.err, Sent: true})
+			if ok != tt.want || wait != tt.wantWait {
+				t.Errorf("Retry = %s, %v, want %s, %v", wait, ok, tt.wantWait, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBadGateway(t *testing.T) {
+	srv, cs := newTestClient(t, WithRetryPolicy(&BackoffRetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}))
+
+	// The API may have deployed the virtual machine, so the request must not be repeated
+	srv.FailNext("deployValueVirtualMachine", http.StatusBadGateway, ErrCodeInternalError, "Bad Gateway")
+	p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+	if _, err := cs.VirtualMachine.DeployValueVirtualMachine(p); err == nil {
+		t.Error("expected the 502 of deployValueVirtualMachine to be returned")
+	}
+	if n := srv.Count("deployValueVirtualMachine"); n != 1 {
+		t.Errorf("deployValueVirtualMachine was sent %d times, want 1", n)
+	}
+
+	srv.FailNext("listZones", http.StatusBadGateway, ErrCodeInternalError, "Bad Gateway")
+	if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+		t.Errorf("ListZones: %v", err)
+	}
+	if n := srv.Count("listZones"); n != 2 {
+		t.Errorf("listZones was sent %d times, want 2", n)
+	}
+}
+
+func TestRetryContextCancelledDuringBackoff(t *testing.T) {
+	srv, cs := newTestClient(t, WithRetryPolicy(&BackoffRetryPolicy{InitialInterval: time.Hour}))
+	srv.FailNext("listZones", http.StatusServiceUnavailable, ErrCodeInternalError, "Service Unavailable")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cs.AccountDomain.ListZonesWithContext(ctx, cs.AccountDomain.NewListZonesParams())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("returned after %s, want right after the context was done", d)
+	}
+	if n := srv.Count("listZones"); n != 1 {
+		t.Errorf("listZones was sent %d times, want 1", n)
+	}
+}
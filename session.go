@@ -0,0 +1,257 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authenticates the requests of a client with a session started by the login command, for
+// users that have a username and password but no API keys.
+type session struct {
+	username string
+	password string
+	domain   string
+
+	mu       sync.Mutex
+	key      string       // The session key; empty when not logged in
+	cookie   *http.Cookie // The JSESSIONID cookie of the session
+	inflight *loginCall   // The login in progress, if any
+}
+
+// A login shared by all requests that need a session while it is in progress
+type loginCall struct {
+	done chan struct{}
+	err  error
+}
+
+// Returns the session key and cookie to send with the next request, logging in first if
+// there is no session yet. Concurrent callers share a single login, and each of them stops
+// waiting for it as soon as its own ctx is done. s.mu is never held during the login.
+func (s *session) current(ctx context.Context, cs *KCPSClient) (string, *http.Cookie, error) {
+	for {
+		s.mu.Lock()
+		if s.key != "" {
+			key, cookie := s.key, s.cookie
+			s.mu.Unlock()
+			return key, cookie, nil
+		}
+
+		call := s.inflight
+		if call == nil {
+			call = &loginCall{done: make(chan struct{})}
+			s.inflight = call
+			s.mu.Unlock()
+
+			key, cookie, err := s.login(ctx, cs)
+
+			s.mu.Lock()
+			if err == nil {
+				s.key, s.cookie = key, cookie
+			}
+			s.inflight = nil
+			s.mu.Unlock()
+
+			call.err = err
+			close(call.done)
+			if err != nil {
+				return "", nil, err
+			}
+			return key, cookie, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		}
+
+		// A login that failed because the ctx of another caller was done is tried again
+		if call.err != nil && !isContextError(call.err) {
+			return "", nil, call.err
+		}
+	}
+}
+
+// Reports whether err was caused by a done context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Returns the key and cookie of the current session, without logging in.
+func (s *session) get() (string, *http.Cookie) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.key, s.cookie
+}
+
+// Drops the session with the given key after the API refused it, so the next request logs in
+// again. A session started in the meantime by a concurrent request is kept.
+func (s *session) expire(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key == key {
+		s.key = ""
+		s.cookie = nil
+	}
+}
+
+// Calls the login command and returns the key and cookie of the new session. The password is
+// sent in the body of a POST request, so it does not end up in any access log.
+func (s *session) login(ctx context.Context, cs *KCPSClient) (string, *http.Cookie, error) {
+	params := url.Values{}
+	params.Set("command", "login")
+	params.Set("response", "json")
+	params.Set("username", s.username)
+	params.Set("password", s.password)
+	if s.domain != "" {
+		params.Set("domain", s.domain)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cs.baseURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if cs.userAgent != "" {
+		req.Header.Set("User-Agent", cs.userAgent)
+	}
+
+	if cs.limiter != nil {
+		release, err := cs.limiter.Acquire(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		defer release()
+	}
+
+	var trace *ResponseInfo
+	if cs.tracing(ctx) {
+		trace = &ResponseInfo{RequestInfo: RequestInfo{Command: "login", Method: req.Method, Params: sanitizeParams(params), Attempt: 1}}
+		cs.traceRequest(ctx, &trace.RequestInfo)
+	}
+	start := time.Now()
+
+	resp, err := cs.client.Do(req)
+	if err != nil {
+		err = redactURLError(err)
+		cs.traceResponse(ctx, trace, start, nil, nil, err)
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	var key string
+	var cookie *http.Cookie
+	b, err := ioutil.ReadAll(io.TeeReader(resp.Body, &buf))
+	if err == nil {
+		key, cookie, err = s.loginResult(resp, params, b)
+	}
+	cs.traceResponse(ctx, trace, start, resp, &buf, err)
+	return key, cookie, err
+}
+
+func (s *session) loginResult(resp *http.Response, params url.Values, b []byte) (string, *http.Cookie, error) {
+	if resp.StatusCode != 200 {
+		return "", nil, newCSError(resp.StatusCode, "login", params, b)
+	}
+
+	var r LoginResponse
+	if err := decodeEnvelope(bytes.NewReader(b), &r); err != nil {
+		return "", nil, err
+	}
+	if r.Sessionkey == "" {
+		return "", nil, fmt.Errorf("Login of user %s did not return a session key", s.username)
+	}
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "JSESSIONID" {
+			cookie = &http.Cookie{Name: c.Name, Value: c.Value}
+		}
+	}
+	if cookie == nil {
+		return "", nil, fmt.Errorf("Login of user %s did not return a session cookie", s.username)
+	}
+	return r.Sessionkey, cookie, nil
+}
+
+type LoginResponse struct {
+	Account    string  `json:"account,omitempty"`
+	Domainid   string  `json:"domainid,omitempty"`
+	Firstname  string  `json:"firstname,omitempty"`
+	Lastname   string  `json:"lastname,omitempty"`
+	Sessionkey string  `json:"sessionkey,omitempty"`
+	Timeout    FlexInt `json:"timeout,omitempty"`
+	Type       string  `json:"type,omitempty"`
+	Userid     string  `json:"userid,omitempty"`
+	Username   string  `json:"username,omitempty"`
+}
+
+// NewClientWithLogin creates a new client which logs in with the given username and password,
+// see WithLogin. It logs in right away, so wrong credentials are reported here.
+func NewClientWithLogin(apiurl string, username string, password string, domain string, opts ...Option) (*KCPSClient, error) {
+	cs, err := New(apiurl, "", "", append([]Option{WithLogin(username, password, domain)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := cs.session.current(context.Background(), cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// Logout ends the session of a client created with WithLogin. It does nothing for clients
+// using API keys. The client logs in again when it is used afterwards.
+func (cs *KCPSClient) Logout(ctx context.Context) error {
+	if cs.session == nil {
+		return nil
+	}
+
+	key, cookie := cs.session.get()
+	if key == "" {
+		return nil
+	}
+
+	// Sent as a single request with the session read above, as logging in again just to log
+	// out makes no sense. A session the API does not know anymore has ended already.
+	var r json.RawMessage
+	_, err := cs.sendRequest(ctx, "logout", url.Values{}, &r, 1, key, cookie)
+	cs.session.expire(key)
+	if IsAuthError(err) {
+		return nil
+	}
+	return err
+}
+
+// Close releases the resources held by the client, which means it logs out of the session of
+// a client created with WithLogin.
+func (cs *KCPSClient) Close() error {
+	return cs.Logout(context.Background())
+}
@@ -0,0 +1,261 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uesyn/gokcps/gokcpstest"
+)
+
+// Returns a hook recording the commands of all requests, and a func returning them.
+func recordCommands() (BeforeRequestHook, func() []string) {
+	var (
+		mu       sync.Mutex
+		commands []string
+	)
+	record := func(ctx context.Context, r *RequestInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		commands = append(commands, r.Command)
+	}
+	return record, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), commands...)
+	}
+}
+
+// Returns a client logged in to srv, and a func returning the commands it sent so far.
+func newLoginClient(t *testing.T, srv *gokcpstest.Server) (*KCPSClient, func() []string) {
+	t.Helper()
+
+	record, commands := recordCommands()
+	cs, err := NewClientWithLogin(srv.URL, srv.Username, srv.Password, "", WithBeforeRequest(record))
+	if err != nil {
+		t.Fatalf("NewClientWithLogin: %v", err)
+	}
+	return cs, commands
+}
+
+// Counts the occurrences of command in commands.
+func countCommand(commands []string, command string) int {
+	n := 0
+	for _, c := range commands {
+		if c == command {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSessionRelogin(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+	cs, commands := newLoginClient(t, srv)
+
+	if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+		t.Fatalf("ListZones: %v", err)
+	}
+	srv.ExpireSessions()
+	if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+		t.Fatalf("ListZones after the session expired: %v", err)
+	}
+
+	want := []string{"login", "listZones", "listZones", "login", "listZones"}
+	if got := commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("commands = %v, want %v", got, want)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name   string
+		expire bool
+	}{
+		{name: "active session"},
+		{name: "expired session", expire: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := gokcpstest.NewServer()
+			defer srv.Close()
+			cs, commands := newLoginClient(t, srv)
+
+			if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+				t.Fatalf("ListZones: %v", err)
+			}
+			if tt.expire {
+				srv.ExpireSessions()
+			}
+			if err := cs.Logout(context.Background()); err != nil {
+				t.Fatalf("Logout: %v", err)
+			}
+
+			want := []string{"login", "listZones", "logout"}
+			if got := commands(); !reflect.DeepEqual(got, want) {
+				t.Errorf("commands = %v, want %v", got, want)
+			}
+			if key := cs.session.key; key != "" {
+				t.Errorf("session key = %q after Logout, want none", key)
+			}
+
+			// Logging out again does not send anything
+			if err := cs.Logout(context.Background()); err != nil {
+				t.Fatalf("second Logout: %v", err)
+			}
+			if got := commands(); len(got) != len(want) {
+				t.Errorf("commands = %v after the second Logout, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLogoutConcurrentExpiry(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+
+	for i := 0; i < 50; i++ {
+		cs, commands := newLoginClient(t, srv)
+		key, _ := cs.session.get()
+
+		// A concurrent request finding the session expired must not make Logout log in again
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cs.session.expire(key)
+		}()
+		if err := cs.Logout(context.Background()); err != nil {
+			t.Fatalf("Logout: %v", err)
+		}
+		wg.Wait()
+
+		if n := countCommand(commands(), "login"); n != 1 {
+			t.Fatalf("commands = %v, want a single login", commands())
+		}
+		if key, _ := cs.session.get(); key != "" {
+			t.Fatalf("session key = %q after Logout, want none", key)
+		}
+	}
+}
+
+func TestSessionSingleLogin(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+
+	record, commands := recordCommands()
+	cs, err := New(srv.URL, "", "", WithLogin(srv.Username, srv.Password, ""), WithBeforeRequest(record))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+				t.Errorf("ListZones: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := countCommand(commands(), "login"); n != 1 {
+		t.Errorf("logged in %d times for concurrent requests, want once", n)
+	}
+}
+
+func TestSessionLoginWaitersUseOwnContext(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+
+	// Holds the login until release is closed
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("command") == "login" {
+			<-release
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	defer slow.Close()
+
+	cs, err := New(slow.URL, "", "", WithLogin(srv.Username, srv.Password, ""))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+		first <- err
+	}()
+	for {
+		cs.session.mu.Lock()
+		started := cs.session.inflight != nil
+		cs.session.mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Neither expire nor a caller with a short deadline are blocked by the login in progress
+	cs.session.expire("unknown")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := cs.AccountDomain.ListZonesWithContext(ctx, cs.AccountDomain.NewListZonesParams()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the deadline of the waiting caller", err)
+	}
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Errorf("ListZones of the first caller: %v", err)
+	}
+}
+
+func TestLoginOptions(t *testing.T) {
+	srv := gokcpstest.NewServer()
+	defer srv.Close()
+
+	// The options of the caller are not written to
+	opts := make([]Option, 1, 4)
+	opts[0] = WithAsync(true)
+	if _, err := NewClientWithLogin(srv.URL, srv.Username, srv.Password, "", opts...); err != nil {
+		t.Fatalf("NewClientWithLogin: %v", err)
+	}
+	if spare := opts[:2][1]; spare != nil {
+		t.Error("NewClientWithLogin wrote into the spare capacity of the options")
+	}
+
+	if _, err := New(srv.URL, srv.APIKey, srv.SecretKey, WithLogin(srv.Username, srv.Password, "")); err == nil {
+		t.Error("New accepted WithLogin together with API keys")
+	}
+	creds := WithCredentials(StaticProvider{APIURL: srv.URL, APIKey: srv.APIKey, SecretKey: srv.SecretKey})
+	if _, err := NewClientWithLogin(srv.URL, srv.Username, srv.Password, "", creds); err == nil {
+		t.Error("NewClientWithLogin accepted WithCredentials")
+	}
+}
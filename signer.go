@@ -0,0 +1,112 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ExpiresLayout is the layout of the expires parameter of requests signed with signature version 3.
+const ExpiresLayout = "2006-01-02T15:04:05-0700"
+
+// Errors returned by Signer.Verify
+var (
+	ErrInvalidSignature = errors.New("Invalid request signature")
+	ErrSignatureExpired = errors.New("Request signature expired")
+)
+
+// Signer signs API requests the way the API verifies them. It is used by the client, and can
+// be used to sign or verify requests elsewhere, e.g. in a proxy.
+type Signer struct {
+	APIKey    string
+	SecretKey string
+
+	// Expiry makes Sign add signatureVersion=3 and an expires parameter, so the API rejects
+	// the request when it arrives after the expiry. 0 means the signature never expires.
+	Expiry time.Duration
+
+	// ClockOffset is added to the local time when computing the expiry, to make up for a
+	// local clock that is off compared to the clock of the API server.
+	ClockOffset time.Duration
+}
+
+// Sign adds the apiKey parameter and, when Expiry is set, the signatureVersion and expires
+// parameters to params, and returns the signature of the result. The signature is not added.
+func (s *Signer) Sign(params url.Values) string {
+	params.Set("apiKey", s.APIKey)
+	if s.Expiry > 0 {
+		params.Set("signatureVersion", "3")
+		params.Set("expires", time.Now().Add(s.ClockOffset+s.Expiry).UTC().Format(ExpiresLayout))
+	}
+	return s.Signature(params)
+}
+
+// Signature returns the signature of params, leaving out the signature parameter if present.
+func (s *Signer) Signature(params url.Values) string {
+	if _, ok := params["signature"]; ok {
+		p := make(url.Values, len(params))
+		for k, v := range params {
+			if k != "signature" {
+				p[k] = v
+			}
+		}
+		params = p
+	}
+
+	// Generate signature for API call
+	// * Serialize parameters, URL encoding only values and sort them by key, done by encodeValues
+	// * Convert the entire argument string to lowercase
+	// * Replace all instances of '+' to '%20'
+	// * Calculate HMAC SHA1 of argument string with CloudStack secret
+	// * URL encode the string and convert to base64
+	s1 := encodeValues(params)
+	s2 := strings.ToLower(s1)
+	s3 := strings.Replace(s2, "+", "%20", -1)
+	mac := hmac.New(sha1.New, []byte(s.SecretKey))
+	mac.Write([]byte(s3))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the apiKey and signature parameters of a signed request. Requests signed
+// with signature version 3 must not have expired, compared with the local time plus the
+// ClockOffset.
+func (s *Signer) Verify(params url.Values) error {
+	signature := params.Get("signature")
+	if params.Get("apiKey") != s.APIKey || signature == "" {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.Signature(params))) {
+		return ErrInvalidSignature
+	}
+
+	if params.Get("signatureVersion") == "3" {
+		expires, err := time.Parse(ExpiresLayout, params.Get("expires"))
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		if time.Now().Add(s.ClockOffset).After(expires) {
+			return ErrSignatureExpired
+		}
+	}
+	return nil
+}
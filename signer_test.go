@@ -0,0 +1,143 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignerExpiry(t *testing.T) {
+	s := &Signer{APIKey: "key", SecretKey: "secret", Expiry: time.Minute}
+
+	p := url.Values{"command": {"listZones"}, "response": {"json"}}
+	p.Set("signature", s.Sign(p))
+	if p.Get("signatureVersion") != "3" {
+		t.Errorf("signatureVersion = %q, want 3", p.Get("signatureVersion"))
+	}
+	expires, err := time.Parse(ExpiresLayout, p.Get("expires"))
+	if err != nil {
+		t.Fatalf("expires %q: %v", p.Get("expires"), err)
+	}
+	if d := time.Until(expires); d < 58*time.Second || d > time.Minute {
+		t.Errorf("expires in %s, want in a minute", d)
+	}
+	if err := s.Verify(p); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	// Both parameters are part of the signature, so they cannot be changed or dropped
+	tests := []struct {
+		name   string
+		change func(url.Values)
+	}{
+		{name: "later expiry", change: func(p url.Values) {
+			p.Set("expires", time.Now().Add(time.Hour).UTC().Format(ExpiresLayout))
+		}},
+		{name: "no expiry", change: func(p url.Values) { p.Del("expires") }},
+		{name: "no signature version", change: func(p url.Values) { p.Del("signatureVersion") }},
+		{name: "other signature version", change: func(p url.Values) { p.Set("signatureVersion", "2") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := make(url.Values)
+			for k, v := range p {
+				q[k] = v
+			}
+			tt.change(q)
+			if err := s.Verify(q); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+
+	// A signature that is checked after it expired
+	late := &Signer{APIKey: "key", SecretKey: "secret", ClockOffset: 2 * time.Minute}
+	if err := late.Verify(p); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Verify after the expiry = %v, want ErrSignatureExpired", err)
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	var retries int
+	srv, cs := newTestClient(t,
+		WithSignatureExpiry(time.Minute),
+		WithRetryPolicy(NoRetry),
+		WithRetryHook(func(*RetryAttempt, time.Duration) { retries++ }),
+	)
+	srv.SetClockOffset(time.Hour)
+
+	// The server refuses the signature as expired, which is reported as the skew of its clock
+	_, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	var skewErr *ClockSkewError
+	if !errors.As(err, &skewErr) {
+		t.Fatalf("err = %v, want a *ClockSkewError", err)
+	}
+	if !IsAuthError(err) {
+		t.Errorf("err = %v, want it to wrap the 401 of the API", err)
+	}
+	if d := skewErr.Skew - time.Hour; d < -maxClockSkew || d > maxClockSkew {
+		t.Errorf("skew = %s, want about an hour", skewErr.Skew)
+	}
+	if d := cs.ClockSkew() - time.Hour; d < -maxClockSkew || d > maxClockSkew {
+		t.Errorf("ClockSkew = %s, want about an hour", cs.ClockSkew())
+	}
+	if retries != 0 {
+		t.Errorf("retried %d times with NoRetry", retries)
+	}
+
+	// The following requests expire relative to the clock of the server
+	if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+		t.Fatalf("ListZones after the skew was detected: %v", err)
+	}
+}
+
+func TestClockSkewRetry(t *testing.T) {
+	var retries []error
+	srv, cs := newTestClient(t,
+		WithSignatureExpiry(time.Minute),
+		WithRetryHook(func(a *RetryAttempt, _ time.Duration) { retries = append(retries, a.Err) }),
+	)
+	srv.SetClockOffset(time.Hour)
+
+	if _, err := cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams()); err != nil {
+		t.Fatalf("ListZones: %v", err)
+	}
+	if len(retries) != 1 || !errors.As(retries[0], new(*ClockSkewError)) {
+		t.Errorf("retries = %v, want a single retry after a *ClockSkewError", retries)
+	}
+}
+
+func TestUnauthorizedWithoutSkew(t *testing.T) {
+	var retries int
+	srv, _ := newTestClient(t)
+	cs, err := New(srv.URL, srv.APIKey, "wrong", WithSignatureExpiry(time.Minute),
+		WithRetryHook(func(*RetryAttempt, time.Duration) { retries++ }))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = cs.AccountDomain.ListZones(cs.AccountDomain.NewListZonesParams())
+	if !IsAuthError(err) || errors.As(err, new(*ClockSkewError)) {
+		t.Errorf("err = %v, want a plain auth error", err)
+	}
+	if retries != 0 || cs.ClockSkew() != 0 {
+		t.Errorf("retried %d times with a skew of %s, want no retry and no skew", retries, cs.ClockSkew())
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Condition reports the current state of whatever is waited for, and whether the wait is
+// over. Returning an error ends the wait with that error.
+type Condition func(ctx context.Context) (state string, done bool, err error)
+
+// WaitFor calls cond until it reports done and returns the last state it reported. The
+// calls are spaced, and the wait is limited, the way w polls async jobs; w may be nil to
+// use the JobWaiter configured for the client. If the wait times out, including when the
+// deadline of ctx is exceeded, the error is a *WaitTimeoutError.
+//
+// WaitFor is meant for changes the API does not report with an async job, e.g. a template
+// that is still being downloaded after registerTemplate returned.
+func (cs *KCPSClient) WaitFor(ctx context.Context, w *JobWaiter, cond Condition) (string, error) {
+	return cs.waitFor(ctx, w, "", "", cond)
+}
+
+func (cs *KCPSClient) waitFor(ctx context.Context, w *JobWaiter, kind, id string, cond Condition) (string, error) {
+	if w == nil {
+		w = &cs.jobWaiter
+	}
+
+	start := time.Now()
+	var state string
+	for poll := 0; ; poll++ {
+		s, done, err := cond(ctx)
+		if err != nil {
+			return state, waitError(ctx, kind, id, state, start, err)
+		}
+		state = s
+		if done {
+			return state, nil
+		}
+
+		wait := backoff(w.InitialInterval, w.MaxInterval, w.Multiplier, w.Jitter, poll)
+		if w.Timeout > 0 {
+			// Make sure to check one last time when the timeout is reached
+			remaining := w.Timeout - time.Since(start)
+			if remaining <= 0 {
+				return state, &WaitTimeoutError{Kind: kind, ID: id, State: state, Timeout: w.Timeout}
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return state, waitError(ctx, kind, id, state, start, err)
+		}
+	}
+}
+
+// Like jobWaitError, for the waits of WaitFor.
+func waitError(ctx context.Context, kind, id, state string, start time.Time, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &WaitTimeoutError{Kind: kind, ID: id, State: state, Timeout: time.Since(start), Err: ctx.Err()}
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2016, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gokcps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/uesyn/gokcps/gokcpstest"
+)
+
+// Deploys a virtual machine on srv and returns its ID.
+func newTestVirtualMachine(t *testing.T, srv *gokcpstest.Server, cs *KCPSClient) string {
+	t.Helper()
+	p := cs.VirtualMachine.NewDeployValueVirtualMachineParams(srv.ServiceOfferingID, srv.TemplateID, srv.ZoneID, "vm1")
+	j, err := cs.VirtualMachine.DeployValueVirtualMachineJob(context.Background(), p)
+	if err != nil {
+		t.Fatalf("DeployValueVirtualMachineJob: %v", err)
+	}
+	vm, err := j.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	return vm.Id
+}
+
+func TestWaitUntilVirtualMachineStopped(t *testing.T) {
+	srv, cs := newTestClient(t, WithJobWaiter(JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1}))
+	id := newTestVirtualMachine(t, srv, cs)
+
+	srv.SetJobDelay(50 * time.Millisecond)
+	if _, err := cs.VirtualMachine.StopVirtualMachine(cs.VirtualMachine.NewStopVirtualMachineParams(id)); err != nil {
+		t.Fatalf("StopVirtualMachine: %v", err)
+	}
+	vm, err := cs.VirtualMachine.WaitUntilVirtualMachineStopped(context.Background(), id, nil)
+	if err != nil {
+		t.Fatalf("WaitUntilVirtualMachineStopped: %v", err)
+	}
+	if vm.State != "Stopped" {
+		t.Errorf("state = %s, want Stopped", vm.State)
+	}
+	if n := srv.Count("listVirtualMachines"); n < 2 {
+		t.Errorf("listVirtualMachines was sent %d times, want the state to be polled", n)
+	}
+}
+
+func TestWaitUntilVirtualMachineTimeout(t *testing.T) {
+	srv, cs := newTestClient(t)
+	id := newTestVirtualMachine(t, srv, cs)
+
+	srv.SetJobDelay(time.Hour)
+	if _, err := cs.VirtualMachine.StopVirtualMachine(cs.VirtualMachine.NewStopVirtualMachineParams(id)); err != nil {
+		t.Fatalf("StopVirtualMachine: %v", err)
+	}
+
+	w := &JobWaiter{InitialInterval: 10 * time.Millisecond, Multiplier: 1, Timeout: 50 * time.Millisecond}
+	_, err := cs.VirtualMachine.WaitUntilVirtualMachineStopped(context.Background(), id, w)
+	var e *WaitTimeoutError
+	if !errors.As(err, &e) {
+		t.Fatalf("err = %v, want a *WaitTimeoutError", err)
+	}
+	if e.Kind != "VirtualMachine" || e.ID != id || e.State != "Stopping" || e.Timeout != w.Timeout {
+		t.Errorf("err = %+v, want a timeout of %s for VirtualMachine %s in state Stopping", e, w.Timeout, id)
+	}
+
+	// The deadline of the context is reported the same way
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	w.Timeout = 0
+	_, err = cs.VirtualMachine.WaitUntilVirtualMachineStopped(ctx, id, w)
+	if !errors.As(err, &e) || !errors.Is(err, context.DeadlineExceeded) || e.State != "Stopping" {
+		t.Errorf("err = %v, want a *WaitTimeoutError wrapping context.DeadlineExceeded", err)
+	}
+}
+
+func TestWaitUntilVirtualMachineUnexpectedState(t *testing.T) {
+	// Reports every listed virtual machine in state Error, which the fake API never does
+	broken := func(next Handler) Handler {
+		return func(ctx context.Context, cmd Command, out interface{}) error {
+			err := next(ctx, cmd, out)
+			if r, ok := out.(*ListVirtualMachinesResponse); ok && err == nil {
+				for _, vm := range r.VirtualMachines {
+					vm.State = "Error"
+				}
+			}
+			return err
+		}
+	}
+	srv, cs := newTestClient(t, WithMiddleware(broken))
+	id := newTestVirtualMachine(t, srv, cs)
+
+	_, err := cs.VirtualMachine.WaitUntilVirtualMachineRunning(context.Background(), id, nil)
+	var e *UnexpectedStateError
+	if !errors.As(err, &e) || e.Kind != "VirtualMachine" || e.ID != id || e.State != "Error" {
+		t.Errorf("err = %v, want an *UnexpectedStateError for state Error", err)
+	}
+	if n := srv.Count("listVirtualMachines"); n != 1 {
+		t.Errorf("listVirtualMachines was sent %d times, want the wait to end at once", n)
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	_, cs := newTestClient(t)
+	w := &JobWaiter{InitialInterval: time.Millisecond, Multiplier: 1, Timeout: time.Second}
+
+	calls := 0
+	state, err := cs.WaitFor(context.Background(), w, func(ctx context.Context) (string, bool, error) {
+		calls++
+		return "step", calls == 3, nil
+	})
+	if err != nil || state != "step" || calls != 3 {
+		t.Errorf("WaitFor = %q, %v after %d calls, want step after 3 calls", state, err, calls)
+	}
+
+	errBoom := errors.New("boom")
+	if _, err := cs.WaitFor(context.Background(), w, func(ctx context.Context) (string, bool, error) {
+		return "", false, errBoom
+	}); !errors.Is(err, errBoom) {
+		t.Errorf("err = %v, want the error of the condition", err)
+	}
+}